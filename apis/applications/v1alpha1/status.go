@@ -31,6 +31,94 @@ type ArgoApplicationStatus struct {
 	ResourceHealthSource string `json:"resourceHealthSource,omitempty" protobuf:"bytes,11,opt,name=resourceHealthSource"`
 	// SourceTypes specifies the type of the sources included in the application
 	SourceTypes []ApplicationSourceType `json:"sourceTypes,omitempty" protobuf:"bytes,12,opt,name=sourceTypes"`
+	// LastSyncRequest is the value of the sync annotation which last triggered a sync of the application
+	// +optional
+	LastSyncRequest *string `json:"lastSyncRequest,omitempty"`
+	// LastSyncRequestTime is the time the sync for LastSyncRequest was triggered
+	// +optional
+	LastSyncRequestTime *metav1.Time `json:"lastSyncRequestTime,omitempty"`
+	// OperationPhase is the phase of the current or last operation, e.g. Running, Succeeded or Failed
+	// +optional
+	OperationPhase *string `json:"operationPhase,omitempty"`
+	// OperationMessage is the message of the current or last operation, typically an error
+	// +optional
+	OperationMessage *string `json:"operationMessage,omitempty"`
+	// SyncRevision is the revision the current or last sync operation syncs to
+	// +optional
+	SyncRevision *string `json:"syncRevision,omitempty"`
+	// ResolvedRevision is the commit the targetRevision of the source resolved to when the application
+	// was last observed. Only set if resolution is enabled with the argocd.crossplane.io/resolve-target-revision
+	// annotation and the targetRevision is a symbolic ref like HEAD, a branch or a tag.
+	// +optional
+	ResolvedRevision *string `json:"resolvedRevision,omitempty"`
+	// ResolvedRevisions are the revisions the targetRevisions of the sources resolved to when the
+	// application was last compared by ArgoCD, in the order of the sources. Only set if resolution is
+	// enabled with the argocd.crossplane.io/resolve-target-revision annotation. Sources of Helm charts
+	// have an empty revision.
+	// +optional
+	ResolvedRevisions []string `json:"resolvedRevisions,omitempty"`
+	// SyncWaves summarizes the progress of the resources of the application by sync wave, lowest wave
+	// first. Only set if the application has resources in more than the default wave.
+	// +optional
+	SyncWaves []SyncWaveSummary `json:"syncWaves,omitempty"`
+	// CurrentSyncWave is the lowest sync wave whose resources are not all synced and healthy while
+	// an operation is running
+	// +optional
+	CurrentSyncWave *int64 `json:"currentSyncWave,omitempty"`
+	// HelmValuesHash is the hash of the Helm values resolved from the valuesRef of the sources when the
+	// application was last observed
+	// +optional
+	HelmValuesHash *string `json:"helmValuesHash,omitempty"`
+	// Notifications are the most recent notifications the ArgoCD notifications controller delivered for
+	// the application, most recent first. ArgoCD only records successful deliveries, a failed delivery
+	// is retried and missing until it succeeds.
+	// +optional
+	Notifications []NotificationDelivery `json:"notifications,omitempty"`
+	// OutOfSyncSince is the time the application last synced successfully while it is out of sync, or
+	// the time it was created if it never synced. Not set while the application is in sync.
+	// +optional
+	OutOfSyncSince *metav1.Time `json:"outOfSyncSince,omitempty"`
+	// OutOfSyncDuration is how long the application has been out of sync since OutOfSyncSince as of
+	// the last observation
+	// +optional
+	OutOfSyncDuration *metav1.Duration `json:"outOfSyncDuration,omitempty"`
+	// ResourceCount is the number of Kubernetes resources managed by the application. Not set until
+	// ArgoCD reconciled the application.
+	// +optional
+	ResourceCount *int32 `json:"resourceCount,omitempty"`
+	// LastError is the error returned by the ArgoCD API on the last failed create, update or delete.
+	// It is cleared once the application was written successfully or is up to date.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+}
+
+// NotificationDelivery is a notification delivered by the ArgoCD notifications controller
+type NotificationDelivery struct {
+	// Trigger is the name of the trigger which sent the notification, e.g. on-sync-succeeded
+	Trigger string `json:"trigger"`
+	// Service is the name of the notification service, e.g. slack
+	Service string `json:"service"`
+	// Recipient is the recipient of the notification service, e.g. a channel
+	Recipient string `json:"recipient"`
+	// DeliveredAt is the time the notification was delivered
+	DeliveredAt metav1.Time `json:"deliveredAt"`
+}
+
+// SyncWaveSummary summarizes the resources of an application in a sync wave
+type SyncWaveSummary struct {
+	// Wave is the sync wave of the resources
+	Wave int64 `json:"wave"`
+	// Resources is the number of resources in the wave
+	Resources int32 `json:"resources"`
+	// Synced is the number of resources in the wave which the current or last operation synced
+	Synced int32 `json:"synced"`
+	// Failed is the number of resources in the wave which the current or last operation failed to sync
+	Failed int32 `json:"failed"`
+	// Healthy is the number of healthy resources in the wave
+	Healthy int32 `json:"healthy"`
 }
 
 // RevisionHistories contains information about the application's sync history
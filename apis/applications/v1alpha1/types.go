@@ -49,6 +49,9 @@ type ApplicationParameters struct {
 	// Sources is a reference to the location of the application's manifests or chart
 	Sources ApplicationSources `json:"sources,omitempty" protobuf:"bytes,8,opt,name=sources"`
 
+	// Labels that will be applied to the ArgoCD Application
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,11,opt,name=labels"`
+
 	// Annotations that will be applied to the ArgoCD Application
 	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,12,opt,name=annotations"`
 
@@ -150,6 +153,12 @@ type ConnectionState struct {
 type ApplicationSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ApplicationParameters `json:"forProvider"`
+
+	// DependsOn references Applications which have to be ready before this
+	// Application is created. An Application is only deleted once no
+	// Application depending on it exists anymore.
+	// +optional
+	DependsOn []xpv1.Reference `json:"dependsOn,omitempty"`
 }
 
 // A ApplicationStatus represents the observed state of an ArgoCD Application.
@@ -180,6 +189,25 @@ type ApplicationSourceHelm struct {
 	SkipCrds *bool `json:"skipCrds,omitempty" protobuf:"bytes,9,opt,name=skipCrds"`
 	// ValuesObject specifies Helm values to be passed to helm template, defined as a map. This takes precedence over Values.
 	ValuesObject extv1.JSON `json:"valuesObject,omitempty" protobuf:"bytes,10,opt,name=valuesObject"`
+	// ValuesRef references a key of a ConfigMap or Secret whose content is passed as Values to helm
+	// template. Only the hash of the content is compared with the values of the application.
+	// +optional
+	ValuesRef *HelmValuesReference `json:"valuesRef,omitempty"`
+}
+
+// HelmValuesReference references a key of a ConfigMap or Secret holding Helm values
+type HelmValuesReference struct {
+	// Kind of the referenced object
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+	// Name of the referenced object
+	Name string `json:"name"`
+	// Namespace of the referenced object
+	Namespace string `json:"namespace"`
+	// Key of the values in the referenced object
+	// +kubebuilder:default=values.yaml
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // HelmParameter is a parameter that's passed to helm template during manifest generation
@@ -216,6 +244,16 @@ type Application struct {
 	Status ApplicationStatus `json:"status,omitempty"`
 }
 
+// GetLastError returns the last error returned by the ArgoCD API for the application and the time it occurred
+func (mg *Application) GetLastError() (*string, *metav1.Time) {
+	return mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime
+}
+
+// SetLastError sets the last error returned by the ArgoCD API for the application and the time it occurred
+func (mg *Application) SetLastError(msg *string, t *metav1.Time) {
+	mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime = msg, t
+}
+
 // +kubebuilder:object:root=true
 
 // ApplicationList contains a list of Application items
@@ -281,7 +319,10 @@ type SyncOptions []string
 type SyncPolicy struct {
 	// Automated will keep an application synced to the target revision
 	Automated *SyncPolicyAutomated `json:"automated,omitempty" protobuf:"bytes,1,opt,name=automated"`
-	// Options allow you to specify whole app sync-options
+	// Options allow you to specify whole app sync-options in the format <name>=<value>, i.e.
+	// Validate, CreateNamespace, PruneLast, Replace, ServerSideApply, ApplyOutOfSyncOnly,
+	// RespectIgnoreDifferences and FailOnSharedResource set to true or false, and
+	// PrunePropagationPolicy set to foreground, background or orphan. Their order is irrelevant.
 	SyncOptions SyncOptions `json:"syncOptions,omitempty" protobuf:"bytes,2,opt,name=syncOptions"`
 	// Retry controls failed sync retry behavior
 	Retry *RetryStrategy `json:"retry,omitempty" protobuf:"bytes,3,opt,name=retry"`
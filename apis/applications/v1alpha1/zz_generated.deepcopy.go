@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -191,6 +192,13 @@ func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -354,6 +362,11 @@ func (in *ApplicationSourceHelm) DeepCopyInto(out *ApplicationSourceHelm) {
 		**out = **in
 	}
 	in.ValuesObject.DeepCopyInto(&out.ValuesObject)
+	if in.ValuesRef != nil {
+		in, out := &in.ValuesRef, &out.ValuesRef
+		*out = new(HelmValuesReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSourceHelm.
@@ -594,6 +607,13 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]v1.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -693,6 +713,85 @@ func (in *ArgoApplicationStatus) DeepCopyInto(out *ArgoApplicationStatus) {
 		*out = make([]ApplicationSourceType, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastSyncRequest != nil {
+		in, out := &in.LastSyncRequest, &out.LastSyncRequest
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastSyncRequestTime != nil {
+		in, out := &in.LastSyncRequestTime, &out.LastSyncRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.OperationPhase != nil {
+		in, out := &in.OperationPhase, &out.OperationPhase
+		*out = new(string)
+		**out = **in
+	}
+	if in.OperationMessage != nil {
+		in, out := &in.OperationMessage, &out.OperationMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncRevision != nil {
+		in, out := &in.SyncRevision, &out.SyncRevision
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResolvedRevision != nil {
+		in, out := &in.ResolvedRevision, &out.ResolvedRevision
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResolvedRevisions != nil {
+		in, out := &in.ResolvedRevisions, &out.ResolvedRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncWaves != nil {
+		in, out := &in.SyncWaves, &out.SyncWaves
+		*out = make([]SyncWaveSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentSyncWave != nil {
+		in, out := &in.CurrentSyncWave, &out.CurrentSyncWave
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HelmValuesHash != nil {
+		in, out := &in.HelmValuesHash, &out.HelmValuesHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OutOfSyncSince != nil {
+		in, out := &in.OutOfSyncSince, &out.OutOfSyncSince
+		*out = (*in).DeepCopy()
+	}
+	if in.OutOfSyncDuration != nil {
+		in, out := &in.OutOfSyncDuration, &out.OutOfSyncDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ResourceCount != nil {
+		in, out := &in.ResourceCount, &out.ResourceCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoApplicationStatus.
@@ -893,6 +992,21 @@ func (in *HelmParameter) DeepCopy() *HelmParameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmValuesReference) DeepCopyInto(out *HelmValuesReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmValuesReference.
+func (in *HelmValuesReference) DeepCopy() *HelmValuesReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmValuesReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Info) DeepCopyInto(out *Info) {
 	*out = *in
@@ -1036,6 +1150,22 @@ func (in *ManagedNamespaceMetadata) DeepCopy() *ManagedNamespaceMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationDelivery) DeepCopyInto(out *NotificationDelivery) {
+	*out = *in
+	in.DeliveredAt.DeepCopyInto(&out.DeliveredAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDelivery.
+func (in *NotificationDelivery) DeepCopy() *NotificationDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Operation) DeepCopyInto(out *Operation) {
 	*out = *in
@@ -1725,3 +1855,18 @@ func (in *SyncStrategyHook) DeepCopy() *SyncStrategyHook {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncWaveSummary) DeepCopyInto(out *SyncWaveSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncWaveSummary.
+func (in *SyncWaveSummary) DeepCopy() *SyncWaveSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncWaveSummary)
+	in.DeepCopyInto(out)
+	return out
+}
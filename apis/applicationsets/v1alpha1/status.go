@@ -6,6 +6,13 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 type ArgoApplicationSetStatus struct {
 	Conditions        []ApplicationSetCondition         `json:"conditions,omitempty" protobuf:"bytes,1,name=conditions"`
 	ApplicationStatus []ApplicationSetApplicationStatus `json:"applicationStatus,omitempty" protobuf:"bytes,2,name=applicationStatus"`
+	// LastError is the error returned by the ArgoCD API on the last failed create, update or delete.
+	// It is cleared once the application set was written successfully or is up to date.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
 }
 
 // ApplicationSetCondition contains details about an applicationset condition, which is usually an error or warning
@@ -105,6 +105,14 @@ type ApplicationSetSyncPolicy struct {
 // If no ApplicationsSyncPolicy is defined, it defaults it to sync
 type ApplicationsSyncPolicy string
 
+// Policies applied on the generated applications
+const (
+	ApplicationsSyncPolicyCreateOnly   ApplicationsSyncPolicy = "create-only"
+	ApplicationsSyncPolicyCreateUpdate ApplicationsSyncPolicy = "create-update"
+	ApplicationsSyncPolicyCreateDelete ApplicationsSyncPolicy = "create-delete"
+	ApplicationsSyncPolicySync         ApplicationsSyncPolicy = "sync"
+)
+
 // MergeGenerator merges the output of two or more generators. Where the values for all specified merge keys are equal
 // between two sets of generated parameters, the parameter sets will be merged with the parameters from the latter
 // generator taking precedence. Parameter sets with merge keys not present in the base generator's params will be
@@ -592,6 +600,16 @@ type ApplicationSet struct {
 	Status ApplicationSetStatus `json:"status,omitempty"`
 }
 
+// GetLastError returns the last error returned by the ArgoCD API for the application set and the time it occurred
+func (mg *ApplicationSet) GetLastError() (*string, *metav1.Time) {
+	return mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime
+}
+
+// SetLastError sets the last error returned by the ArgoCD API for the application set and the time it occurred
+func (mg *ApplicationSet) SetLastError(msg *string, t *metav1.Time) {
+	mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime = msg, t
+}
+
 // +kubebuilder:object:root=true
 
 // ApplicationSetList contains a list of ApplicationSet
@@ -25,6 +25,7 @@ import (
 	clusterv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
 	projectsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
 	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+	settingsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 )
 
@@ -37,6 +38,7 @@ func init() {
 		clusterv1alpha1.SchemeBuilder.AddToScheme,
 		applicationv1alpha1.SchemeBuilder.AddToScheme,
 		applicationsetsv1alpha1.SchemeBuilder.AddToScheme,
+		settingsv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
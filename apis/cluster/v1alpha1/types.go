@@ -33,12 +33,20 @@ type ClusterParameters struct {
 	// Config holds cluster information for connecting to a cluster
 	Config ClusterConfig `json:"config"`
 	// Holds list of namespaces which are accessible in that cluster. Cluster level resources will be ignored if namespace list is not empty.
+	// An empty list means all namespaces. The namespaces of the cluster in ArgoCD are adopted if omitted.
+	// Their order is irrelevant and changing them updates the cluster in place.
 	// +optional
-	Namespaces []string `json:"namespaces,omitempty"`
+	Namespaces []string `json:"namespaces"`
 	// Shard contains optional shard number. Calculated on the fly by the application controller if not specified.
 	// +optional
 	Shard *int64 `json:"shard,omitempty"`
-	// Reference between project and cluster that allow you automatically to be added as item inside Destinations project entity
+	// ClusterResources indicates if cluster level resources should be managed.
+	// This setting is used only if the list of namespaces is not empty.
+	// +optional
+	ClusterResources *bool `json:"clusterResources,omitempty"`
+	// Reference between project and cluster that allow you automatically to be added as item inside Destinations project entity.
+	// Projects with permitOnlyProjectScopedClusters only accept destinations on clusters scoped to them.
+	// Changing the project updates the cluster in place.
 	// +optional
 	Project *string `json:"project,omitempty"`
 	// Labels for cluster secret metadata
@@ -121,7 +129,8 @@ type TLSClientConfig struct {
 	Insecure bool `json:"insecure"`
 	// ServerName is passed to the server for SNI and is used in the client to check server
 	// certificates against. If ServerName is empty, the hostname used to contact the
-	// server is used.
+	// server is used. An empty ServerName clears a ServerName previously set in ArgoCD, e.g. for a
+	// cluster which is no longer behind a proxy.
 	// +optional
 	ServerName *string `json:"serverName,omitempty"`
 	// CertDataSecretRef references a secret holding PEM-encoded bytes (typically read from a client certificate file).
@@ -199,6 +208,30 @@ type ClusterObservation struct {
 	// Kubeconfig tracks changes to a Kubeconfig secret
 	// +optional
 	Kubeconfig *KubeconfigObservation `json:"kubeconfig,omitempty"`
+	// TLSClientConfig tracks the TLS data last applied to the cluster
+	// +optional
+	TLSClientConfig *TLSClientConfigObservation `json:"tlsClientConfig,omitempty"`
+	// InCluster is true if the cluster is the in-cluster destination of ArgoCD,
+	// https://kubernetes.default.svc, which the provider refuses to delete
+	// +optional
+	InCluster *bool `json:"inCluster,omitempty"`
+	// LastError is the error returned by the ArgoCD API on the last failed create, update or delete.
+	// It is cleared once the cluster was written successfully or is up to date.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+}
+
+// TLSClientConfigObservation holds the hashes of the normalized TLS data last applied to a cluster
+type TLSClientConfigObservation struct {
+	// CADataHash is the hash of the applied CAData
+	// +optional
+	CADataHash string `json:"caDataHash,omitempty"`
+	// CertDataHash is the hash of the applied CertData
+	// +optional
+	CertDataHash string `json:"certDataHash,omitempty"`
 }
 
 // A ClusterSpec defines the desired state of an ArgoCD Cluster.
@@ -229,6 +262,16 @@ type Cluster struct {
 	Status ClusterStatus `json:"status,omitempty"`
 }
 
+// GetLastError returns the last error returned by the ArgoCD API for the cluster and the time it occurred
+func (mg *Cluster) GetLastError() (*string, *metav1.Time) {
+	return mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime
+}
+
+// SetLastError sets the last error returned by the ArgoCD API for the cluster and the time it occurred
+func (mg *Cluster) SetLastError(msg *string, t *metav1.Time) {
+	mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime = msg, t
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterList contains a list of Cluster items
@@ -226,6 +226,25 @@ func (in *ClusterObservation) DeepCopyInto(out *ClusterObservation) {
 		*out = new(KubeconfigObservation)
 		**out = **in
 	}
+	if in.TLSClientConfig != nil {
+		in, out := &in.TLSClientConfig, &out.TLSClientConfig
+		*out = new(TLSClientConfigObservation)
+		**out = **in
+	}
+	if in.InCluster != nil {
+		in, out := &in.InCluster, &out.InCluster
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObservation.
@@ -262,6 +281,11 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ClusterResources != nil {
+		in, out := &in.ClusterResources, &out.ClusterResources
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Project != nil {
 		in, out := &in.Project, &out.Project
 		*out = new(string)
@@ -473,3 +497,18 @@ func (in *TLSClientConfig) DeepCopy() *TLSClientConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSClientConfigObservation) DeepCopyInto(out *TLSClientConfigObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSClientConfigObservation.
+func (in *TLSClientConfigObservation) DeepCopy() *TLSClientConfigObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSClientConfigObservation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -48,6 +48,11 @@ type TokenParameters struct {
 	// +optional
 	// +kubebuilder:validation:Pattern=`^([0-9]+)(s|m|h|d)$`
 	RenewBefore *string `json:"renewBefore,omitempty"`
+
+	// ReadOnly only records the metadata of the token identified by the external name
+	// into the observation. The token is never created, renewed or deleted.
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
 }
 
 // TokenObservation holds the issuedAt and expiresAt values of a token
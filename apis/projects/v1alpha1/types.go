@@ -26,7 +26,8 @@ import (
 
 // ProjectParameters define the desired state of an ArgoCD Git Project
 type ProjectParameters struct {
-	// SourceRepos contains list of repository URLs which can be used for deployment
+	// SourceRepos contains list of repository URLs which can be used for deployment.
+	// Entries prefixed with ! deny matching repositories, even if another entry allows them.
 	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1.Repository
 	// +crossplane:generate:reference:refFieldName=SourceReposRefs
 	// +crossplane:generate:reference:selectorFieldName=SourceReposSelector
@@ -38,7 +39,9 @@ type ProjectParameters struct {
 	// SourceReposSelector selects references to Repositories used to set SourceRepos
 	// +optional
 	SourceReposSelector *xpv1.Selector `json:"sourceReposSelector,omitempty"`
-	// Destinations contains list of destinations available for deployment
+	// Destinations contains list of destinations available for deployment.
+	// Destinations added to the project in ArgoCD are removed by the next update, unless
+	// destinations are listed in the argocd.crossplane.io/ignore-fields annotation.
 	// +optional
 	Destinations []ApplicationDestination `json:"destinations,omitempty"`
 	// SourceNamespaces contains list of namespaces which are authorized in the project
@@ -50,6 +53,11 @@ type ProjectParameters struct {
 	// Roles are user defined RBAC roles associated with this project
 	// +optional
 	Roles []ProjectRole `json:"roles,omitempty"`
+	// CaseInsensitiveRoleGroups compares the OIDC groups of roles case-insensitively when observing the project.
+	// Leading and trailing whitespace of groups is always ignored, so that group claims formatted differently
+	// by an SSO provider don't cause an update on every reconcile. Groups are sent to ArgoCD as specified.
+	// +optional
+	CaseInsensitiveRoleGroups *bool `json:"caseInsensitiveRoleGroups,omitempty"`
 	// ClusterResourceWhitelist contains list of whitelisted cluster level resources
 	// +optional
 	ClusterResourceWhitelist []metav1.GroupKind `json:"clusterResourceWhitelist,omitempty"`
@@ -71,6 +79,9 @@ type ProjectParameters struct {
 	// ClusterResourceBlacklist contains list of blacklisted cluster level resources
 	// +optional
 	ClusterResourceBlacklist []metav1.GroupKind `json:"clusterResourceBlacklist,omitempty"`
+	// PermitOnlyProjectScopedClusters determines whether destinations can only reference clusters which are scoped to the project
+	// +optional
+	PermitOnlyProjectScopedClusters *bool `json:"permitOnlyProjectScopedClusters,omitempty"`
 	// ProjectLabels labels that will be applied to the AppProject
 	// +optional
 	ProjectLabels map[string]string `json:"projectLabels,omitempty"`
@@ -110,7 +121,9 @@ type ProjectRole struct {
 	// Policies Stores a list of casbin formated strings that define access policies for the role in the project
 	// +optional
 	Policies []string `json:"policies,omitempty"`
-	// JWTTokens are a list of generated JWT tokens bound to this role
+	// JWTTokens are a list of generated JWT tokens bound to this role.
+	// Tokens with an id but without iat are created by the provider once the role exists
+	// and written to the connection secret with key <role>.<id>.
 	// +optional
 	JWTTokens []JWTToken `json:"jwtTokens,omitempty"`
 	// Groups are a list of OIDC group claims bound to this role
@@ -192,6 +205,76 @@ type ProjectObservation struct {
 	// JWTTokensByRole contains a list of JWT tokens issued for a given role
 	// +optional
 	JWTTokensByRole map[string]JWTTokens `json:"jwtTokensByRole,omitempty"`
+	// CreatedAt is the time the project was created in ArgoCD
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	// SyncWindowActive reports whether any sync window of the project was active when it was last observed.
+	// Only set if the project has sync windows.
+	// +optional
+	SyncWindowActive *bool `json:"syncWindowActive,omitempty"`
+	// ManualSyncAllowed reports whether the sync windows of the project allowed manual syncs when it was last observed.
+	// Only set if the project has sync windows.
+	// +optional
+	ManualSyncAllowed *bool `json:"manualSyncAllowed,omitempty"`
+	// SyncFrozen reports whether the sync windows of the project blocked automated syncs when it was last observed.
+	// Only set if the project has sync windows.
+	// +optional
+	SyncFrozen *bool `json:"syncFrozen,omitempty"`
+	// LastError is the error returned by the ArgoCD API on the last failed create, update or delete.
+	// It is cleared once the project was written successfully or is up to date.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+	// TokenAudit records the tokens of all roles of the project when it was last observed,
+	// most recently issued first. It is limited to the 50 most recently issued tokens.
+	// +optional
+	TokenAudit []TokenAuditRecord `json:"tokenAudit,omitempty"`
+	// FailedTokens lists the declared tokens which could not be created on the last create or update.
+	// The tokens are retried by the next update.
+	// +optional
+	FailedTokens []TokenFailure `json:"failedTokens,omitempty"`
+	// OrphanedTokens lists the tokens issued for roles which no longer exist in the project.
+	// They can't be used anymore and should be cleaned up.
+	// +optional
+	OrphanedTokens []TokenAuditRecord `json:"orphanedTokens,omitempty"`
+	// ApplicationCount is the number of applications using the project when it was last observed.
+	// Only set if enabled with the argocd.crossplane.io/observe-application-count annotation.
+	// +optional
+	ApplicationCount *int32 `json:"applicationCount,omitempty"`
+	// Diff summarizes which parameters differ from the project in ArgoCD, e.g. "description changed, 2 labels added".
+	// Only set while the project is not up to date.
+	// +optional
+	Diff *string `json:"diff,omitempty"`
+	// TokenExpiry is the time until the token of the project expiring soonest when it was last observed,
+	// e.g. "in 13h", or since it expired, e.g. "expired 2d ago". Not set if no token expires.
+	// +optional
+	TokenExpiry *string `json:"tokenExpiry,omitempty"`
+}
+
+// TokenAuditRecord records the issuance of a token of a project role
+type TokenAuditRecord struct {
+	// Role the token was issued for
+	Role string `json:"role"`
+	// ID of the token
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// IssuedAt is the time the token was issued at in seconds since the epoch
+	IssuedAt int64 `json:"iat"`
+	// ExpiresAt is the time the token expires at in seconds since the epoch. Not set if the token never expires.
+	// +optional
+	ExpiresAt *int64 `json:"exp,omitempty"`
+}
+
+// TokenFailure records a declared token of a project role which could not be created
+type TokenFailure struct {
+	// Role the token was declared for
+	Role string `json:"role"`
+	// ID of the token
+	ID string `json:"id"`
+	// Error returned when creating the token
+	Error string `json:"error"`
 }
 
 // A ProjectSpec defines the desired state of an ArgoCD Project.
@@ -211,6 +294,9 @@ type ProjectStatus struct {
 // A Project is a managed resource that represents an ArgoCD Git Project
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CREATED",type="date",JSONPath=".status.atProvider.createdAt"
+// +kubebuilder:printcolumn:name="FROZEN",type="boolean",JSONPath=".status.atProvider.syncFrozen"
+// +kubebuilder:printcolumn:name="TOKEN-EXPIRY",type="string",JSONPath=".status.atProvider.tokenExpiry"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
@@ -222,6 +308,16 @@ type Project struct {
 	Status ProjectStatus `json:"status,omitempty"`
 }
 
+// GetLastError returns the last error returned by the ArgoCD API for the project and the time it occurred
+func (mg *Project) GetLastError() (*string, *metav1.Time) {
+	return mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime
+}
+
+// SetLastError sets the last error returned by the ArgoCD API for the project and the time it occurred
+func (mg *Project) SetLastError(msg *string, t *metav1.Time) {
+	mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime = msg, t
+}
+
 // +kubebuilder:object:root=true
 
 // ProjectList contains a list of Project items
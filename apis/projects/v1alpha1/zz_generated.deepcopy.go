@@ -239,6 +239,68 @@ func (in *ProjectObservation) DeepCopyInto(out *ProjectObservation) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncWindowActive != nil {
+		in, out := &in.SyncWindowActive, &out.SyncWindowActive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManualSyncAllowed != nil {
+		in, out := &in.ManualSyncAllowed, &out.ManualSyncAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SyncFrozen != nil {
+		in, out := &in.SyncFrozen, &out.SyncFrozen
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TokenAudit != nil {
+		in, out := &in.TokenAudit, &out.TokenAudit
+		*out = make([]TokenAuditRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedTokens != nil {
+		in, out := &in.FailedTokens, &out.FailedTokens
+		*out = make([]TokenFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanedTokens != nil {
+		in, out := &in.OrphanedTokens, &out.OrphanedTokens
+		*out = make([]TokenAuditRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplicationCount != nil {
+		in, out := &in.ApplicationCount, &out.ApplicationCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(string)
+		**out = **in
+	}
+	if in.TokenExpiry != nil {
+		in, out := &in.TokenExpiry, &out.TokenExpiry
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectObservation.
@@ -295,6 +357,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CaseInsensitiveRoleGroups != nil {
+		in, out := &in.CaseInsensitiveRoleGroups, &out.CaseInsensitiveRoleGroups
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ClusterResourceWhitelist != nil {
 		in, out := &in.ClusterResourceWhitelist, &out.ClusterResourceWhitelist
 		*out = make([]metav1.GroupKind, len(*in))
@@ -332,6 +399,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 		*out = make([]metav1.GroupKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.PermitOnlyProjectScopedClusters != nil {
+		in, out := &in.PermitOnlyProjectScopedClusters, &out.PermitOnlyProjectScopedClusters
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ProjectLabels != nil {
 		in, out := &in.ProjectLabels, &out.ProjectLabels
 		*out = make(map[string]string, len(*in))
@@ -535,6 +607,46 @@ func (in *Token) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenAuditRecord) DeepCopyInto(out *TokenAuditRecord) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenAuditRecord.
+func (in *TokenAuditRecord) DeepCopy() *TokenAuditRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenAuditRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenFailure) DeepCopyInto(out *TokenFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenFailure.
+func (in *TokenFailure) DeepCopy() *TokenFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenList) DeepCopyInto(out *TokenList) {
 	*out = *in
@@ -630,6 +742,11 @@ func (in *TokenParameters) DeepCopyInto(out *TokenParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenParameters.
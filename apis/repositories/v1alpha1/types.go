@@ -50,14 +50,17 @@ type RepositoryParameters struct {
 	// TLS client cert key for authenticating at the repo server
 	// +optional
 	TLSClientCertKeyRef *SecretReference `json:"tlsClientCertKeyRef,omitempty"`
-	// type of the repo, maybe "git or "helm, "git" is assumed if empty or absent
+	// type of the repo, either "git" or "helm". If absent, it is inferred from the repo URL:
+	// OCI registries with enableOCI and hosts named charts.* are "helm", all other repositories "git".
 	// +optional
 	Type *string `json:"type,omitempty"`
 	// Project is a reference to the project with scoped repositories
 	// +optional
 	// only for git repos
 	Project *string `json:"project,omitempty"`
-	// only for Helm repos
+	// Name of the repository, by which Helm and OCI repositories can be referenced from
+	// multi-source applications. ArgoCD identifies repositories by their URL, so changing
+	// the name updates the repository in place.
 	// +optional
 	Name *string `json:"name,omitempty"`
 	// Whether credentials were inherited from a credential set
@@ -78,6 +81,15 @@ type RepositoryParameters struct {
 	// Github App Enterprise base url if empty will default to https://api.github.com
 	// +optional
 	GitHubAppEnterpriseBaseURL *string `json:"githubAppEnterpriseBaseUrl,omitempty"`
+	// Whether basic auth should be forced for HTTP connections to the repo, e.g. for Azure DevOps.
+	// Drift is only detected if set.
+	// +optional
+	ForceHTTPBasicAuth *bool `json:"forceHttpBasicAuth,omitempty"`
+	// Whether Azure workload identity should be used to authenticate at the repo.
+	// Requires ArgoCD 2.13 or newer, which the ArgoCD client of the provider does not support yet.
+	// Enabling it is therefore rejected.
+	// +optional
+	UseAzureWorkloadIdentity *bool `json:"useAzureWorkloadIdentity,omitempty"`
 }
 
 // SecretReference holds the reference to a Kubernetes secret
@@ -101,6 +113,9 @@ type PasswordObservation struct {
 type SecretObservation struct {
 	// ResourceVersion tracks the meta1.ResourceVersion of an Object
 	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Hash is the SHA-256 hash of the secret value last applied to the repository.
+	// The referenced secret is applied again if the hash of its current value differs.
+	Hash string `json:"hash,omitempty"`
 }
 
 // RepositoryObservation represents an argocd repository.
@@ -127,6 +142,23 @@ type RepositoryObservation struct {
 	// GithubAppPrivateKey tracks changes to a GithubAppPrivateKey secret
 	// +optional
 	GithubAppPrivateKey *PasswordObservation `json:"githubAppPrivateKey,omitempty"`
+
+	// InheritedCredentials is true if the repository has no credentials of its own and ArgoCD
+	// authenticates with the credentials of the credential template CredentialTemplate
+	// +optional
+	InheritedCredentials *bool `json:"inheritedCredentials,omitempty"`
+
+	// CredentialTemplate is the URL prefix of the credential template the repository inherits its
+	// credentials from
+	// +optional
+	CredentialTemplate *string `json:"credentialTemplate,omitempty"`
+	// LastError is the error returned by the ArgoCD API on the last failed create, update or delete.
+	// It is cleared once the repository was written successfully or is up to date.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
 }
 
 // ConnectionState is the observed state of the argocd repository
@@ -164,6 +196,16 @@ type Repository struct {
 	Status RepositoryStatus `json:"status,omitempty"`
 }
 
+// GetLastError returns the last error returned by the ArgoCD API for the repository and the time it occurred
+func (mg *Repository) GetLastError() (*string, *metav1.Time) {
+	return mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime
+}
+
+// SetLastError sets the last error returned by the ArgoCD API for the repository and the time it occurred
+func (mg *Repository) SetLastError(msg *string, t *metav1.Time) {
+	mg.Status.AtProvider.LastError, mg.Status.AtProvider.LastErrorTime = msg, t
+}
+
 // +kubebuilder:object:root=true
 
 // RepositoryList contains a list of Repository items
@@ -147,6 +147,25 @@ func (in *RepositoryObservation) DeepCopyInto(out *RepositoryObservation) {
 		*out = new(PasswordObservation)
 		**out = **in
 	}
+	if in.InheritedCredentials != nil {
+		in, out := &in.InheritedCredentials, &out.InheritedCredentials
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CredentialTemplate != nil {
+		in, out := &in.CredentialTemplate, &out.CredentialTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryObservation.
@@ -242,6 +261,16 @@ func (in *RepositoryParameters) DeepCopyInto(out *RepositoryParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ForceHTTPBasicAuth != nil {
+		in, out := &in.ForceHTTPBasicAuth, &out.ForceHTTPBasicAuth
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseAzureWorkloadIdentity != nil {
+		in, out := &in.UseAzureWorkloadIdentity, &out.UseAzureWorkloadIdentity
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryParameters.
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CmdParamsConfigParameters define the desired state of the ArgoCD command parameters. Only a curated
+// set of timeout and parallelism tunables is supported, all other keys of argocd-cmd-params-cm are left
+// untouched. Settings which aren't set are left untouched as well. The ArgoCD components must be restarted
+// to pick up changed parameters.
+type CmdParamsConfigParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-cmd-params-cm ConfigMap is read from and written to this
+	// namespace of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// ControllerStatusProcessors is the number of application status processors of the application
+	// controller, i.e. the controller.status.processors parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ControllerStatusProcessors *int32 `json:"controllerStatusProcessors,omitempty"`
+	// ControllerOperationProcessors is the number of application operation processors of the application
+	// controller, i.e. the controller.operation.processors parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ControllerOperationProcessors *int32 `json:"controllerOperationProcessors,omitempty"`
+	// ControllerRepoServerTimeoutSeconds is the timeout of the requests of the application controller to
+	// the repo server, i.e. the controller.repo.server.timeout.seconds parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ControllerRepoServerTimeoutSeconds *int32 `json:"controllerRepoServerTimeoutSeconds,omitempty"`
+	// ControllerSelfHealTimeoutSeconds is the delay before an application is self-healed again,
+	// i.e. the controller.self.heal.timeout.seconds parameter.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ControllerSelfHealTimeoutSeconds *int32 `json:"controllerSelfHealTimeoutSeconds,omitempty"`
+	// ControllerKubectlParallelismLimit is the maximum number of concurrent kubectl fork/execs of the
+	// application controller, i.e. the controller.kubectl.parallelism.limit parameter. 0 means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ControllerKubectlParallelismLimit *int32 `json:"controllerKubectlParallelismLimit,omitempty"`
+	// ServerRepoServerTimeoutSeconds is the timeout of the requests of the API server to the repo server,
+	// i.e. the server.repo.server.timeout.seconds parameter.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ServerRepoServerTimeoutSeconds *int32 `json:"serverRepoServerTimeoutSeconds,omitempty"`
+	// RepoServerParallelismLimit is the maximum number of concurrent manifest generations of the repo
+	// server, i.e. the reposerver.parallelism.limit parameter. 0 means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RepoServerParallelismLimit *int32 `json:"repoServerParallelismLimit,omitempty"`
+}
+
+// A CmdParamsConfigSpec defines the desired state of the ArgoCD command parameters.
+type CmdParamsConfigSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CmdParamsConfigParameters `json:"forProvider"`
+}
+
+// A CmdParamsConfigStatus represents the observed state of the ArgoCD command parameters.
+type CmdParamsConfigStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CmdParamsConfig is a managed resource that represents tunables of the command parameters in argocd-cmd-params-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type CmdParamsConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CmdParamsConfigSpec   `json:"spec"`
+	Status CmdParamsConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CmdParamsConfigList contains a list of CmdParamsConfig items
+type CmdParamsConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CmdParamsConfig `json:"items"`
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RBACConfigParameters define the desired state of the ArgoCD RBAC settings
+type RBACConfigParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-rbac-cm ConfigMap is read from and written to this namespace
+	// of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// PolicyDefault is the role of authenticated users without a matching policy, e.g. role:readonly.
+	// The policy.default setting is left untouched if not set.
+	// +optional
+	PolicyDefault *string `json:"policyDefault,omitempty"`
+	// Scopes are the OIDC claims which are matched against group bindings, e.g. groups and email.
+	// The scopes setting is left untouched if not set.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Roles are rendered into the p lines of the policy.csv setting
+	// +optional
+	Roles []RBACRole `json:"roles,omitempty"`
+	// GroupBindings are rendered into the g lines of the policy.csv setting
+	// +optional
+	GroupBindings []RBACGroupBinding `json:"groupBindings,omitempty"`
+	// Policies are additional lines of the policy.csv setting in the ArgoCD CSV format,
+	// e.g. "p, role:deployer, applications, sync, */*, allow" or "g, my-org:team, role:deployer"
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+}
+
+// RBACRole is a role with the permissions granted or denied to it
+type RBACRole struct {
+	// Name of the role. The role: prefix is added if missing.
+	Name string `json:"name"`
+	// Policies of the role
+	Policies []RBACPolicy `json:"policies"`
+}
+
+// RBACPolicy grants or denies an action on ArgoCD resources
+type RBACPolicy struct {
+	// Resource is the type of ArgoCD resource, e.g. applications, clusters or repositories
+	Resource string `json:"resource"`
+	// Action on the resource, e.g. get, create, sync or *
+	Action string `json:"action"`
+	// Object the policy applies to, e.g. <project>/<application> for applications. Defaults to *.
+	// +optional
+	Object *string `json:"object,omitempty"`
+	// Effect of the policy. Defaults to allow.
+	// +kubebuilder:validation:Enum=allow;deny
+	// +optional
+	Effect *string `json:"effect,omitempty"`
+}
+
+// RBACGroupBinding assigns a role to a user or group
+type RBACGroupBinding struct {
+	// Subject is the user or group, as given by the claims in scopes
+	Subject string `json:"subject"`
+	// Role assigned to the subject. The role: prefix is added if missing.
+	Role string `json:"role"`
+}
+
+// A RBACConfigSpec defines the desired state of the ArgoCD RBAC settings.
+type RBACConfigSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RBACConfigParameters `json:"forProvider"`
+}
+
+// A RBACConfigStatus represents the observed state of the ArgoCD RBAC settings.
+type RBACConfigStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RBACConfig is a managed resource that represents the RBAC settings in argocd-rbac-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type RBACConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RBACConfigSpec   `json:"spec"`
+	Status RBACConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RBACConfigList contains a list of RBACConfig items
+type RBACConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RBACConfig `json:"items"`
+}
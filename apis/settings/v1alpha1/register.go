@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "settings.argocd.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// CmdParamsConfig type metadata
+var (
+	CmdParamsConfigKind             = reflect.TypeOf(CmdParamsConfig{}).Name()
+	CmdParamsConfigGroupKind        = schema.GroupKind{Group: Group, Kind: CmdParamsConfigKind}.String()
+	CmdParamsConfigKindAPIVersion   = CmdParamsConfigKind + "." + SchemeGroupVersion.String()
+	CmdParamsConfigGroupVersionKind = SchemeGroupVersion.WithKind(CmdParamsConfigKind)
+)
+
+// GlobalProject type metadata
+var (
+	GlobalProjectKind             = reflect.TypeOf(GlobalProject{}).Name()
+	GlobalProjectGroupKind        = schema.GroupKind{Group: Group, Kind: GlobalProjectKind}.String()
+	GlobalProjectKindAPIVersion   = GlobalProjectKind + "." + SchemeGroupVersion.String()
+	GlobalProjectGroupVersionKind = SchemeGroupVersion.WithKind(GlobalProjectKind)
+)
+
+// RBACConfig type metadata
+var (
+	RBACConfigKind             = reflect.TypeOf(RBACConfig{}).Name()
+	RBACConfigGroupKind        = schema.GroupKind{Group: Group, Kind: RBACConfigKind}.String()
+	RBACConfigKindAPIVersion   = RBACConfigKind + "." + SchemeGroupVersion.String()
+	RBACConfigGroupVersionKind = SchemeGroupVersion.WithKind(RBACConfigKind)
+)
+
+// ResourceFilter type metadata
+var (
+	ResourceFilterKind             = reflect.TypeOf(ResourceFilter{}).Name()
+	ResourceFilterGroupKind        = schema.GroupKind{Group: Group, Kind: ResourceFilterKind}.String()
+	ResourceFilterKindAPIVersion   = ResourceFilterKind + "." + SchemeGroupVersion.String()
+	ResourceFilterGroupVersionKind = SchemeGroupVersion.WithKind(ResourceFilterKind)
+)
+
+// ResourceHealthCheck type metadata
+var (
+	ResourceHealthCheckKind             = reflect.TypeOf(ResourceHealthCheck{}).Name()
+	ResourceHealthCheckGroupKind        = schema.GroupKind{Group: Group, Kind: ResourceHealthCheckKind}.String()
+	ResourceHealthCheckKindAPIVersion   = ResourceHealthCheckKind + "." + SchemeGroupVersion.String()
+	ResourceHealthCheckGroupVersionKind = SchemeGroupVersion.WithKind(ResourceHealthCheckKind)
+)
+
+// ResourceIgnoreDifference type metadata
+var (
+	ResourceIgnoreDifferenceKind             = reflect.TypeOf(ResourceIgnoreDifference{}).Name()
+	ResourceIgnoreDifferenceGroupKind        = schema.GroupKind{Group: Group, Kind: ResourceIgnoreDifferenceKind}.String()
+	ResourceIgnoreDifferenceKindAPIVersion   = ResourceIgnoreDifferenceKind + "." + SchemeGroupVersion.String()
+	ResourceIgnoreDifferenceGroupVersionKind = SchemeGroupVersion.WithKind(ResourceIgnoreDifferenceKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CmdParamsConfig{}, &CmdParamsConfigList{})
+	SchemeBuilder.Register(&GlobalProject{}, &GlobalProjectList{})
+	SchemeBuilder.Register(&RBACConfig{}, &RBACConfigList{})
+	SchemeBuilder.Register(&ResourceFilter{}, &ResourceFilterList{})
+	SchemeBuilder.Register(&ResourceHealthCheck{}, &ResourceHealthCheckList{})
+	SchemeBuilder.Register(&ResourceIgnoreDifference{}, &ResourceIgnoreDifferenceList{})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceFilterParameters define the resources tracked by ArgoCD
+type ResourceFilterParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-cm ConfigMap is read from and written to this namespace
+	// of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Exclusions are rendered into the resource.exclusions setting. Matching resources are not tracked by ArgoCD.
+	// The setting is left untouched if not set.
+	// +optional
+	Exclusions []FilteredResource `json:"exclusions,omitempty"`
+	// Inclusions are rendered into the resource.inclusions setting. Only matching resources are tracked by ArgoCD.
+	// The setting is left untouched if not set.
+	// +optional
+	Inclusions []FilteredResource `json:"inclusions,omitempty"`
+}
+
+// FilteredResource matches resources by API group, kind and cluster
+type FilteredResource struct {
+	// APIGroups of the resources, e.g. cilium.io. Matches all groups if empty or *.
+	// +optional
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Kinds of the resources, e.g. CiliumIdentity. Matches all kinds if empty or *.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+	// Clusters the resources are in, given by their server URL. Matches all clusters if empty or *.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// A ResourceFilterSpec defines the desired state of the ArgoCD resource exclusions and inclusions.
+type ResourceFilterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ResourceFilterParameters `json:"forProvider"`
+}
+
+// A ResourceFilterStatus represents the observed state of the ArgoCD resource exclusions and inclusions.
+type ResourceFilterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ResourceFilter is a managed resource that represents the resource exclusions and inclusions settings in argocd-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type ResourceFilter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceFilterSpec   `json:"spec"`
+	Status ResourceFilterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceFilterList contains a list of ResourceFilter items
+type ResourceFilterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceFilter `json:"items"`
+}
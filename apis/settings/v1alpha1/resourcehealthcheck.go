@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceHealthCheckParameters define the desired state of an ArgoCD custom resource health check
+type ResourceHealthCheckParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-cm ConfigMap is read from and written to this namespace
+	// of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Group of the resources the health check applies to, e.g. cert-manager.io. Empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of the resources the health check applies to, e.g. Certificate
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+	// HealthLua is the Lua script assessing the health of a resource. It is stored in the
+	// resource.customizations.health.<group>_<kind> setting.
+	// +kubebuilder:validation:MinLength=1
+	HealthLua string `json:"healthLua"`
+}
+
+// A ResourceHealthCheckSpec defines the desired state of an ArgoCD custom resource health check.
+type ResourceHealthCheckSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ResourceHealthCheckParameters `json:"forProvider"`
+}
+
+// A ResourceHealthCheckStatus represents the observed state of an ArgoCD custom resource health check.
+type ResourceHealthCheckStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ResourceHealthCheck is a managed resource that represents a custom health check setting in argocd-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.group"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.forProvider.kind"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type ResourceHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceHealthCheckSpec   `json:"spec"`
+	Status ResourceHealthCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceHealthCheckList contains a list of ResourceHealthCheck items
+type ResourceHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceHealthCheck `json:"items"`
+}
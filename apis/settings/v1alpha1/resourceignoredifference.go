@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceIgnoreDifferenceParameters define the desired state of fields ArgoCD ignores when it compares
+// the resources of a group and kind of all applications
+type ResourceIgnoreDifferenceParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-cm ConfigMap is read from and written to this namespace
+	// of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Group of the resources whose differences are ignored, e.g. apps. Empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of the resources whose differences are ignored, e.g. Deployment. The kind all with an empty
+	// group applies to all resources. The fields are stored in the
+	// resource.customizations.ignoreDifferences.<group>_<kind> setting.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+	// JSONPointers are the JSON pointers of the ignored fields, e.g. /spec/replicas
+	// +optional
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+	// JQPathExpressions are the JQ path expressions of the ignored fields
+	// +optional
+	JQPathExpressions []string `json:"jqPathExpressions,omitempty"`
+	// ManagedFieldsManagers are the managers whose fields are ignored, e.g. kube-controller-manager
+	// +optional
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// A ResourceIgnoreDifferenceSpec defines the desired state of fields ArgoCD ignores globally.
+type ResourceIgnoreDifferenceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ResourceIgnoreDifferenceParameters `json:"forProvider"`
+}
+
+// A ResourceIgnoreDifferenceStatus represents the observed state of fields ArgoCD ignores globally.
+type ResourceIgnoreDifferenceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ResourceIgnoreDifference is a managed resource that represents a global ignoreDifferences setting in argocd-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.group"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.forProvider.kind"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type ResourceIgnoreDifference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceIgnoreDifferenceSpec   `json:"spec"`
+	Status ResourceIgnoreDifferenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceIgnoreDifferenceList contains a list of ResourceIgnoreDifference items
+type ResourceIgnoreDifferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceIgnoreDifference `json:"items"`
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate go run github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets ./...
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GlobalProjectParameters define the desired state of an ArgoCD global project entry
+type GlobalProjectParameters struct {
+	// Namespace ArgoCD is installed in. The argocd-cm ConfigMap is read from and written to this namespace
+	// of the cluster the provider runs in. Defaults to argocd.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// ProjectName is the name of the project whose settings are applied to all matching projects
+	ProjectName string `json:"projectName"`
+	// LabelSelector selects the projects the global project applies to
+	LabelSelector metav1.LabelSelector `json:"labelSelector"`
+}
+
+// A GlobalProjectSpec defines the desired state of an ArgoCD global project.
+type GlobalProjectSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GlobalProjectParameters `json:"forProvider"`
+}
+
+// A GlobalProjectStatus represents the observed state of an ArgoCD global project.
+type GlobalProjectStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GlobalProject is a managed resource that represents an entry of the globalProjects setting in argocd-cm
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PROJECT",type="string",JSONPath=".spec.forProvider.projectName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type GlobalProject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GlobalProjectSpec   `json:"spec"`
+	Status GlobalProjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalProjectList contains a list of GlobalProject items
+type GlobalProjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalProject `json:"items"`
+}
@@ -43,10 +43,59 @@ type ProviderConfigSpec struct {
 	// +optional
 	GRPCWebRootPath *string `json:"grpcWebRootPath,omitempty"`
 
+	// UserAgent sent with every request to the argocd API. Defaults to provider-argocd/<version>.
+	// +optional
+	UserAgent *string `json:"userAgent,omitempty"`
+
+	// MaxGRPCMessageSizeMiB is the maximum size in MiB of gRPC messages sent to and received from
+	// the argocd API. Raise it if requests of large projects or applications fail with
+	// ResourceExhausted. Default: 256.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxGRPCMessageSizeMiB *int32 `json:"maxGRPCMessageSizeMiB,omitempty"`
+
+	// RateLimit throttles the calls of all managed resources using this ProviderConfig
+	// to the argocd API. Calls are not throttled if not set.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// NamePrefix is prepended to the external name of Applications and ApplicationSets
+	// to form the name of the ArgoCD object, e.g. to separate the objects of several teams.
+	// Projects and clusters are not renamed, as applications reference them by name, and
+	// repositories are identified by their URL.
+	// +optional
+	NamePrefix *string `json:"namePrefix,omitempty"`
+
+	// NameSuffix is appended to the external name of Applications and ApplicationSets
+	// to form the name of the ArgoCD object.
+	// +optional
+	NameSuffix *string `json:"nameSuffix,omitempty"`
+
+	// TokenSecretKeyTemplate is a Go template forming the connection secret key of a project
+	// token from its .Role and .ID, e.g. {{ .Role }}-{{ .ID }}. The keys of all tokens of a
+	// project must be unique. Default: {{ .Role }}.{{ .ID }}.
+	// +optional
+	TokenSecretKeyTemplate *string `json:"tokenSecretKeyTemplate,omitempty"`
+
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 }
 
+// RateLimit is a token bucket limiting the calls to the argocd API. Each call takes one token
+// and waits until one is available. Resources whose calls cannot be made before the reconcile
+// times out are requeued with backoff.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which tokens are added to the bucket
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Burst is the size of the bucket, i.e. the number of calls allowed at once.
+	// Defaults to RequestsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+}
+
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
 	// Source of the provider credentials.
@@ -44,6 +44,8 @@ func main() {
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
 		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 		pollInterval             = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
+		kindPollIntervals        = app.Flag("poll-kind", "Poll interval of the resources of a kind, overriding --poll for them, e.g. Project=10m. Can be repeated.").StringMap()
+		kindMaxReconciles        = app.Flag("max-concurrent-reconciles-kind", "Maximum of concurrent reconciles of the controller of a kind, overriding --max-reconcile-rate for it, e.g. Application=20. Can be repeated.").StringMap()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -91,6 +93,10 @@ func main() {
 
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add argocd APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup argocd controllers")
+	pollIntervals, err := controller.ParsePollIntervals(*kindPollIntervals)
+	kingpin.FatalIfError(err, "Cannot parse poll intervals")
+	maxConcurrentReconciles, err := controller.ParseMaxConcurrentReconciles(*kindMaxReconciles)
+	kingpin.FatalIfError(err, "Cannot parse maximum of concurrent reconciles")
+	kingpin.FatalIfError(controller.Setup(mgr, o, pollIntervals, maxConcurrentReconciles), "Cannot setup argocd controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// AnnotationKeyAdoptExisting adopts the ArgoCD object of a resource if set to "true" and
+	// creating it fails because it already exists, e.g. because it was created by a reconcile
+	// whose result was never persisted.
+	AnnotationKeyAdoptExisting = "argocd.crossplane.io/adopt-existing"
+
+	errAdoptExisting   = "cannot observe existing ArgoCD object to adopt it"
+	errorAlreadyExists = "code = AlreadyExists"
+	// ArgoCD returns an existing object on create if it is identical and otherwise rejects the create
+	// with InvalidArgument, e.g. "existing project spec is different; use upsert flag to force update"
+	errorInvalidArgument = "code = InvalidArgument"
+	errorSpecIsDifferent = "spec is different"
+	errorExisting        = "existing "
+
+	errFmtAdoptNotExists = "ArgoCD reported %s as already existing, but it cannot be observed"
+)
+
+// IsAdoptExistingEnabled returns whether an existing ArgoCD object of o is adopted on create
+func IsAdoptExistingEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyAdoptExisting] == "true"
+}
+
+// IsErrorAlreadyExists returns whether err is returned by ArgoCD because the object to create already
+// exists with a different spec. ArgoCD reports this with InvalidArgument, AlreadyExists is matched as well.
+func IsErrorAlreadyExists(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(errors.Cause(err)); ok {
+		switch s.Code() { //nolint:exhaustive
+		case codes.AlreadyExists:
+			return true
+		case codes.InvalidArgument:
+			return isSpecIsDifferent(s.Message())
+		}
+	}
+	msg := err.Error()
+	return strings.Contains(msg, errorAlreadyExists) || strings.Contains(msg, errorInvalidArgument) && isSpecIsDifferent(msg)
+}
+
+// isSpecIsDifferent returns whether msg is the message of ArgoCD rejecting the create of an existing object
+func isSpecIsDifferent(msg string) bool {
+	return strings.Contains(msg, errorExisting) && strings.Contains(msg, errorSpecIsDifferent)
+}
+
+// WithAlreadyExistsHandling wraps an ExternalClient so that a resource with the AnnotationKeyAdoptExisting
+// annotation adopts its ArgoCD object if creating it fails because it already exists. The object is observed again
+// and, if it exists, the resource is treated as created, so that later reconciles update it.
+func WithAlreadyExistsHandling(c managed.ExternalClient) managed.ExternalClient {
+	return &alreadyExistsHandlingClient{client: c}
+}
+
+type alreadyExistsHandlingClient struct {
+	client managed.ExternalClient
+}
+
+func (c *alreadyExistsHandlingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return c.client.Observe(ctx, mg)
+}
+
+func (c *alreadyExistsHandlingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := c.client.Create(ctx, mg)
+	if !IsErrorAlreadyExists(err) || !IsAdoptExistingEnabled(mg) {
+		return cr, err
+	}
+	if meta.GetExternalName(mg) == "" {
+		meta.SetExternalName(mg, mg.GetName())
+	}
+	o, oerr := c.client.Observe(ctx, mg)
+	if oerr != nil {
+		return managed.ExternalCreation{}, errors.Wrap(oerr, errAdoptExisting)
+	}
+	if !o.ResourceExists {
+		return managed.ExternalCreation{}, errors.Wrapf(err, errFmtAdoptNotExists, meta.GetExternalName(mg))
+	}
+	return managed.ExternalCreation{ConnectionDetails: o.ConnectionDetails}, nil
+}
+
+func (c *alreadyExistsHandlingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return c.client.Update(ctx, mg)
+}
+
+func (c *alreadyExistsHandlingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return c.client.Delete(ctx, mg)
+}
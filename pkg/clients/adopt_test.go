@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestAlreadyExistsHandlingCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+	errExists := errors.Wrap(status.Error(codes.InvalidArgument, "existing project spec is different; use upsert flag to force update"), "cannot create")
+	adopt := func() *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName("test")
+		meta.AddAnnotations(mg, map[string]string{AnnotationKeyAdoptExisting: "true"})
+		return mg
+	}
+
+	type want struct {
+		result       managed.ExternalCreation
+		observed     int
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		mg         *fake.Managed
+		create     error
+		observed   managed.ExternalObservation
+		observeErr error
+		want       want
+	}{
+		"Created": {
+			mg:   adopt(),
+			want: want{},
+		},
+		"AlreadyExistsThenAdopt": {
+			mg:       adopt(),
+			create:   errExists,
+			observed: managed.ExternalObservation{ResourceExists: true, ConnectionDetails: managed.ConnectionDetails{"token": []byte("t")}},
+			want: want{
+				result:       managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{"token": []byte("t")}},
+				observed:     1,
+				externalName: "test",
+			},
+		},
+		"AlreadyExistsMessage": {
+			mg:       adopt(),
+			create:   errors.New("rpc error: code = AlreadyExists desc = project test already exists"),
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want:     want{observed: 1, externalName: "test"},
+		},
+		"SpecIsDifferentMessage": {
+			mg:       adopt(),
+			create:   errors.New("rpc error: code = InvalidArgument desc = existing application spec is different, use upsert flag to force update"),
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want:     want{observed: 1, externalName: "test"},
+		},
+		"AdoptionDisabled": {
+			mg:     &fake.Managed{},
+			create: errExists,
+			want:   want{err: errExists},
+		},
+		"AlreadyExistsButNotObserved": {
+			mg:     adopt(),
+			create: errExists,
+			want: want{
+				observed:     1,
+				externalName: "test",
+				err:          errors.Wrapf(errExists, errFmtAdoptNotExists, "test"),
+			},
+		},
+		"ObserveFailed": {
+			mg:         adopt(),
+			create:     errExists,
+			observeErr: errBoom,
+			want: want{
+				observed:     1,
+				externalName: "test",
+				err:          errors.Wrap(errBoom, errAdoptExisting),
+			},
+		},
+		"OtherError": {
+			mg:     adopt(),
+			create: errBoom,
+			want:   want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := 0
+			c := WithAlreadyExistsHandling(managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					observed++
+					return tc.observed, tc.observeErr
+				},
+				CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, tc.create
+				},
+			})
+			got, err := c.Create(context.Background(), tc.mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, observed); diff != "" {
+				t.Errorf("Create(...): -want observed, +got observed:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("Create(...): -want external name, +got external name:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsErrorAlreadyExists(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Nil":                  {err: nil, want: false},
+		"AlreadyExists":        {err: status.Error(codes.AlreadyExists, "project test already exists"), want: true},
+		"ProjectSpecDifferent": {err: errors.Wrap(status.Error(codes.InvalidArgument, "existing project spec is different; use upsert flag to force update"), "cannot create"), want: true},
+		"AppSetSpecDifferent":  {err: status.Error(codes.InvalidArgument, "existing ApplicationSet spec is different, use upsert flag to force update"), want: true},
+		"Message":              {err: errors.New("rpc error: code = InvalidArgument desc = existing repository spec is different; use upsert flag to force update"), want: true},
+		"OtherInvalidArgument": {err: status.Error(codes.InvalidArgument, "application spec for test is invalid"), want: false},
+		"PlainError":           {err: errors.New("boom"), want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsErrorAlreadyExists(tc.err)); diff != "" {
+				t.Errorf("IsErrorAlreadyExists(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package applications
+
+import (
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyMatchStrategy selects how an existing application is matched when it is adopted.
+	// Applications are matched by their name by default.
+	AnnotationKeyMatchStrategy = "argocd.crossplane.io/match-strategy"
+
+	// AnnotationKeyTrackingID is the ArgoCD tracking id of the application to adopt with MatchStrategyTrackingID
+	AnnotationKeyTrackingID = "argocd.crossplane.io/tracking-id"
+
+	// MatchStrategyName matches the application whose name is the external name
+	MatchStrategyName = "name"
+	// MatchStrategyTrackingID additionally matches the application whose ArgoCD tracking id is
+	// given by AnnotationKeyTrackingID, if there is no application with the external name
+	MatchStrategyTrackingID = "tracking-id"
+
+	// ArgoCD tracks the resources it manages, including applications managed by an
+	// app of apps, with an annotation or a label depending on its tracking method.
+	argoCDAnnotationKeyTrackingID = "argocd.argoproj.io/tracking-id"
+	argoCDLabelKeyInstance        = "app.kubernetes.io/instance"
+
+	errFmtInvalidMatchStrategy = "invalid value %q of annotation %s, expected name or tracking-id"
+	errFmtMissingTrackingID    = "annotation %s is required to match applications by tracking id"
+)
+
+// MatchStrategy returns the strategy used to match an existing application for o
+func MatchStrategy(o metav1.Object) (string, error) {
+	switch s := o.GetAnnotations()[AnnotationKeyMatchStrategy]; s {
+	case "", MatchStrategyName:
+		return MatchStrategyName, nil
+	case MatchStrategyTrackingID:
+		if o.GetAnnotations()[AnnotationKeyTrackingID] == "" {
+			return "", errors.Errorf(errFmtMissingTrackingID, AnnotationKeyTrackingID)
+		}
+		return MatchStrategyTrackingID, nil
+	default:
+		return "", errors.Errorf(errFmtInvalidMatchStrategy, s, AnnotationKeyMatchStrategy)
+	}
+}
+
+// HasTrackingID reports whether ArgoCD tracks app with the given tracking id,
+// either with the tracking id annotation or the instance label.
+func HasTrackingID(app *v1alpha1.Application, id string) bool {
+	return app.Annotations[argoCDAnnotationKeyTrackingID] == id || app.Labels[argoCDLabelKeyInstance] == id
+}
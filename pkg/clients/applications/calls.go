@@ -0,0 +1,60 @@
+package applications
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v2/reposerver/apiclient"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// callClient waits for the rate limit of the ProviderConfig in use before every call and
+// records the deprecations ArgoCD reports for it in the clients.Deprecations of the context.
+type callClient struct {
+	client ServiceClient
+}
+
+// withCalls returns a client which rate limits the calls of c and records their deprecations
+func withCalls(c ServiceClient) ServiceClient {
+	return &callClient{client: c}
+}
+
+func (c *callClient) Get(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Call(ctx, c.client.Get, in, opts...)
+}
+
+func (c *callClient) GetManifests(ctx context.Context, in *application.ApplicationManifestQuery, opts ...grpc.CallOption) (*repoapiclient.ManifestResponse, error) {
+	return clients.Call(ctx, c.client.GetManifests, in, opts...)
+}
+
+func (c *callClient) List(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationList, error) {
+	return clients.Call(ctx, c.client.List, in, opts...)
+}
+
+func (c *callClient) Create(ctx context.Context, in *application.ApplicationCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Call(ctx, c.client.Create, in, opts...)
+}
+
+func (c *callClient) Update(ctx context.Context, in *application.ApplicationUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Call(ctx, c.client.Update, in, opts...)
+}
+
+func (c *callClient) Patch(ctx context.Context, in *application.ApplicationPatchRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Call(ctx, c.client.Patch, in, opts...)
+}
+
+func (c *callClient) Delete(ctx context.Context, in *application.ApplicationDeleteRequest, opts ...grpc.CallOption) (*application.ApplicationResponse, error) {
+	return clients.Call(ctx, c.client.Delete, in, opts...)
+}
+
+func (c *callClient) Sync(ctx context.Context, in *application.ApplicationSyncRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Call(ctx, c.client.Sync, in, opts...)
+}
+
+func (c *callClient) TerminateOperation(ctx context.Context, in *application.OperationTerminateRequest, opts ...grpc.CallOption) (*application.OperationTerminateResponse, error) {
+	return clients.Call(ctx, c.client.TerminateOperation, in, opts...)
+}
@@ -7,13 +7,23 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v2/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v2/util/io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"google.golang.org/grpc"
 )
 
 const (
 	errorNotFound = "code = NotFound desc = repo"
+
+	// AnnotationKeyValidateSourceRepos enables checking on create that the sources of an application
+	// are permitted by the sourceRepos of its project if set to "true"
+	AnnotationKeyValidateSourceRepos = "argocd.crossplane.io/validate-source-repos"
+
+	// AnnotationKeyValidateProject enables checking that the project exists before an application
+	// is moved to it if set to "true"
+	AnnotationKeyValidateProject = "argocd.crossplane.io/validate-project"
 )
 
 // ServiceClient wraps the functions to connect to argocd repositories
@@ -21,6 +31,9 @@ type ServiceClient interface {
 	// Get returns an application by name
 	Get(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.Application, error)
 
+	// GetManifests returns the manifests of an application at a revision
+	GetManifests(ctx context.Context, in *application.ApplicationManifestQuery, opts ...grpc.CallOption) (*repoapiclient.ManifestResponse, error)
+
 	// List returns list of applications
 	List(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationList, error)
 
@@ -30,14 +43,33 @@ type ServiceClient interface {
 	// Update updates an application
 	Update(ctx context.Context, in *application.ApplicationUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error)
 
+	// Patch patches an application
+	Patch(ctx context.Context, in *application.ApplicationPatchRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error)
+
 	// Delete deletes an application
 	Delete(ctx context.Context, in *application.ApplicationDeleteRequest, opts ...grpc.CallOption) (*application.ApplicationResponse, error)
+
+	// Sync syncs an application to its target state
+	Sync(ctx context.Context, in *application.ApplicationSyncRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error)
+
+	// TerminateOperation terminates the currently running operation of an application
+	TerminateOperation(ctx context.Context, in *application.OperationTerminateRequest, opts ...grpc.CallOption) (*application.OperationTerminateResponse, error)
 }
 
 // NewApplicationServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
 func NewApplicationServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, ServiceClient) {
 	conn, repoIf := apiclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
-	return conn, repoIf
+	return conn, withCalls(repoIf)
+}
+
+// IsSourceRepoValidationEnabled returns whether the sources of o are checked against the sourceRepos of its project
+func IsSourceRepoValidationEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyValidateSourceRepos] == "true"
+}
+
+// IsProjectValidationEnabled returns whether the project of o is checked to exist before o is moved to it
+func IsProjectValidationEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyValidateProject] == "true"
 }
 
 // IsErrorApplicationNotFound helper function to test for errorNotFound error.
@@ -30,12 +30,36 @@ type Converter interface {
 
 	ToArgoApplicationSpec(in *v1alpha1.ApplicationParameters) *argocdv1alpha1.ApplicationSpec
 
+	// goverter:ignore ValuesRef
+	FromArgoApplicationSourceHelm(in *argocdv1alpha1.ApplicationSourceHelm) *v1alpha1.ApplicationSourceHelm
+
+	ToArgoApplicationSourceHelm(in *v1alpha1.ApplicationSourceHelm) *argocdv1alpha1.ApplicationSourceHelm
+
+	// goverter:ignore LastSyncRequest
+	// goverter:ignore LastSyncRequestTime
+	// goverter:ignore OperationPhase
+	// goverter:ignore OperationMessage
+	// goverter:ignore SyncRevision
+	// goverter:ignore ResolvedRevision
+	// goverter:ignore ResolvedRevisions
+	// goverter:ignore SyncWaves
+	// goverter:ignore CurrentSyncWave
+	// goverter:ignore HelmValuesHash
+	// goverter:ignore Notifications
+	// goverter:ignore OutOfSyncSince
+	// goverter:ignore OutOfSyncDuration
+	// goverter:ignore ResourceCount
+	// goverter:ignore LastError
+	// goverter:ignore LastErrorTime
 	FromArgoApplicationStatus(in *argocdv1alpha1.ApplicationStatus) *v1alpha1.ArgoApplicationStatus
 }
 
 // ExtV1JSONToRuntimeRawExtension converts an extv1.JSON into a
-// *runtime.RawExtension.
+// *runtime.RawExtension. Empty JSON is converted to nil, as ArgoCD omits it.
 func ExtV1JSONToRuntimeRawExtension(in extv1.JSON) *runtime.RawExtension {
+	if len(in.Raw) == 0 {
+		return nil
+	}
 	return &runtime.RawExtension{
 		Raw: in.Raw,
 	}
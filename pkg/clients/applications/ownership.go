@@ -0,0 +1,57 @@
+package applications
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+)
+
+const (
+	// AnnotationKeyOwnedFields declares the comma separated top-level fields of forProvider, e.g.
+	// source,destination, which are owned by the provider. If set, only the owned fields are compared
+	// and updated with a merge patch, the other fields of the application are left to other controllers.
+	// All fields are owned if not set.
+	AnnotationKeyOwnedFields = "argocd.crossplane.io/owned-fields"
+
+	errFmtUnknownOwnedField = "unknown field %q in annotation %s, expected one of %s"
+)
+
+// MetadataFields are the fields of forProvider which are set in the metadata of an application
+var MetadataFields = []string{"labels", "annotations", "finalizers"}
+
+// ParameterFields returns the names of the top-level fields of forProvider
+func ParameterFields() []string {
+	t := reflect.TypeOf(v1alpha1.ApplicationParameters{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// OwnedFields returns the top-level fields of forProvider owned by the provider for o, or nil if
+// all fields are owned
+func OwnedFields(o metav1.Object) ([]string, error) {
+	v := o.GetAnnotations()[AnnotationKeyOwnedFields]
+	if strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+	known := ParameterFields()
+	var owned []string
+	for _, f := range strings.Split(v, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(known, f) {
+			return nil, errors.Errorf(errFmtUnknownOwnedField, f, AnnotationKeyOwnedFields, strings.Join(known, ", "))
+		}
+		if !slices.Contains(owned, f) {
+			owned = append(owned, f)
+		}
+	}
+	return owned, nil
+}
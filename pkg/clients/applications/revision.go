@@ -0,0 +1,26 @@
+package applications
+
+import (
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyResolveTargetRevision enables resolving a symbolic targetRevision of the source
+	// of an application, e.g. HEAD, a branch or a tag, to a commit if set to "true". The commit is
+	// reported as resolvedRevision in the status of the application.
+	AnnotationKeyResolveTargetRevision = "argocd.crossplane.io/resolve-target-revision"
+)
+
+var commitSHA = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// IsTargetRevisionResolutionEnabled returns whether the targetRevision of o is resolved to a commit
+func IsTargetRevisionResolutionEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyResolveTargetRevision] == "true"
+}
+
+// IsCommitSHA returns whether revision is a full commit SHA, i.e. not a symbolic ref
+func IsCommitSHA(revision string) bool {
+	return commitSHA.MatchString(revision)
+}
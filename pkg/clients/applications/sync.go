@@ -0,0 +1,133 @@
+package applications
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+const (
+	// AnnotationKeySync requests a sync of the application. Every new value triggers one sync.
+	AnnotationKeySync = "argocd.crossplane.io/sync"
+
+	// AnnotationKeySyncResources limits a sync requested with AnnotationKeySync to a comma separated
+	// list of resources, each given as group/kind/name or group/kind/namespace/name. The group of
+	// core resources is empty, e.g. /Service/web. All resources are synced if not set.
+	AnnotationKeySyncResources = "argocd.crossplane.io/sync-resources"
+
+	// AnnotationKeySyncTimeout bounds the time a sync requested with AnnotationKeySync may take to
+	// complete, e.g. 10m. The application is reported as unavailable once the sync has not
+	// completed within the timeout. Default: DefaultSyncTimeout.
+	AnnotationKeySyncTimeout = "argocd.crossplane.io/sync-timeout"
+
+	// AnnotationKeySyncPrune prunes the resources which are no longer part of the source during a sync
+	// requested with AnnotationKeySync if set to "true".
+	AnnotationKeySyncPrune = "argocd.crossplane.io/sync-prune"
+
+	// AnnotationKeySyncDryRun only previews a sync requested with AnnotationKeySync if set to "true",
+	// no resource is changed. The result is reported with the TypeDryRunSync condition.
+	AnnotationKeySyncDryRun = "argocd.crossplane.io/sync-dry-run"
+
+	// AnnotationKeySyncStrategy is the strategy of a sync requested with AnnotationKeySync, either
+	// SyncStrategyApply or SyncStrategyHook. Default: SyncStrategyHook.
+	AnnotationKeySyncStrategy = "argocd.crossplane.io/sync-strategy"
+
+	// SyncStrategyApply applies the resources with kubectl apply and skips hooks
+	SyncStrategyApply = "apply"
+	// SyncStrategyHook applies the resources and runs the hooks
+	SyncStrategyHook = "hook"
+
+	// TypeDryRunSync indicates the result of the last dry run sync of an application
+	TypeDryRunSync xpv1.ConditionType = "DryRunSync"
+
+	// ReasonDryRunSucceeded is set when the last dry run sync succeeded
+	ReasonDryRunSucceeded xpv1.ConditionReason = "DryRunSucceeded"
+	// ReasonDryRunFailed is set when the last dry run sync failed
+	ReasonDryRunFailed xpv1.ConditionReason = "DryRunFailed"
+
+	// DefaultSyncTimeout is the time a requested sync may take if AnnotationKeySyncTimeout is not set
+	DefaultSyncTimeout = 30 * time.Minute
+
+	errFmtInvalidSyncResource = "invalid resource %q in annotation %s, expected group/kind/name or group/kind/namespace/name"
+	errFmtInvalidSyncTimeout  = "invalid duration %q in annotation %s"
+	errFmtInvalidSyncOption   = "invalid value %q in annotation %s, expected true or false"
+	errFmtInvalidSyncStrategy = "invalid sync strategy %q in annotation %s, expected apply or hook"
+)
+
+// ParseSyncResources parses the value of the AnnotationKeySyncResources annotation into the
+// resources of a sync request.
+func ParseSyncResources(v string) ([]*v1alpha1.SyncOperationResource, error) {
+	if strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+	var resources []*v1alpha1.SyncOperationResource
+	for _, r := range strings.Split(v, ",") {
+		r = strings.TrimSpace(r)
+		parts := strings.Split(r, "/")
+		var res *v1alpha1.SyncOperationResource
+		switch len(parts) {
+		case 3:
+			res = &v1alpha1.SyncOperationResource{Group: parts[0], Kind: parts[1], Name: parts[2]}
+		case 4:
+			res = &v1alpha1.SyncOperationResource{Group: parts[0], Kind: parts[1], Namespace: parts[2], Name: parts[3]}
+		}
+		if res == nil || res.Kind == "" || res.Name == "" {
+			return nil, errors.Errorf(errFmtInvalidSyncResource, r, AnnotationKeySyncResources)
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// SyncTimeout returns the time a sync of o requested with AnnotationKeySync may take to complete.
+func SyncTimeout(o metav1.Object) (time.Duration, error) {
+	v, ok := o.GetAnnotations()[AnnotationKeySyncTimeout]
+	if !ok {
+		return DefaultSyncTimeout, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf(errFmtInvalidSyncTimeout, v, AnnotationKeySyncTimeout)
+	}
+	return d, nil
+}
+
+// SetSyncOptions sets the prune, dry run and strategy options given by the annotations of o on req
+func SetSyncOptions(o metav1.Object, req *application.ApplicationSyncRequest) error {
+	a := o.GetAnnotations()
+	options := []struct {
+		key   string
+		value **bool
+	}{
+		{key: AnnotationKeySyncPrune, value: &req.Prune},
+		{key: AnnotationKeySyncDryRun, value: &req.DryRun},
+	}
+	for _, o := range options {
+		v, ok := a[o.key]
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Errorf(errFmtInvalidSyncOption, v, o.key)
+		}
+		*o.value = &b
+	}
+	switch v := a[AnnotationKeySyncStrategy]; v {
+	case "":
+	case SyncStrategyApply:
+		req.Strategy = &v1alpha1.SyncStrategy{Apply: &v1alpha1.SyncStrategyApply{}}
+	case SyncStrategyHook:
+		req.Strategy = &v1alpha1.SyncStrategy{Hook: &v1alpha1.SyncStrategyHook{}}
+	default:
+		return errors.Errorf(errFmtInvalidSyncStrategy, v, AnnotationKeySyncStrategy)
+	}
+	return nil
+}
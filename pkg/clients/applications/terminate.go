@@ -0,0 +1,42 @@
+package applications
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyTerminateOperationAfter terminates a running operation of the application, e.g. a
+	// stuck sync, once it runs longer than the given duration, e.g. 30m. Operations are never
+	// terminated if not set.
+	AnnotationKeyTerminateOperationAfter = "argocd.crossplane.io/terminate-operation-after"
+
+	errorNoOperationInProgress = "No operation is in progress"
+
+	errFmtInvalidTerminateAfter = "invalid duration %q in annotation %s"
+)
+
+// TerminateOperationAfter returns the duration after which a running operation of o is terminated,
+// or zero if running operations are never terminated.
+func TerminateOperationAfter(o metav1.Object) (time.Duration, error) {
+	v, ok := o.GetAnnotations()[AnnotationKeyTerminateOperationAfter]
+	if !ok {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf(errFmtInvalidTerminateAfter, v, AnnotationKeyTerminateOperationAfter)
+	}
+	return d, nil
+}
+
+// IsErrorNoOperationInProgress returns whether err reports that there was no operation to terminate
+func IsErrorNoOperationInProgress(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), errorNoOperationInProgress)
+}
@@ -17,6 +17,51 @@ import (
 // +k8s:deepcopy-gen=false
 type ConverterImpl struct{}
 
+func (c *ConverterImpl) FromArgoApplicationSourceHelm(source *v1alpha1.ApplicationSourceHelm) *v1alpha11.ApplicationSourceHelm {
+	var pV1alpha1ApplicationSourceHelm *v1alpha11.ApplicationSourceHelm
+	if source != nil {
+		var v1alpha1ApplicationSourceHelm v1alpha11.ApplicationSourceHelm
+		var stringList []string
+		if (*source).ValueFiles != nil {
+			stringList = make([]string, len((*source).ValueFiles))
+			for i := 0; i < len((*source).ValueFiles); i++ {
+				stringList[i] = (*source).ValueFiles[i]
+			}
+		}
+		v1alpha1ApplicationSourceHelm.ValueFiles = stringList
+		var v1alpha1HelmParameterList []v1alpha11.HelmParameter
+		if (*source).Parameters != nil {
+			v1alpha1HelmParameterList = make([]v1alpha11.HelmParameter, len((*source).Parameters))
+			for j := 0; j < len((*source).Parameters); j++ {
+				v1alpha1HelmParameterList[j] = c.v1alpha1HelmParameterToV1alpha1HelmParameter((*source).Parameters[j])
+			}
+		}
+		v1alpha1ApplicationSourceHelm.Parameters = v1alpha1HelmParameterList
+		pString := (*source).ReleaseName
+		v1alpha1ApplicationSourceHelm.ReleaseName = &pString
+		pString2 := (*source).Values
+		v1alpha1ApplicationSourceHelm.Values = &pString2
+		var v1alpha1HelmFileParameterList []v1alpha11.HelmFileParameter
+		if (*source).FileParameters != nil {
+			v1alpha1HelmFileParameterList = make([]v1alpha11.HelmFileParameter, len((*source).FileParameters))
+			for k := 0; k < len((*source).FileParameters); k++ {
+				v1alpha1HelmFileParameterList[k] = c.v1alpha1HelmFileParameterToV1alpha1HelmFileParameter((*source).FileParameters[k])
+			}
+		}
+		v1alpha1ApplicationSourceHelm.FileParameters = v1alpha1HelmFileParameterList
+		pString3 := (*source).Version
+		v1alpha1ApplicationSourceHelm.Version = &pString3
+		pBool := (*source).PassCredentials
+		v1alpha1ApplicationSourceHelm.PassCredentials = &pBool
+		pBool2 := (*source).IgnoreMissingValueFiles
+		v1alpha1ApplicationSourceHelm.IgnoreMissingValueFiles = &pBool2
+		pBool3 := (*source).SkipCrds
+		v1alpha1ApplicationSourceHelm.SkipCrds = &pBool3
+		v1alpha1ApplicationSourceHelm.ValuesObject = c.pRuntimeRawExtensionToV1JSON((*source).ValuesObject)
+		pV1alpha1ApplicationSourceHelm = &v1alpha1ApplicationSourceHelm
+	}
+	return pV1alpha1ApplicationSourceHelm
+}
 func (c *ConverterImpl) FromArgoApplicationStatus(source *v1alpha1.ApplicationStatus) *v1alpha11.ArgoApplicationStatus {
 	var pV1alpha1ArgoApplicationStatus *v1alpha11.ArgoApplicationStatus
 	if source != nil {
@@ -68,6 +113,69 @@ func (c *ConverterImpl) FromArgoDestination(source v1alpha1.ApplicationDestinati
 	v1alpha1ApplicationDestination.Name = &pString3
 	return v1alpha1ApplicationDestination
 }
+func (c *ConverterImpl) ToArgoApplicationSourceHelm(source *v1alpha11.ApplicationSourceHelm) *v1alpha1.ApplicationSourceHelm {
+	var pV1alpha1ApplicationSourceHelm *v1alpha1.ApplicationSourceHelm
+	if source != nil {
+		var v1alpha1ApplicationSourceHelm v1alpha1.ApplicationSourceHelm
+		var stringList []string
+		if (*source).ValueFiles != nil {
+			stringList = make([]string, len((*source).ValueFiles))
+			for i := 0; i < len((*source).ValueFiles); i++ {
+				stringList[i] = (*source).ValueFiles[i]
+			}
+		}
+		v1alpha1ApplicationSourceHelm.ValueFiles = stringList
+		var v1alpha1HelmParameterList []v1alpha1.HelmParameter
+		if (*source).Parameters != nil {
+			v1alpha1HelmParameterList = make([]v1alpha1.HelmParameter, len((*source).Parameters))
+			for j := 0; j < len((*source).Parameters); j++ {
+				v1alpha1HelmParameterList[j] = c.v1alpha1HelmParameterToV1alpha1HelmParameter2((*source).Parameters[j])
+			}
+		}
+		v1alpha1ApplicationSourceHelm.Parameters = v1alpha1HelmParameterList
+		var xstring string
+		if (*source).ReleaseName != nil {
+			xstring = *(*source).ReleaseName
+		}
+		v1alpha1ApplicationSourceHelm.ReleaseName = xstring
+		var xstring2 string
+		if (*source).Values != nil {
+			xstring2 = *(*source).Values
+		}
+		v1alpha1ApplicationSourceHelm.Values = xstring2
+		var v1alpha1HelmFileParameterList []v1alpha1.HelmFileParameter
+		if (*source).FileParameters != nil {
+			v1alpha1HelmFileParameterList = make([]v1alpha1.HelmFileParameter, len((*source).FileParameters))
+			for k := 0; k < len((*source).FileParameters); k++ {
+				v1alpha1HelmFileParameterList[k] = c.v1alpha1HelmFileParameterToV1alpha1HelmFileParameter2((*source).FileParameters[k])
+			}
+		}
+		v1alpha1ApplicationSourceHelm.FileParameters = v1alpha1HelmFileParameterList
+		var xstring3 string
+		if (*source).Version != nil {
+			xstring3 = *(*source).Version
+		}
+		v1alpha1ApplicationSourceHelm.Version = xstring3
+		var xbool bool
+		if (*source).PassCredentials != nil {
+			xbool = *(*source).PassCredentials
+		}
+		v1alpha1ApplicationSourceHelm.PassCredentials = xbool
+		var xbool2 bool
+		if (*source).IgnoreMissingValueFiles != nil {
+			xbool2 = *(*source).IgnoreMissingValueFiles
+		}
+		v1alpha1ApplicationSourceHelm.IgnoreMissingValueFiles = xbool2
+		var xbool3 bool
+		if (*source).SkipCrds != nil {
+			xbool3 = *(*source).SkipCrds
+		}
+		v1alpha1ApplicationSourceHelm.SkipCrds = xbool3
+		v1alpha1ApplicationSourceHelm.ValuesObject = ExtV1JSONToRuntimeRawExtension((*source).ValuesObject)
+		pV1alpha1ApplicationSourceHelm = &v1alpha1ApplicationSourceHelm
+	}
+	return pV1alpha1ApplicationSourceHelm
+}
 func (c *ConverterImpl) ToArgoApplicationSpec(source *v1alpha11.ApplicationParameters) *v1alpha1.ApplicationSpec {
 	var pV1alpha1ApplicationSpec *v1alpha1.ApplicationSpec
 	if source != nil {
@@ -195,114 +303,6 @@ func (c *ConverterImpl) pV1alpha1ApplicationSourceDirectoryToPV1alpha1Applicatio
 	}
 	return pV1alpha1ApplicationSourceDirectory
 }
-func (c *ConverterImpl) pV1alpha1ApplicationSourceHelmToPV1alpha1ApplicationSourceHelm(source *v1alpha1.ApplicationSourceHelm) *v1alpha11.ApplicationSourceHelm {
-	var pV1alpha1ApplicationSourceHelm *v1alpha11.ApplicationSourceHelm
-	if source != nil {
-		var v1alpha1ApplicationSourceHelm v1alpha11.ApplicationSourceHelm
-		var stringList []string
-		if (*source).ValueFiles != nil {
-			stringList = make([]string, len((*source).ValueFiles))
-			for i := 0; i < len((*source).ValueFiles); i++ {
-				stringList[i] = (*source).ValueFiles[i]
-			}
-		}
-		v1alpha1ApplicationSourceHelm.ValueFiles = stringList
-		var v1alpha1HelmParameterList []v1alpha11.HelmParameter
-		if (*source).Parameters != nil {
-			v1alpha1HelmParameterList = make([]v1alpha11.HelmParameter, len((*source).Parameters))
-			for j := 0; j < len((*source).Parameters); j++ {
-				v1alpha1HelmParameterList[j] = c.v1alpha1HelmParameterToV1alpha1HelmParameter((*source).Parameters[j])
-			}
-		}
-		v1alpha1ApplicationSourceHelm.Parameters = v1alpha1HelmParameterList
-		pString := (*source).ReleaseName
-		v1alpha1ApplicationSourceHelm.ReleaseName = &pString
-		pString2 := (*source).Values
-		v1alpha1ApplicationSourceHelm.Values = &pString2
-		var v1alpha1HelmFileParameterList []v1alpha11.HelmFileParameter
-		if (*source).FileParameters != nil {
-			v1alpha1HelmFileParameterList = make([]v1alpha11.HelmFileParameter, len((*source).FileParameters))
-			for k := 0; k < len((*source).FileParameters); k++ {
-				v1alpha1HelmFileParameterList[k] = c.v1alpha1HelmFileParameterToV1alpha1HelmFileParameter((*source).FileParameters[k])
-			}
-		}
-		v1alpha1ApplicationSourceHelm.FileParameters = v1alpha1HelmFileParameterList
-		pString3 := (*source).Version
-		v1alpha1ApplicationSourceHelm.Version = &pString3
-		pBool := (*source).PassCredentials
-		v1alpha1ApplicationSourceHelm.PassCredentials = &pBool
-		pBool2 := (*source).IgnoreMissingValueFiles
-		v1alpha1ApplicationSourceHelm.IgnoreMissingValueFiles = &pBool2
-		pBool3 := (*source).SkipCrds
-		v1alpha1ApplicationSourceHelm.SkipCrds = &pBool3
-		v1alpha1ApplicationSourceHelm.ValuesObject = c.pRuntimeRawExtensionToV1JSON((*source).ValuesObject)
-		pV1alpha1ApplicationSourceHelm = &v1alpha1ApplicationSourceHelm
-	}
-	return pV1alpha1ApplicationSourceHelm
-}
-func (c *ConverterImpl) pV1alpha1ApplicationSourceHelmToPV1alpha1ApplicationSourceHelm2(source *v1alpha11.ApplicationSourceHelm) *v1alpha1.ApplicationSourceHelm {
-	var pV1alpha1ApplicationSourceHelm *v1alpha1.ApplicationSourceHelm
-	if source != nil {
-		var v1alpha1ApplicationSourceHelm v1alpha1.ApplicationSourceHelm
-		var stringList []string
-		if (*source).ValueFiles != nil {
-			stringList = make([]string, len((*source).ValueFiles))
-			for i := 0; i < len((*source).ValueFiles); i++ {
-				stringList[i] = (*source).ValueFiles[i]
-			}
-		}
-		v1alpha1ApplicationSourceHelm.ValueFiles = stringList
-		var v1alpha1HelmParameterList []v1alpha1.HelmParameter
-		if (*source).Parameters != nil {
-			v1alpha1HelmParameterList = make([]v1alpha1.HelmParameter, len((*source).Parameters))
-			for j := 0; j < len((*source).Parameters); j++ {
-				v1alpha1HelmParameterList[j] = c.v1alpha1HelmParameterToV1alpha1HelmParameter2((*source).Parameters[j])
-			}
-		}
-		v1alpha1ApplicationSourceHelm.Parameters = v1alpha1HelmParameterList
-		var xstring string
-		if (*source).ReleaseName != nil {
-			xstring = *(*source).ReleaseName
-		}
-		v1alpha1ApplicationSourceHelm.ReleaseName = xstring
-		var xstring2 string
-		if (*source).Values != nil {
-			xstring2 = *(*source).Values
-		}
-		v1alpha1ApplicationSourceHelm.Values = xstring2
-		var v1alpha1HelmFileParameterList []v1alpha1.HelmFileParameter
-		if (*source).FileParameters != nil {
-			v1alpha1HelmFileParameterList = make([]v1alpha1.HelmFileParameter, len((*source).FileParameters))
-			for k := 0; k < len((*source).FileParameters); k++ {
-				v1alpha1HelmFileParameterList[k] = c.v1alpha1HelmFileParameterToV1alpha1HelmFileParameter2((*source).FileParameters[k])
-			}
-		}
-		v1alpha1ApplicationSourceHelm.FileParameters = v1alpha1HelmFileParameterList
-		var xstring3 string
-		if (*source).Version != nil {
-			xstring3 = *(*source).Version
-		}
-		v1alpha1ApplicationSourceHelm.Version = xstring3
-		var xbool bool
-		if (*source).PassCredentials != nil {
-			xbool = *(*source).PassCredentials
-		}
-		v1alpha1ApplicationSourceHelm.PassCredentials = xbool
-		var xbool2 bool
-		if (*source).IgnoreMissingValueFiles != nil {
-			xbool2 = *(*source).IgnoreMissingValueFiles
-		}
-		v1alpha1ApplicationSourceHelm.IgnoreMissingValueFiles = xbool2
-		var xbool3 bool
-		if (*source).SkipCrds != nil {
-			xbool3 = *(*source).SkipCrds
-		}
-		v1alpha1ApplicationSourceHelm.SkipCrds = xbool3
-		v1alpha1ApplicationSourceHelm.ValuesObject = ExtV1JSONToRuntimeRawExtension((*source).ValuesObject)
-		pV1alpha1ApplicationSourceHelm = &v1alpha1ApplicationSourceHelm
-	}
-	return pV1alpha1ApplicationSourceHelm
-}
 func (c *ConverterImpl) pV1alpha1ApplicationSourceKustomizeToPV1alpha1ApplicationSourceKustomize(source *v1alpha1.ApplicationSourceKustomize) *v1alpha11.ApplicationSourceKustomize {
 	var pV1alpha1ApplicationSourceKustomize *v1alpha11.ApplicationSourceKustomize
 	if source != nil {
@@ -442,7 +442,7 @@ func (c *ConverterImpl) pV1alpha1ApplicationSourceToPV1alpha1ApplicationSource2(
 			xstring2 = *(*source).TargetRevision
 		}
 		v1alpha1ApplicationSource.TargetRevision = xstring2
-		v1alpha1ApplicationSource.Helm = c.pV1alpha1ApplicationSourceHelmToPV1alpha1ApplicationSourceHelm2((*source).Helm)
+		v1alpha1ApplicationSource.Helm = c.ToArgoApplicationSourceHelm((*source).Helm)
 		v1alpha1ApplicationSource.Kustomize = c.pV1alpha1ApplicationSourceKustomizeToPV1alpha1ApplicationSourceKustomize2((*source).Kustomize)
 		v1alpha1ApplicationSource.Directory = c.pV1alpha1ApplicationSourceDirectoryToPV1alpha1ApplicationSourceDirectory2((*source).Directory)
 		v1alpha1ApplicationSource.Plugin = c.pV1alpha1ApplicationSourcePluginToPV1alpha1ApplicationSourcePlugin2((*source).Plugin)
@@ -929,7 +929,7 @@ func (c *ConverterImpl) v1alpha1ApplicationSourceToV1alpha1ApplicationSource(sou
 	v1alpha1ApplicationSource.Path = &pString
 	pString2 := source.TargetRevision
 	v1alpha1ApplicationSource.TargetRevision = &pString2
-	v1alpha1ApplicationSource.Helm = c.pV1alpha1ApplicationSourceHelmToPV1alpha1ApplicationSourceHelm(source.Helm)
+	v1alpha1ApplicationSource.Helm = c.FromArgoApplicationSourceHelm(source.Helm)
 	v1alpha1ApplicationSource.Kustomize = c.pV1alpha1ApplicationSourceKustomizeToPV1alpha1ApplicationSourceKustomize(source.Kustomize)
 	v1alpha1ApplicationSource.Directory = c.pV1alpha1ApplicationSourceDirectoryToPV1alpha1ApplicationSourceDirectory(source.Directory)
 	v1alpha1ApplicationSource.Plugin = c.pV1alpha1ApplicationSourcePluginToPV1alpha1ApplicationSourcePlugin(source.Plugin)
@@ -952,7 +952,7 @@ func (c *ConverterImpl) v1alpha1ApplicationSourceToV1alpha1ApplicationSource2(so
 		xstring2 = *source.TargetRevision
 	}
 	v1alpha1ApplicationSource.TargetRevision = xstring2
-	v1alpha1ApplicationSource.Helm = c.pV1alpha1ApplicationSourceHelmToPV1alpha1ApplicationSourceHelm2(source.Helm)
+	v1alpha1ApplicationSource.Helm = c.ToArgoApplicationSourceHelm(source.Helm)
 	v1alpha1ApplicationSource.Kustomize = c.pV1alpha1ApplicationSourceKustomizeToPV1alpha1ApplicationSourceKustomize2(source.Kustomize)
 	v1alpha1ApplicationSource.Directory = c.pV1alpha1ApplicationSourceDirectoryToPV1alpha1ApplicationSourceDirectory2(source.Directory)
 	v1alpha1ApplicationSource.Plugin = c.pV1alpha1ApplicationSourcePluginToPV1alpha1ApplicationSourcePlugin2(source.Plugin)
@@ -0,0 +1,39 @@
+package applicationsets
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/applicationset"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// callClient waits for the rate limit of the ProviderConfig in use before every call and
+// records the deprecations ArgoCD reports for it in the clients.Deprecations of the context.
+type callClient struct {
+	client ServiceClient
+}
+
+// withCalls returns a client which rate limits the calls of c and records their deprecations
+func withCalls(c ServiceClient) ServiceClient {
+	return &callClient{client: c}
+}
+
+func (c *callClient) Get(ctx context.Context, in *applicationset.ApplicationSetGetQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationSet, error) {
+	return clients.Call(ctx, c.client.Get, in, opts...)
+}
+
+func (c *callClient) List(ctx context.Context, in *applicationset.ApplicationSetListQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationSetList, error) {
+	return clients.Call(ctx, c.client.List, in, opts...)
+}
+
+func (c *callClient) Create(ctx context.Context, in *applicationset.ApplicationSetCreateRequest, opts ...grpc.CallOption) (*v1alpha1.ApplicationSet, error) {
+	return clients.Call(ctx, c.client.Create, in, opts...)
+}
+
+func (c *callClient) Delete(ctx context.Context, in *applicationset.ApplicationSetDeleteRequest, opts ...grpc.CallOption) (*applicationset.ApplicationSetResponse, error) {
+	return clients.Call(ctx, c.client.Delete, in, opts...)
+}
@@ -27,7 +27,7 @@ type ServiceClient interface {
 // NewApplicationSetServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
 func NewApplicationSetServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, ServiceClient) {
 	conn, repoIf := apiclient.NewClientOrDie(clientOpts).NewApplicationSetClientOrDie()
-	return conn, repoIf
+	return conn, withCalls(repoIf)
 }
 
 // IsNotFound returns true if the error code is NotFound
@@ -32,6 +32,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/version"
+)
+
+const (
+	errNoProviderConfigRef = "providerConfigRef is not given"
+	errTrackUsage          = "cannot track ProviderConfig usage"
 )
 
 // NewClient creates new argocd Client with provided argocd Configurations/Credentials.
@@ -53,40 +59,65 @@ func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*argo
 	case mg.GetProviderConfigReference() != nil:
 		return UseProviderConfig(ctx, c, mg)
 	default:
-		return nil, errors.New("providerConfigRef is not given")
+		return nil, errors.New(errNoProviderConfigRef)
 	}
 }
 
 // UseProviderConfig to produce a config that can be used to authenticate to AWS.
 func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*argocd.ClientOptions, error) {
-	pc := &v1alpha1.ProviderConfig{}
-	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, "cannot get referenced Provider")
-	}
+	opts, _, err := useProviderConfig(ctx, c, mg)
+	return opts, err
+}
+
+// useProviderConfig produces the client options of the ProviderConfig referenced by mg
+// and tracks its usage.
+func useProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*argocd.ClientOptions, *v1alpha1.ProviderConfig, error) {
+	return providerConfigOptions(ctx, c, mg.GetProviderConfigReference().Name, func() error {
+		return TrackProviderConfigUsage(ctx, c, mg)
+	})
+}
 
+// TrackProviderConfigUsage records that mg uses the ProviderConfig it references, so that the
+// ProviderConfig cannot be deleted while mg exists.
+func TrackProviderConfigUsage(ctx context.Context, c client.Client, mg resource.Managed) error {
 	t := resource.NewProviderConfigUsageTracker(c, &v1alpha1.ProviderConfigUsage{})
-	if err := t.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+	return errors.Wrap(t.Track(ctx, mg), errTrackUsage)
+}
+
+// providerConfigOptions produces the client options of the ProviderConfig name and returns
+// it alongside. track is called once the ProviderConfig was found.
+func providerConfigOptions(ctx context.Context, c client.Client, name string, track func() error) (*argocd.ClientOptions, *v1alpha1.ProviderConfig, error) {
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get referenced Provider")
 	}
 
-	insecure := ptr.Deref(pc.Spec.Insecure, false)
-	plaintext := ptr.Deref(pc.Spec.PlainText, false)
+	if err := track(); err != nil {
+		return nil, nil, err
+	}
 
 	authToken, err := authFromCredentials(ctx, c, pc.Spec.Credentials)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	grpcWeb := ptr.Deref(pc.Spec.GRPCWeb, false)
-	grpcWebRoot := ptr.Deref(pc.Spec.GRPCWebRootPath, "")
+	return clientOptions(&pc.Spec, authToken), pc, nil
+}
 
+// UserAgent returns the default user agent of the provider
+func UserAgent() string {
+	return "provider-argocd/" + version.Version
+}
+
+func clientOptions(spec *v1alpha1.ProviderConfigSpec, authToken string) *argocd.ClientOptions {
 	return &argocd.ClientOptions{
-		ServerAddr:      pc.Spec.ServerAddr,
-		Insecure:        insecure,
-		PlainText:       plaintext,
+		ServerAddr:      spec.ServerAddr,
+		Insecure:        ptr.Deref(spec.Insecure, false),
+		PlainText:       ptr.Deref(spec.PlainText, false),
 		AuthToken:       authToken,
-		GRPCWeb:         grpcWeb,
-		GRPCWebRootPath: grpcWebRoot,
-	}, nil
+		GRPCWeb:         ptr.Deref(spec.GRPCWeb, false),
+		GRPCWebRootPath: ptr.Deref(spec.GRPCWebRootPath, ""),
+		UserAgent:       ptr.Deref(spec.UserAgent, UserAgent()),
+	}
 }
 
 func authFromCredentials(ctx context.Context, c client.Client, creds v1alpha1.ProviderCredentials) (string, error) {
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/version"
+)
+
+func TestClientOptions(t *testing.T) {
+	cases := map[string]struct {
+		spec v1alpha1.ProviderConfigSpec
+		want *argocd.ClientOptions
+	}{
+		"DefaultUserAgent": {
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr: "argocd-server.argocd.svc:443",
+			},
+			want: &argocd.ClientOptions{
+				ServerAddr: "argocd-server.argocd.svc:443",
+				AuthToken:  "token",
+				UserAgent:  "provider-argocd/" + version.Version,
+			},
+		},
+		"ConfiguredUserAgent": {
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr: "argocd-server.argocd.svc:443",
+				Insecure:   ptr.To(true),
+				UserAgent:  ptr.To("provider-argocd-team-a"),
+			},
+			want: &argocd.ClientOptions{
+				ServerAddr: "argocd-server.argocd.svc:443",
+				Insecure:   true,
+				AuthToken:  "token",
+				UserAgent:  "provider-argocd-team-a",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := clientOptions(&tc.spec, "token")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("clientOptions(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// callClient waits for the rate limit of the ProviderConfig in use before every call and
+// records the deprecations ArgoCD reports for it in the clients.Deprecations of the context.
+type callClient struct {
+	client ServiceClient
+}
+
+// withCalls returns a client which rate limits the calls of c and records their deprecations
+func withCalls(c ServiceClient) ServiceClient {
+	return &callClient{client: c}
+}
+
+func (c *callClient) Create(ctx context.Context, in *cluster.ClusterCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Call(ctx, c.client.Create, in, opts...)
+}
+
+func (c *callClient) Get(ctx context.Context, in *cluster.ClusterQuery, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Call(ctx, c.client.Get, in, opts...)
+}
+
+func (c *callClient) Update(ctx context.Context, in *cluster.ClusterUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Call(ctx, c.client.Update, in, opts...)
+}
+
+func (c *callClient) Delete(ctx context.Context, in *cluster.ClusterQuery, opts ...grpc.CallOption) (*cluster.ClusterResponse, error) {
+	return clients.Call(ctx, c.client.Delete, in, opts...)
+}
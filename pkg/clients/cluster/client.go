@@ -10,6 +10,8 @@ import (
 	"github.com/argoproj/argo-cd/v2/util/io"
 
 	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 )
 
 const (
@@ -30,9 +32,9 @@ type ServiceClient interface {
 }
 
 // NewClusterServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewClusterServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, cluster.ClusterServiceClient) {
+func NewClusterServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, ServiceClient) {
 	conn, repoIf := apiclient.NewClientOrDie(clientOpts).NewClusterClientOrDie()
-	return conn, repoIf
+	return conn, withCalls(repoIf)
 }
 
 // IsErrorClusterNotFound helper function to test for errorClusterNotFound error.
@@ -50,3 +52,9 @@ func IsErrorPermissionDenied(err error) bool {
 	}
 	return strings.Contains(err.Error(), errorPermissionDenied)
 }
+
+// IsInCluster returns whether server is the in-cluster destination of ArgoCD, i.e. the
+// Kubernetes cluster ArgoCD runs in.
+func IsInCluster(server string) bool {
+	return server != "" && clients.CanonicalServerURL(server) == v1alpha1.KubernetesInternalAPIServerAddr
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// ExternalNameIndexKey is the field index of the managed resources by the ArgoCD instance
+	// and external name of their ArgoCD object
+	ExternalNameIndexKey = "argocd.crossplane.io/external-name"
+
+	// TypeConflict indicates whether another resource manages the same ArgoCD object as a resource
+	TypeConflict xpv1.ConditionType = "Conflict"
+
+	// ReasonExternalNameConflict is set when an older resource already manages the ArgoCD object of a resource
+	ReasonExternalNameConflict xpv1.ConditionReason = "ExternalNameConflict"
+	// ReasonNoConflict is set when a resource no longer conflicts with another resource
+	ReasonNoConflict xpv1.ConditionReason = "NoConflict"
+
+	errListConflicts = "cannot list resources with the same external name"
+	errFmtConflict   = "%s %q is already managed by %s"
+)
+
+// ExternalNameIndexValue returns the value of mg in the ExternalNameIndexKey index, i.e. the name of its
+// ProviderConfig and its external name. Resources without an external name aren't indexed.
+func ExternalNameIndexValue(mg resource.Managed) string {
+	name := meta.GetExternalName(mg)
+	if name == "" {
+		return ""
+	}
+	pc := "default"
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		pc = ref.Name
+	}
+	return pc + "/" + name
+}
+
+// IndexExternalName returns the values of obj in the ExternalNameIndexKey index
+func IndexExternalName(obj client.Object) []string {
+	mg, ok := obj.(resource.Managed)
+	if !ok {
+		return nil
+	}
+	if v := ExternalNameIndexValue(mg); v != "" {
+		return []string{v}
+	}
+	return nil
+}
+
+// Conflict returns a condition that indicates that the resource owner already manages the ArgoCD object of a resource
+func Conflict(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExternalNameConflict,
+		Message:            err.Error(),
+	}
+}
+
+// NoConflict returns a condition that indicates that a resource no longer conflicts with another resource
+func NoConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoConflict,
+	}
+}
+
+// WithConflictHandling wraps an ExternalClient managing resources of the given kind, so that two resources
+// with the same external name don't fight over the same ArgoCD object. The oldest of them owns the object,
+// all others report the Conflict condition and are never created, updated or deleted. The resources are
+// looked up in the ExternalNameIndexKey index of kube, which newList returns a list of.
+func WithConflictHandling(c managed.ExternalClient, kube client.Reader, kind string, newList func() resource.ManagedList) managed.ExternalClient {
+	return &conflictHandlingClient{client: c, kube: kube, kind: kind, newList: newList}
+}
+
+type conflictHandlingClient struct {
+	client  managed.ExternalClient
+	kube    client.Reader
+	kind    string
+	newList func() resource.ManagedList
+}
+
+func (c *conflictHandlingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	owner, err := c.owner(ctx, mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if owner == nil {
+		if mg.GetCondition(TypeConflict).Reason == ReasonExternalNameConflict {
+			mg.SetConditions(NoConflict())
+		}
+		return c.client.Observe(ctx, mg)
+	}
+	// a conflicting resource being deleted is released without deleting the
+	// ArgoCD object of its owner
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	err = errors.Errorf(errFmtConflict, c.kind, meta.GetExternalName(mg), owner.GetName())
+	mg.SetConditions(Conflict(err))
+	return managed.ExternalObservation{}, err
+}
+
+func (c *conflictHandlingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return c.client.Create(ctx, mg)
+}
+
+func (c *conflictHandlingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return c.client.Update(ctx, mg)
+}
+
+func (c *conflictHandlingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return c.client.Delete(ctx, mg)
+}
+
+// owner returns the resource which owns the ArgoCD object of mg, if that's not mg itself
+func (c *conflictHandlingClient) owner(ctx context.Context, mg resource.Managed) (resource.Managed, error) {
+	v := ExternalNameIndexValue(mg)
+	if v == "" {
+		return nil, nil
+	}
+	l := c.newList()
+	if err := c.kube.List(ctx, l, client.MatchingFields{ExternalNameIndexKey: v}); err != nil {
+		return nil, errors.Wrap(err, errListConflicts)
+	}
+	var owner resource.Managed
+	for _, o := range l.GetItems() {
+		// the index of the cache may be stale, so the external name is checked again
+		if o.GetUID() == mg.GetUID() || ExternalNameIndexValue(o) != v {
+			continue
+		}
+		if isOlder(o, mg) && (owner == nil || isOlder(o, owner)) {
+			owner = o
+		}
+	}
+	return owner, nil
+}
+
+// isOlder returns whether a was created before b. Resources created at the same time are ordered by name.
+func isOlder(a, b metav1.Object) bool {
+	ta, tb := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !ta.Equal(&tb) {
+		return ta.Before(&tb)
+	}
+	return a.GetName() < b.GetName()
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// managedList is a list of fake managed resources
+type managedList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []*fake.Managed
+}
+
+func (l *managedList) DeepCopyObject() runtime.Object { return l }
+
+func (l *managedList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = l.Items[i]
+	}
+	return items
+}
+
+func TestConflictHandling(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	newManaged := func(name, externalName string, created time.Time, m ...func(*fake.Managed)) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName(name)
+		mg.SetUID(types.UID(name))
+		mg.SetCreationTimestamp(metav1.NewTime(created))
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: "argocd"})
+		meta.SetExternalName(mg, externalName)
+		for _, f := range m {
+			f(mg)
+		}
+		return mg
+	}
+	deleted := func(mg *fake.Managed) { mg.SetDeletionTimestamp(&metav1.Time{Time: now}) }
+	withCondition := func(c xpv1.Condition) func(*fake.Managed) {
+		return func(mg *fake.Managed) { mg.SetConditions(c) }
+	}
+
+	type want struct {
+		observation managed.ExternalObservation
+		observed    bool
+		err         error
+		conditions  []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		mg      *fake.Managed
+		others  []*fake.Managed
+		listErr error
+		want    want
+	}{
+		"NoConflict": {
+			mg: newManaged("team-a", "team", now),
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				observed:    true,
+			},
+		},
+		"OwnerOfNewer": {
+			mg:     newManaged("team-a", "team", now),
+			others: []*fake.Managed{newManaged("team-b", "team", now.Add(time.Minute))},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				observed:    true,
+			},
+		},
+		"ConflictWithOlder": {
+			mg:     newManaged("team-b", "team", now),
+			others: []*fake.Managed{newManaged("team-a", "team", now.Add(-time.Minute))},
+			want: want{
+				err:        errors.Errorf(errFmtConflict, "Project", "team", "team-a"),
+				conditions: []xpv1.Condition{Conflict(errors.Errorf(errFmtConflict, "Project", "team", "team-a"))},
+			},
+		},
+		"ConflictWithOldest": {
+			mg: newManaged("team-c", "team", now),
+			others: []*fake.Managed{
+				newManaged("team-b", "team", now.Add(-time.Minute)),
+				newManaged("team-a", "team", now.Add(-time.Hour)),
+			},
+			want: want{
+				err:        errors.Errorf(errFmtConflict, "Project", "team", "team-a"),
+				conditions: []xpv1.Condition{Conflict(errors.Errorf(errFmtConflict, "Project", "team", "team-a"))},
+			},
+		},
+		"ConflictCreatedAtSameTime": {
+			mg:     newManaged("team-b", "team", now),
+			others: []*fake.Managed{newManaged("team-a", "team", now)},
+			want: want{
+				err:        errors.Errorf(errFmtConflict, "Project", "team", "team-a"),
+				conditions: []xpv1.Condition{Conflict(errors.Errorf(errFmtConflict, "Project", "team", "team-a"))},
+			},
+		},
+		"StaleIndexIgnored": {
+			mg:     newManaged("team-b", "team", now),
+			others: []*fake.Managed{newManaged("team-a", "other-team", now.Add(-time.Minute))},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				observed:    true,
+			},
+		},
+		"ConflictResolved": {
+			mg: newManaged("team-b", "team", now, withCondition(Conflict(errBoom))),
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				observed:    true,
+				conditions:  []xpv1.Condition{NoConflict()},
+			},
+		},
+		"DeletedConflictReleased": {
+			mg:     newManaged("team-b", "team", now, deleted),
+			others: []*fake.Managed{newManaged("team-a", "team", now.Add(-time.Minute))},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ListFailed": {
+			mg:      newManaged("team-b", "team", now),
+			listErr: errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errListConflicts),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+					lo := &client.ListOptions{}
+					lo.ApplyOptions(opts)
+					if v, ok := lo.FieldSelector.RequiresExactMatch(ExternalNameIndexKey); !ok || v != "argocd/team" {
+						return errors.Errorf("unexpected field selector %s", lo.FieldSelector)
+					}
+					obj.(*managedList).Items = append(tc.others, tc.mg)
+					return tc.listErr
+				},
+			}
+			observed := false
+			c := WithConflictHandling(managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					observed = true
+					return managed.ExternalObservation{ResourceExists: true}, nil
+				},
+			}, kube, "Project", func() resource.ManagedList { return &managedList{} })
+
+			got, err := c.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observation, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if observed != tc.want.observed {
+				t.Errorf("Observe(...): want observed %t, got %t", tc.want.observed, observed)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.mg.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Observe(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIndexExternalName(t *testing.T) {
+	cases := map[string]struct {
+		mg   *fake.Managed
+		want []string
+	}{
+		"DefaultProviderConfig": {
+			mg: func() *fake.Managed {
+				mg := &fake.Managed{}
+				meta.SetExternalName(mg, "team")
+				return mg
+			}(),
+			want: []string{"default/team"},
+		},
+		"ProviderConfig": {
+			mg: func() *fake.Managed {
+				mg := &fake.Managed{}
+				mg.SetProviderConfigReference(&xpv1.Reference{Name: "argocd"})
+				meta.SetExternalName(mg, "team")
+				return mg
+			}(),
+			want: []string{"argocd/team"},
+		},
+		"NoExternalName": {
+			mg: &fake.Managed{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IndexExternalName(tc.mg)); diff != "" {
+				t.Errorf("IndexExternalName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
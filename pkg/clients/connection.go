@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// TypeConnection indicates whether ArgoCD could be reached when a resource was last reconciled
+	TypeConnection xpv1.ConditionType = "ArgoCDConnection"
+
+	// ReasonConnectionUnavailable is set when no ArgoCD instance of a resource could be reached
+	ReasonConnectionUnavailable xpv1.ConditionReason = "ConnectionUnavailable"
+	// ReasonConnected is set when ArgoCD can be reached again after it was unavailable
+	ReasonConnected xpv1.ConditionReason = "Connected"
+
+	errArgoCDUnavailable = "ArgoCD is unavailable"
+)
+
+// ConnectionUnavailable returns a condition that indicates that ArgoCD could not be reached
+func ConnectionUnavailable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConnection,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConnectionUnavailable,
+		Message:            err.Error(),
+	}
+}
+
+// Connected returns a condition that indicates that ArgoCD can be reached again
+func Connected() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConnection,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConnected,
+	}
+}
+
+// handleConnection reports an Unavailable error with the ConnectionUnavailable condition of mg and
+// marks mg as connected again once ArgoCD can be reached. The error is returned wrapped, so that it is
+// distinguishable from errors of the resource itself and the reconcile is retried with backoff.
+func handleConnection(mg resource.Managed, err error) error {
+	switch {
+	case IsErrorUnavailable(err):
+		err = errors.Wrap(err, errArgoCDUnavailable)
+		mg.SetConditions(ConnectionUnavailable(err))
+	case err == nil && mg.GetCondition(TypeConnection).Reason == ReasonConnectionUnavailable:
+		mg.SetConditions(Connected())
+	}
+	return err
+}
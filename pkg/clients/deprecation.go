@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// DeprecationTrailerKey is the gRPC trailer in which ArgoCD reports the deprecated fields and
+	// APIs used by a request. It may be repeated for several deprecations.
+	DeprecationTrailerKey = "deprecation"
+
+	// TypeDeprecation indicates whether ArgoCD reported deprecations when a resource was last reconciled
+	TypeDeprecation xpv1.ConditionType = "Deprecation"
+
+	// ReasonDeprecated is set when ArgoCD reports that a resource uses deprecated fields or APIs
+	ReasonDeprecated xpv1.ConditionReason = "Deprecated"
+	// ReasonNotDeprecated is set when ArgoCD no longer reports deprecations for a resource
+	ReasonNotDeprecated xpv1.ConditionReason = "NotDeprecated"
+)
+
+// Deprecated returns a condition that indicates that ArgoCD reported the deprecations msgs
+func Deprecated(msgs []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeprecation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeprecated,
+		Message:            strings.Join(msgs, "; "),
+	}
+}
+
+// NotDeprecated returns a condition that indicates that ArgoCD no longer reports deprecations
+func NotDeprecated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeprecation,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotDeprecated,
+	}
+}
+
+type deprecationsKey struct{}
+
+// Deprecations collects the trailers of the calls to ArgoCD made for an operation on a resource.
+type Deprecations struct {
+	mu       sync.Mutex
+	trailers []*metadata.MD
+}
+
+// WithDeprecations returns a context whose calls to ArgoCD are collected by the returned Deprecations
+func WithDeprecations(ctx context.Context) (context.Context, *Deprecations) {
+	d := &Deprecations{}
+	return context.WithValue(ctx, deprecationsKey{}, d), d
+}
+
+// DeprecationTrailer returns a call option which records the trailers of a call in the Deprecations
+// of ctx. It does nothing if the calls of ctx aren't collected.
+func DeprecationTrailer(ctx context.Context) grpc.CallOption {
+	d, ok := ctx.Value(deprecationsKey{}).(*Deprecations)
+	if !ok {
+		return grpc.EmptyCallOption{}
+	}
+	md := &metadata.MD{}
+	d.mu.Lock()
+	d.trailers = append(d.trailers, md)
+	d.mu.Unlock()
+	return grpc.Trailer(md)
+}
+
+// Messages returns the sorted and deduplicated deprecations reported by the collected calls
+func (d *Deprecations) Messages() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := map[string]bool{}
+	var msgs []string
+	for _, md := range d.trailers {
+		for _, m := range md.Get(DeprecationTrailerKey) {
+			if m = strings.TrimSpace(m); m != "" && !seen[m] {
+				seen[m] = true
+				msgs = append(msgs, m)
+			}
+		}
+	}
+	sort.Strings(msgs)
+	return msgs
+}
+
+// handleDeprecations reports the deprecations collected by d with the Deprecation condition of mg
+// and marks mg as not deprecated once a successful operation reports none.
+func handleDeprecations(mg resource.Managed, d *Deprecations, err error) {
+	msgs := d.Messages()
+	switch {
+	case len(msgs) > 0:
+		mg.SetConditions(Deprecated(msgs))
+	case err == nil && mg.GetCondition(TypeDeprecation).Reason == ReasonDeprecated:
+		mg.SetConditions(NotDeprecated())
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// respond simulates a call to ArgoCD whose response carries the trailers md
+func respond(ctx context.Context, md metadata.MD) {
+	if opt, ok := DeprecationTrailer(ctx).(grpc.TrailerCallOption); ok {
+		*opt.TrailerAddr = md
+	}
+}
+
+func TestFallbackClientDeprecations(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err        error
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		conditions []xpv1.Condition
+		trailers   []metadata.MD
+		err        error
+		want       want
+	}{
+		"Deprecated": {
+			trailers: []metadata.MD{
+				metadata.Pairs(DeprecationTrailerKey, "spec.source.helm.values is deprecated, use valuesObject"),
+				metadata.Pairs(DeprecationTrailerKey, "spec.info is deprecated", DeprecationTrailerKey, "spec.source.helm.values is deprecated, use valuesObject"),
+			},
+			want: want{
+				conditions: []xpv1.Condition{Deprecated([]string{"spec.info is deprecated", "spec.source.helm.values is deprecated, use valuesObject"})},
+			},
+		},
+		"DeprecatedOnError": {
+			trailers: []metadata.MD{metadata.Pairs(DeprecationTrailerKey, "spec.info is deprecated")},
+			err:      errBoom,
+			want: want{
+				err:        errBoom,
+				conditions: []xpv1.Condition{Deprecated([]string{"spec.info is deprecated"})},
+			},
+		},
+		"NotDeprecated": {
+			trailers: []metadata.MD{metadata.Pairs("other", "value")},
+			want:     want{},
+		},
+		"NoLongerDeprecated": {
+			conditions: []xpv1.Condition{Deprecated([]string{"spec.info is deprecated"})},
+			trailers:   []metadata.MD{{}},
+			want: want{
+				conditions: []xpv1.Condition{NotDeprecated()},
+			},
+		},
+		"StillDeprecatedOnError": {
+			conditions: []xpv1.Condition{Deprecated([]string{"spec.info is deprecated"})},
+			err:        errBoom,
+			want: want{
+				err:        errBoom,
+				conditions: []xpv1.Condition{Deprecated([]string{"spec.info is deprecated"})},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.conditions...)
+
+			c := &FallbackClient{
+				names: []string{"primary"},
+				client: managed.ExternalClientFns{
+					ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						for _, md := range tc.trailers {
+							respond(ctx, md)
+						}
+						return managed.ExternalObservation{ResourceExists: true}, tc.err
+					},
+				},
+			}
+			_, err := c.Observe(context.Background(), mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, mg.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Observe(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
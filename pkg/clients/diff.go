@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxDiffSummaryLength bounds the length of the string returned by DiffSummary.String
+const MaxDiffSummaryLength = 256
+
+// DiffSummary collects short human-readable descriptions of how a desired resource differs
+// from the observed one, e.g. "description changed" or "2 labels added".
+type DiffSummary []string
+
+// Changed records that field differs.
+func (d *DiffSummary) Changed(field string) {
+	*d = append(*d, field+" changed")
+}
+
+// ChangedIf records that field differs if differs is true.
+func (d *DiffSummary) ChangedIf(field string, differs bool) {
+	if differs {
+		d.Changed(field)
+	}
+}
+
+// Map records how many entries named noun are added, removed or changed in desired compared to observed.
+func (d *DiffSummary) Map(noun string, desired, observed map[string]string) {
+	added, removed, changed := 0, 0, 0
+	for k, v := range desired {
+		o, ok := observed[k]
+		switch {
+		case !ok:
+			added++
+		case o != v:
+			changed++
+		}
+	}
+	for k := range observed {
+		if _, ok := desired[k]; !ok {
+			removed++
+		}
+	}
+	d.count(noun, added, "added")
+	d.count(noun, removed, "removed")
+	d.count(noun, changed, "changed")
+}
+
+func (d *DiffSummary) count(noun string, n int, verb string) {
+	switch {
+	case n == 1:
+		*d = append(*d, fmt.Sprintf("1 %s %s", noun, verb))
+	case n > 1:
+		*d = append(*d, fmt.Sprintf("%d %ss %s", n, noun, verb))
+	}
+}
+
+// String joins the recorded differences. Differences which don't fit into MaxDiffSummaryLength
+// are only counted.
+func (d DiffSummary) String() string {
+	var b strings.Builder
+	for i, s := range d {
+		sep := ""
+		if i > 0 {
+			sep = ", "
+		}
+		more := ""
+		if i < len(d)-1 {
+			more = fmt.Sprintf(", and %d more", len(d)-i-1)
+		}
+		if b.Len()+len(sep)+len(s)+len(more) > MaxDiffSummaryLength {
+			fmt.Fprintf(&b, "%sand %d more", sep, len(d)-i)
+			break
+		}
+		b.WriteString(sep + s)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffSummary(t *testing.T) {
+	long := strings.Repeat("x", 100)
+
+	cases := map[string]struct {
+		record func(d *DiffSummary)
+		want   string
+	}{
+		"Empty": {
+			record: func(d *DiffSummary) {},
+			want:   "",
+		},
+		"Changed": {
+			record: func(d *DiffSummary) {
+				d.Changed("description")
+				d.ChangedIf("sourceRepos", false)
+				d.ChangedIf("roles", true)
+			},
+			want: "description changed, roles changed",
+		},
+		"Map": {
+			record: func(d *DiffSummary) {
+				d.Map("label", map[string]string{"a": "1", "b": "2", "c": "3"}, map[string]string{"c": "4", "d": "5"})
+			},
+			want: "2 labels added, 1 label removed, 1 label changed",
+		},
+		"Bounded": {
+			record: func(d *DiffSummary) {
+				d.Changed(long)
+				d.Changed(long)
+				d.Changed(long)
+				d.Changed("roles")
+			},
+			want: long + " changed, " + long + " changed, and 2 more",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var d DiffSummary
+			tc.record(&d)
+			got := d.String()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("String(): -want, +got:\n%s", diff)
+			}
+			if len(got) > MaxDiffSummaryLength {
+				t.Errorf("String(): length %d exceeds %d", len(got), MaxDiffSummaryLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	// AnnotationKeyFallbackProviderConfigs is a comma separated list of ProviderConfigs which are tried
+	// in order when the ArgoCD instance of the referenced ProviderConfig is unavailable or the
+	// ProviderConfig cannot be loaded. It is an annotation rather than a field, as the
+	// providerConfigRef is part of the ResourceSpec shared by all managed resources of crossplane.
+	AnnotationKeyFallbackProviderConfigs = "argocd.crossplane.io/fallback-provider-configs"
+
+	// TypeProviderConfig indicates which ProviderConfig serves a resource with fallback ProviderConfigs
+	TypeProviderConfig xpv1.ConditionType = "ArgoCDProviderConfig"
+
+	// ReasonPrimaryProviderConfig is set when the referenced ProviderConfig serves a resource
+	ReasonPrimaryProviderConfig xpv1.ConditionReason = "PrimaryProviderConfig"
+	// ReasonFallbackProviderConfig is set when a fallback ProviderConfig serves a resource
+	ReasonFallbackProviderConfig xpv1.ConditionReason = "FallbackProviderConfig"
+
+	errFmtFallbackProviderConfig = "cannot use fallback ProviderConfig %s"
+	errorUnavailable             = "code = Unavailable"
+)
+
+// ConnectFn connects an ExternalClient to the ArgoCD instance described by cfg,
+// which is configured by the ProviderConfig spec in use.
+// The returned io.Closer closes the connections of the client.
+type ConnectFn func(cfg *argocd.ClientOptions, spec *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer)
+
+// Closers closes all of its elements
+type Closers []io.Closer
+
+// Close closes all closers and returns the first error
+func (cs Closers) Close() error {
+	var err error
+	for _, c := range cs {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// IsErrorUnavailable returns whether err is an Unavailable error, i.e. ArgoCD could not be reached
+func IsErrorUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(errors.Cause(err)); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	return strings.Contains(err.Error(), errorUnavailable)
+}
+
+// FallbackProviderConfigs returns the fallback ProviderConfigs given by the
+// AnnotationKeyFallbackProviderConfigs annotation of o
+func FallbackProviderConfigs(o metav1.Object) []string {
+	var names []string
+	for _, n := range strings.Split(o.GetAnnotations()[AnnotationKeyFallbackProviderConfigs], ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// ProviderConfigServed returns a condition that indicates which ProviderConfig serves a resource
+func ProviderConfigServed(name string, fallback bool) xpv1.Condition {
+	reason := ReasonPrimaryProviderConfig
+	if fallback {
+		reason = ReasonFallbackProviderConfig
+	}
+	return xpv1.Condition{
+		Type:               TypeProviderConfig,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            fmt.Sprintf("served by ProviderConfig %s", name),
+	}
+}
+
+// ConnectWithFallback connects to the ArgoCD instance of the ProviderConfig referenced by mg.
+// If the ProviderConfig cannot be loaded or ArgoCD is unavailable, the returned client connects
+// to the fallback ProviderConfigs of mg in order and retries the operation. Every reconcile starts
+// with the referenced ProviderConfig again, so that resources return to it once it is reachable.
+// The usage of every ProviderConfig in use is tracked, so that it cannot be deleted.
+// Every call to ArgoCD waits for the rate limit of the ProviderConfig in use, if any.
+// The connections belong to the returned client and are closed once ctx is done. The managed
+// reconciler connects with a context which ends with the reconcile, so concurrent reconciles
+// never close the connections of each other.
+func ConnectWithFallback(ctx context.Context, kube client.Client, mg resource.Managed, connect ConnectFn) (*FallbackClient, error) {
+	if mg.GetProviderConfigReference() == nil {
+		return nil, errors.New(errNoProviderConfigRef)
+	}
+	if err := TrackProviderConfigUsage(ctx, kube, mg); err != nil {
+		return nil, err
+	}
+	c := &FallbackClient{
+		kube:    kube,
+		connect: connect,
+		names:   append([]string{mg.GetProviderConfigReference().Name}, FallbackProviderConfigs(mg)...),
+		current: -1,
+	}
+	if err := c.next(ctx, mg); err != nil {
+		return nil, err
+	}
+	context.AfterFunc(ctx, func() { _ = c.Disconnect(context.Background()) })
+	return c, nil
+}
+
+// A FallbackClient is an ExternalClient which switches to the next
+// ProviderConfig when ArgoCD is unavailable.
+type FallbackClient struct {
+	kube    client.Client
+	connect ConnectFn
+	names   []string
+	current int
+	client  managed.ExternalClient
+	limiter *rate.Limiter
+
+	mu      sync.Mutex
+	closers Closers
+}
+
+// Observe the external resource.
+func (c *FallbackClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	var o managed.ExternalObservation
+	err := c.do(ctx, mg, func(ctx context.Context, ext managed.ExternalClient) (err error) {
+		o, err = ext.Observe(ctx, mg)
+		return err
+	})
+	return o, err
+}
+
+// Create the external resource.
+func (c *FallbackClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	var o managed.ExternalCreation
+	err := c.do(ctx, mg, func(ctx context.Context, ext managed.ExternalClient) (err error) {
+		o, err = ext.Create(ctx, mg)
+		return err
+	})
+	return o, err
+}
+
+// Update the external resource.
+func (c *FallbackClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	var o managed.ExternalUpdate
+	err := c.do(ctx, mg, func(ctx context.Context, ext managed.ExternalClient) (err error) {
+		o, err = ext.Update(ctx, mg)
+		return err
+	})
+	return o, err
+}
+
+// Delete the external resource.
+func (c *FallbackClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return c.do(ctx, mg, func(ctx context.Context, ext managed.ExternalClient) error {
+		return ext.Delete(ctx, mg)
+	})
+}
+
+// Disconnect closes the connections to all ArgoCD instances used by the client.
+func (c *FallbackClient) Disconnect(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	closers := c.closers
+	c.closers = nil
+	return closers.Close()
+}
+
+// do runs fn with the current client and moves on to the next ProviderConfig
+// as long as ArgoCD is unavailable. Every call of fn to ArgoCD waits for the rate
+// limit of the current ProviderConfig. If no ArgoCD instance is available, mg is
+// marked with the ConnectionUnavailable condition. The deprecations ArgoCD reports
+// for the calls of fn are reported with the Deprecation condition of mg.
+func (c *FallbackClient) do(ctx context.Context, mg resource.Managed, fn func(context.Context, managed.ExternalClient) error) error {
+	for {
+		dctx, d := WithDeprecations(withRateLimit(ctx, c.names[c.current], c.limiter))
+		err := fn(dctx, c.client)
+		if !IsErrorUnavailable(err) || c.current+1 >= len(c.names) {
+			if err == nil && len(c.names) > 1 {
+				mg.SetConditions(ProviderConfigServed(c.names[c.current], c.current > 0))
+			}
+			handleDeprecations(mg, d, err)
+			return withResourceExhaustedHint(handleConnection(mg, err))
+		}
+		if err := c.next(ctx, mg); err != nil {
+			return err
+		}
+	}
+}
+
+// next connects to the next ProviderConfig of mg which can be loaded. ProviderConfigs which cannot
+// be loaded, e.g. because their credentials are missing, are skipped. The error of the first
+// skipped ProviderConfig is returned if none is left.
+func (c *FallbackClient) next(ctx context.Context, mg resource.Managed) error {
+	var first error
+	for c.current+1 < len(c.names) {
+		c.current++
+		name := c.names[c.current]
+		// the usage of the referenced ProviderConfig is tracked on connect
+		track := func() error { return nil }
+		if c.current > 0 {
+			track = func() error { return trackFallbackProviderConfigUsage(ctx, c.kube, mg, name) }
+		}
+		cfg, pc, err := providerConfigOptions(ctx, c.kube, name, track)
+		if err != nil {
+			if c.current > 0 {
+				err = errors.Wrapf(err, errFmtFallbackProviderConfig, name)
+			}
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		ext, conn := connectWithMaxGRPCMessageSize(&pc.Spec, cfg, c.connect)
+		c.client = ext
+		c.limiter = rateLimiterFor(name, &pc.Spec)
+		c.mu.Lock()
+		c.closers = append(c.closers, conn)
+		c.mu.Unlock()
+		return nil
+	}
+	return first
+}
+
+// trackFallbackProviderConfigUsage records that mg uses the fallback ProviderConfig name, so that it
+// cannot be deleted while mg exists. The usage is kept in addition to the usage of the referenced
+// ProviderConfig and is garbage collected with mg.
+func trackFallbackProviderConfigUsage(ctx context.Context, c client.Client, mg resource.Managed, name string) error {
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	pcu := &v1alpha1.ProviderConfigUsage{}
+	pcu.SetName(string(mg.GetUID()) + "-" + name)
+	pcu.SetLabels(map[string]string{xpv1.LabelKeyProviderName: name})
+	pcu.SetOwnerReferences([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(mg, gvk))})
+	pcu.SetProviderConfigReference(xpv1.Reference{Name: name})
+	pcu.SetResourceReference(xpv1.TypedReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       mg.GetName(),
+	})
+	err := resource.NewAPIPatchingApplicator(c).Apply(ctx, pcu, resource.MustBeControllableBy(mg.GetUID()))
+	return errors.Wrap(err, errTrackUsage)
+}
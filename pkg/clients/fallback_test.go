@@ -0,0 +1,429 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "connection refused")
+
+// withProviderConfigs returns a MockGetFn which serves ProviderConfigs whose
+// server address and name prefix is their name, authenticated with a token from a secret.
+// ProviderConfigUsages are not found.
+func withProviderConfigs() test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha1.ProviderConfig:
+			o.Spec.ServerAddr = key.Name
+			o.Spec.NamePrefix = ptr.To(key.Name + "-")
+			o.Spec.Credentials = v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "argocd-token", Namespace: "crossplane-system"},
+						Key:             "token",
+					},
+				},
+			}
+		case *corev1.Secret:
+			o.Data = map[string][]byte{"token": []byte("token")}
+		case *v1alpha1.ProviderConfigUsage:
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		default:
+			return errors.Errorf("unexpected object %s", key)
+		}
+		return nil
+	}
+}
+
+func TestFallbackClientObserve(t *testing.T) {
+	type want struct {
+		servers    []string
+		usages     []string
+		err        error
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		fallbacks  string
+		conditions []xpv1.Condition
+		errs       map[string]error
+		want       want
+	}{
+		"Primary": {
+			fallbacks: "secondary",
+			want: want{
+				servers:    []string{"primary"},
+				conditions: []xpv1.Condition{ProviderConfigServed("primary", false)},
+			},
+		},
+		"FailoverWhenPrimaryUnavailable": {
+			fallbacks: "secondary, tertiary",
+			errs:      map[string]error{"primary": errUnavailable},
+			want: want{
+				servers:    []string{"primary", "secondary"},
+				usages:     []string{"uid-secondary"},
+				conditions: []xpv1.Condition{ProviderConfigServed("secondary", true)},
+			},
+		},
+		"AllUnavailable": {
+			fallbacks: "secondary",
+			errs:      map[string]error{"primary": errUnavailable, "secondary": errUnavailable},
+			want: want{
+				servers:    []string{"primary", "secondary"},
+				usages:     []string{"uid-secondary"},
+				err:        errors.Wrap(errUnavailable, errArgoCDUnavailable),
+				conditions: []xpv1.Condition{ConnectionUnavailable(errors.Wrap(errUnavailable, errArgoCDUnavailable))},
+			},
+		},
+		"NoFailoverOnOtherError": {
+			fallbacks: "secondary",
+			errs:      map[string]error{"primary": errors.New("boom")},
+			want: want{
+				servers: []string{"primary"},
+				err:     errors.New("boom"),
+			},
+		},
+		"NoFallbacks": {
+			errs: map[string]error{"primary": errUnavailable},
+			want: want{
+				servers:    []string{"primary"},
+				err:        errors.Wrap(errUnavailable, errArgoCDUnavailable),
+				conditions: []xpv1.Condition{ConnectionUnavailable(errors.Wrap(errUnavailable, errArgoCDUnavailable))},
+			},
+		},
+		"Reconnected": {
+			conditions: []xpv1.Condition{ConnectionUnavailable(errors.Wrap(errUnavailable, errArgoCDUnavailable))},
+			want: want{
+				servers:    []string{"primary"},
+				conditions: []xpv1.Condition{Connected()},
+			},
+		},
+		"StillUnavailable": {
+			conditions: []xpv1.Condition{ConnectionUnavailable(errors.Wrap(errUnavailable, errArgoCDUnavailable))},
+			errs:       map[string]error{"primary": errUnavailable},
+			want: want{
+				servers:    []string{"primary"},
+				err:        errors.Wrap(errUnavailable, errArgoCDUnavailable),
+				conditions: []xpv1.Condition{ConnectionUnavailable(errors.Wrap(errUnavailable, errArgoCDUnavailable))},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetUID(types.UID("uid"))
+			mg.SetAnnotations(map[string]string{AnnotationKeyFallbackProviderConfigs: tc.fallbacks})
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "primary"})
+			mg.SetConditions(tc.conditions...)
+
+			var usages []string
+			kube := &test.MockClient{
+				MockGet: withProviderConfigs(),
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					usages = append(usages, obj.GetName())
+					return nil
+				},
+			}
+
+			var servers []string
+			closed := 0
+			connect := func(cfg *argocd.ClientOptions, _ *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+				servers = append(servers, cfg.ServerAddr)
+				return managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, tc.errs[cfg.ServerAddr]
+					},
+				}, io.NewCloser(func() error {
+					closed++
+					return nil
+				})
+			}
+
+			primary, conn := connect(&argocd.ClientOptions{ServerAddr: "primary"}, &v1alpha1.ProviderConfigSpec{})
+			c := &FallbackClient{
+				kube:    kube,
+				connect: connect,
+				names:   append([]string{"primary"}, FallbackProviderConfigs(mg)...),
+				client:  primary,
+				closers: Closers{conn},
+			}
+			_, err := c.Observe(context.Background(), mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.servers, servers); diff != "" {
+				t.Errorf("Observe(...): -want servers, +got servers:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.usages, usages); diff != "" {
+				t.Errorf("Observe(...): -want usages, +got usages:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, mg.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Observe(...): -want conditions, +got conditions:\n%s", diff)
+			}
+			if err := c.Disconnect(context.Background()); err != nil {
+				t.Errorf("Disconnect(): %s", err)
+			}
+			if diff := cmp.Diff(len(servers), closed); diff != "" {
+				t.Errorf("Disconnect(): -want closed, +got closed:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectWithFallbackTracksUsage(t *testing.T) {
+	type want struct {
+		usage *xpv1.Reference
+		err   error
+	}
+
+	errBoom := errors.New("boom")
+	cases := map[string]struct {
+		usageErr error
+		want     want
+	}{
+		"CreatesUsage": {
+			usageErr: kerrors.NewNotFound(schema.GroupResource{}, "uid"),
+			want: want{
+				usage: &xpv1.Reference{Name: "primary"},
+			},
+		},
+		"TrackFailed": {
+			usageErr: errBoom,
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get object"), "cannot apply ProviderConfigUsage"), errTrackUsage),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetUID(types.UID("uid"))
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "primary"})
+
+			var usage *xpv1.Reference
+			kube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					if _, ok := obj.(*v1alpha1.ProviderConfigUsage); ok {
+						return tc.usageErr
+					}
+					return withProviderConfigs()(ctx, key, obj)
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					pcu, ok := obj.(*v1alpha1.ProviderConfigUsage)
+					if !ok || pcu.GetName() != "uid" {
+						return errors.Errorf("unexpected object %s", obj.GetName())
+					}
+					ref := pcu.GetProviderConfigReference()
+					usage = &ref
+					return nil
+				},
+			}
+			connect := func(_ *argocd.ClientOptions, _ *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+				return managed.ExternalClientFns{}, io.NewCloser(func() error { return nil })
+			}
+			_, err := ConnectWithFallback(context.Background(), kube, mg, connect)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.usage, usage); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want usage, +got usage:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectWithFallbackConcurrentReconciles(t *testing.T) {
+	kube := &test.MockClient{MockGet: withProviderConfigs(), MockCreate: test.NewMockCreateFn(nil)}
+
+	// the first reconcile disconnects while the second one still uses its connection
+	var connected sync.WaitGroup
+	connected.Add(2)
+	firstDone := make(chan struct{})
+	errs := make([]error, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 0 {
+				defer close(firstDone)
+			}
+			mg := &fake.Managed{}
+			mg.SetUID(types.UID("uid"))
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "primary"})
+
+			closed := make(chan struct{})
+			connect := func(_ *argocd.ClientOptions, _ *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+				return managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						select {
+						case <-closed:
+							return managed.ExternalObservation{}, errors.New("connection is closed")
+						default:
+							return managed.ExternalObservation{ResourceExists: true}, nil
+						}
+					},
+				}, io.NewCloser(func() error {
+					close(closed)
+					return nil
+				})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			c, err := ConnectWithFallback(ctx, kube, mg, connect)
+			connected.Done()
+			if err != nil {
+				errs[i] = err
+				cancel()
+				return
+			}
+			connected.Wait()
+			if i == 1 {
+				<-firstDone
+			}
+			_, errs[i] = c.Observe(ctx, mg)
+			cancel()
+			// the connection of the reconcile is closed once it is done
+			<-closed
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("reconcile %d: %s", i, err)
+		}
+	}
+}
+
+func TestConnectWithFallback(t *testing.T) {
+	type want struct {
+		servers  []string
+		prefixes []string
+		usages   []string
+		err      error
+	}
+
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "primary")
+	cases := map[string]struct {
+		fallbacks string
+		missing   []string
+		want      want
+	}{
+		"Primary": {
+			fallbacks: "secondary",
+			want: want{
+				servers:  []string{"primary"},
+				prefixes: []string{"primary-"},
+				usages:   []string{"uid"},
+			},
+		},
+		"FailoverWhenPrimaryCannotBeLoaded": {
+			fallbacks: "secondary, tertiary",
+			missing:   []string{"primary"},
+			want: want{
+				servers:  []string{"secondary"},
+				prefixes: []string{"secondary-"},
+				usages:   []string{"uid", "uid-secondary"},
+			},
+		},
+		"NoneCanBeLoaded": {
+			fallbacks: "secondary",
+			missing:   []string{"primary", "secondary"},
+			want: want{
+				usages: []string{"uid"},
+				err:    errors.Wrap(errNotFound, "cannot get referenced Provider"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetUID(types.UID("uid"))
+			mg.SetAnnotations(map[string]string{AnnotationKeyFallbackProviderConfigs: tc.fallbacks})
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "primary"})
+
+			var usages []string
+			kube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					if _, ok := obj.(*v1alpha1.ProviderConfig); ok && slices.Contains(tc.missing, key.Name) {
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					return withProviderConfigs()(ctx, key, obj)
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					usages = append(usages, obj.GetName())
+					return nil
+				},
+			}
+			var servers, prefixes []string
+			connect := func(cfg *argocd.ClientOptions, spec *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+				// the names are transformed by the ProviderConfig in use
+				servers = append(servers, cfg.ServerAddr)
+				prefixes = append(prefixes, NameTransformFor(spec).Prefix)
+				return managed.ExternalClientFns{}, io.NewCloser(func() error { return nil })
+			}
+			_, err := ConnectWithFallback(context.Background(), kube, mg, connect)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.servers, servers); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want servers, +got servers:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.prefixes, prefixes); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want prefixes, +got prefixes:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.usages, usages); diff != "" {
+				t.Errorf("ConnectWithFallback(...): -want usages, +got usages:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"sync"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	// DefaultMaxGRPCMessageSizeMiB is the maximum gRPC message size if a ProviderConfig sets none.
+	// It exceeds the 200 MiB ArgoCD allows by default.
+	DefaultMaxGRPCMessageSizeMiB = 256
+
+	errResourceExhausted = "message exceeds the maximum gRPC message size, consider raising maxGRPCMessageSizeMiB of the ProviderConfig"
+)
+
+// maxGRPCMessageSizeMu serializes connects, as the argocd client reads the maximum
+// message size from a package variable when dialing.
+var maxGRPCMessageSizeMu sync.Mutex
+
+// maxGRPCMessageSize returns the maximum gRPC message size in bytes configured by spec
+func maxGRPCMessageSize(spec *v1alpha1.ProviderConfigSpec) int {
+	return int(ptr.Deref(spec.MaxGRPCMessageSizeMiB, DefaultMaxGRPCMessageSizeMiB)) * 1024 * 1024
+}
+
+// connectWithMaxGRPCMessageSize connects with the maximum size of sent and received gRPC
+// messages configured by spec.
+func connectWithMaxGRPCMessageSize(spec *v1alpha1.ProviderConfigSpec, cfg *argocd.ClientOptions, connect ConnectFn) (managed.ExternalClient, io.Closer) {
+	maxGRPCMessageSizeMu.Lock()
+	defer maxGRPCMessageSizeMu.Unlock()
+
+	previous := argocd.MaxGRPCMessageSize
+	argocd.MaxGRPCMessageSize = maxGRPCMessageSize(spec)
+	defer func() { argocd.MaxGRPCMessageSize = previous }()
+
+	return connect(cfg, spec)
+}
+
+// IsErrorResourceExhausted returns whether err is a ResourceExhausted error, e.g. a message exceeding the maximum size
+func IsErrorResourceExhausted(err error) bool {
+	s, ok := status.FromError(errors.Cause(err))
+	return err != nil && ok && s.Code() == codes.ResourceExhausted
+}
+
+// withResourceExhaustedHint suggests raising the maximum message size if err is a ResourceExhausted error
+func withResourceExhaustedHint(err error) error {
+	if !IsErrorResourceExhausted(err) {
+		return err
+	}
+	return errors.Wrap(err, errResourceExhausted)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+func TestConnectWithMaxGRPCMessageSize(t *testing.T) {
+	cases := map[string]struct {
+		spec v1alpha1.ProviderConfigSpec
+		want int
+	}{
+		"Default": {
+			want: 256 * 1024 * 1024,
+		},
+		"Configured": {
+			spec: v1alpha1.ProviderConfigSpec{MaxGRPCMessageSizeMiB: ptr.To[int32](512)},
+			want: 512 * 1024 * 1024,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			previous := argocd.MaxGRPCMessageSize
+			var got int
+			connectWithMaxGRPCMessageSize(&tc.spec, &argocd.ClientOptions{}, func(_ *argocd.ClientOptions, _ *v1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+				// the argocd client reads the size when it dials
+				got = argocd.MaxGRPCMessageSize
+				return nil, nil
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("dial size: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(previous, argocd.MaxGRPCMessageSize); diff != "" {
+				t.Errorf("restored size: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithResourceExhaustedHint(t *testing.T) {
+	exhausted := status.Error(codes.ResourceExhausted, "grpc: received message larger than max")
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		err  error
+		want error
+	}{
+		"NoError": {},
+		"OtherError": {
+			err:  errBoom,
+			want: errBoom,
+		},
+		"ResourceExhausted": {
+			err:  exhausted,
+			want: errors.Wrap(exhausted, errResourceExhausted),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, withResourceExhaustedHint(tc.err), test.EquateErrors()); diff != "" {
+				t.Errorf("withResourceExhaustedHint(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+)
+
+// Hash returns the SHA-256 hash of a secret value, so that changes can be detected without storing it.
+// It returns an empty string for an empty value.
+func Hash(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashTLSData returns the Hash of normalized PEM-encoded TLS data. Data which is additionally
+// base64-encoded, differs in line endings or surrounding whitespace hashes the same.
+func HashTLSData(b []byte) string {
+	return Hash(normalizeTLSData(b))
+}
+
+func normalizeTLSData(b []byte) []byte {
+	d := bytes.TrimSpace(bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n")))
+	if !bytes.HasPrefix(d, []byte("-----BEGIN")) {
+		if dec, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(d), nil))); err == nil {
+			if dec = bytes.TrimSpace(bytes.ReplaceAll(dec, []byte("\r\n"), []byte("\n"))); bytes.HasPrefix(dec, []byte("-----BEGIN")) {
+				d = dec
+			}
+		}
+	}
+	var out []byte
+	for rest := d; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		out = append(out, pem.EncodeToMemory(block)...)
+	}
+	if len(out) == 0 {
+		return d
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKeyIgnoreFields holds a comma separated list of forProvider fields, named as in the
+// manifest, which are excluded from drift detection and updates.
+const AnnotationKeyIgnoreFields = "argocd.crossplane.io/ignore-fields"
+
+// GetIgnoredFields returns the fields listed in the ignore fields annotation of o. params is the
+// forProvider struct of o and every listed field has to be one of its fields.
+func GetIgnoredFields(o metav1.Object, params any) ([]string, error) {
+	v, ok := o.GetAnnotations()[AnnotationKeyIgnoreFields]
+	if !ok || strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+	t := reflect.Indirect(reflect.ValueOf(params)).Type()
+	var fields []string
+	for _, f := range strings.Split(v, ",") {
+		f = strings.TrimSpace(f)
+		if _, ok := fieldByJSONName(t, f); !ok {
+			return nil, errors.Errorf("unknown field %q in annotation %s", f, AnnotationKeyIgnoreFields)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// ApplyIgnoredFields copies the given fields from observed into desired.
+// Both have to be pointers to the same struct type.
+func ApplyIgnoredFields(desired, observed any, fields []string) {
+	d := reflect.ValueOf(desired).Elem()
+	o := reflect.ValueOf(observed).Elem()
+	for _, f := range fields {
+		i, ok := fieldByJSONName(d.Type(), f)
+		if !ok {
+			continue
+		}
+		d.Field(i).Set(o.Field(i))
+	}
+}
+
+func fieldByJSONName(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != "" && tag != "-" && tag == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
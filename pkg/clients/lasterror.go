@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// LastErrorRecorder is a resource which records the last error returned by ArgoCD in its status
+type LastErrorRecorder interface {
+	// GetLastError returns the last error and the time it occurred
+	GetLastError() (*string, *metav1.Time)
+	// SetLastError sets the last error and the time it occurred
+	SetLastError(msg *string, t *metav1.Time)
+}
+
+// RecordLastError records err as the last error of r at the current time of clk, or clears the last
+// error if err is nil. It returns err.
+func RecordLastError(r LastErrorRecorder, clk clock.PassiveClock, err error) error {
+	if err == nil {
+		r.SetLastError(nil, nil)
+		return nil
+	}
+	t := metav1.NewTime(clk.Now())
+	r.SetLastError(ptr.To(err.Error()), &t)
+	return err
+}
+
+// WithLastErrorRecording wraps an ExternalClient managing resources which implement LastErrorRecorder,
+// so that users see why ArgoCD rejected a change without reading the logs of the provider. The error
+// of a failed create, update or delete is recorded in the status of the resource. It is kept while the
+// resource is not up to date, and cleared once a change succeeds or the resource is up to date.
+func WithLastErrorRecording(c managed.ExternalClient) managed.ExternalClient {
+	return &lastErrorRecordingClient{client: c, clock: clock.RealClock{}}
+}
+
+type lastErrorRecordingClient struct {
+	client managed.ExternalClient
+	clock  clock.PassiveClock
+}
+
+func (c *lastErrorRecordingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	r, ok := mg.(LastErrorRecorder)
+	if !ok {
+		return c.client.Observe(ctx, mg)
+	}
+	// the observation of the resource is regenerated by Observe, which drops the last error
+	msg, t := r.GetLastError()
+	o, err := c.client.Observe(ctx, mg)
+	if err != nil || !o.ResourceExists || !o.ResourceUpToDate {
+		r.SetLastError(msg, t)
+		return o, err
+	}
+	r.SetLastError(nil, nil)
+	return o, nil
+}
+
+func (c *lastErrorRecordingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	o, err := c.client.Create(ctx, mg)
+	return o, c.record(mg, err)
+}
+
+func (c *lastErrorRecordingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	o, err := c.client.Update(ctx, mg)
+	return o, c.record(mg, err)
+}
+
+func (c *lastErrorRecordingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return c.record(mg, c.client.Delete(ctx, mg))
+}
+
+// record records err as the last error of mg. A conflict is not recorded, it only requeues mg, e.g.
+// while the calls to ArgoCD are rate limited.
+func (c *lastErrorRecordingClient) record(mg resource.Managed, err error) error {
+	r, ok := mg.(LastErrorRecorder)
+	if !ok || kerrors.IsConflict(err) {
+		return err
+	}
+	return RecordLastError(r, c.clock, err)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var (
+	testFailedAt   = time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+	testRecordedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+)
+
+type lastErrorManaged struct {
+	fake.Managed
+	lastError     *string
+	lastErrorTime *metav1.Time
+}
+
+func (m *lastErrorManaged) GetLastError() (*string, *metav1.Time) {
+	return m.lastError, m.lastErrorTime
+}
+
+func (m *lastErrorManaged) SetLastError(msg *string, t *metav1.Time) {
+	m.lastError, m.lastErrorTime = msg, t
+}
+
+func TestLastErrorRecording(t *testing.T) {
+	errBoom := errors.New("boom")
+	errConflict := kerrors.NewConflict(schema.GroupResource{}, "default", errors.New(errRateLimited))
+
+	type want struct {
+		err           error
+		lastError     *string
+		lastErrorTime *metav1.Time
+	}
+
+	cases := map[string]struct {
+		recorded *string
+		call     string
+		observed managed.ExternalObservation
+		err      error
+		want     want
+	}{
+		"UpdateFailed": {
+			call: "update",
+			err:  errBoom,
+			want: want{err: errBoom, lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testRecordedAt}},
+		},
+		"UpdateSucceeded": {
+			recorded: ptr.To("boom"),
+			call:     "update",
+		},
+		"CreateFailed": {
+			call: "create",
+			err:  errBoom,
+			want: want{err: errBoom, lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testRecordedAt}},
+		},
+		"DeleteFailed": {
+			call: "delete",
+			err:  errBoom,
+			want: want{err: errBoom, lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testRecordedAt}},
+		},
+		"RateLimited": {
+			recorded: ptr.To("boom"),
+			call:     "update",
+			err:      errConflict,
+			want:     want{err: errConflict, lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testFailedAt}},
+		},
+		"ObservedNotUpToDate": {
+			recorded: ptr.To("boom"),
+			call:     "observe",
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want:     want{lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testFailedAt}},
+		},
+		"ObservedMissing": {
+			recorded: ptr.To("boom"),
+			call:     "observe",
+			want:     want{lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testFailedAt}},
+		},
+		"ObserveFailed": {
+			recorded: ptr.To("boom"),
+			call:     "observe",
+			err:      errBoom,
+			want:     want{err: errBoom, lastError: ptr.To("boom"), lastErrorTime: &metav1.Time{Time: testFailedAt}},
+		},
+		"ObservedUpToDate": {
+			recorded: ptr.To("boom"),
+			call:     "observe",
+			observed: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &lastErrorRecordingClient{
+				client: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+						// the observation is regenerated by the controllers
+						mg.(*lastErrorManaged).SetLastError(nil, nil)
+						return tc.observed, tc.err
+					},
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, tc.err
+					},
+					UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+						return managed.ExternalUpdate{}, tc.err
+					},
+					DeleteFn: func(_ context.Context, _ resource.Managed) error {
+						return tc.err
+					},
+				},
+				clock: clocktesting.NewFakePassiveClock(testRecordedAt),
+			}
+			mg := &lastErrorManaged{}
+			if tc.recorded != nil {
+				mg.SetLastError(tc.recorded, &metav1.Time{Time: testFailedAt})
+			}
+
+			var err error
+			switch tc.call {
+			case "observe":
+				_, err = c.Observe(context.Background(), mg)
+			case "create":
+				_, err = c.Create(context.Background(), mg)
+			case "update":
+				_, err = c.Update(context.Background(), mg)
+			case "delete":
+				err = c.Delete(context.Background(), mg)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("%s(...): -want error, +got error:\n%s", tc.call, diff)
+			}
+			if diff := cmp.Diff(tc.want.lastError, mg.lastError); diff != "" {
+				t.Errorf("%s(...): -want last error, +got last error:\n%s", tc.call, diff)
+			}
+			if diff := cmp.Diff(tc.want.lastErrorTime, mg.lastErrorTime); diff != "" {
+				t.Errorf("%s(...): -want last error time, +got last error time:\n%s", tc.call, diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	repocreds "github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
 	repository "github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
@@ -136,3 +137,46 @@ func (mr *MockRepositoryServiceClientMockRecorder) UpdateRepository(ctx, in inte
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepository", reflect.TypeOf((*MockRepositoryServiceClient)(nil).UpdateRepository), varargs...)
 }
+
+// MockRepoCredsServiceClient is a mock of RepoCredsServiceClient interface.
+type MockRepoCredsServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoCredsServiceClientMockRecorder
+}
+
+// MockRepoCredsServiceClientMockRecorder is the mock recorder for MockRepoCredsServiceClient.
+type MockRepoCredsServiceClientMockRecorder struct {
+	mock *MockRepoCredsServiceClient
+}
+
+// NewMockRepoCredsServiceClient creates a new mock instance.
+func NewMockRepoCredsServiceClient(ctrl *gomock.Controller) *MockRepoCredsServiceClient {
+	mock := &MockRepoCredsServiceClient{ctrl: ctrl}
+	mock.recorder = &MockRepoCredsServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoCredsServiceClient) EXPECT() *MockRepoCredsServiceClientMockRecorder {
+	return m.recorder
+}
+
+// ListRepositoryCredentials mocks base method.
+func (m *MockRepoCredsServiceClient) ListRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsQuery, opts ...grpc.CallOption) (*v1alpha1.RepoCredsList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRepositoryCredentials", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepoCredsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoryCredentials indicates an expected call of ListRepositoryCredentials.
+func (mr *MockRepoCredsServiceClientMockRecorder) ListRepositoryCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoryCredentials", reflect.TypeOf((*MockRepoCredsServiceClient)(nil).ListRepositoryCredentials), varargs...)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	errFmtInvalidName = "invalid ArgoCD object name %q: %s"
+)
+
+// A NameTransform derives the name of an ArgoCD object from the external name
+// of its managed resource using the prefix and suffix of the ProviderConfig.
+type NameTransform struct {
+	Prefix string
+	Suffix string
+}
+
+// NameTransformFor returns the NameTransform configured by spec
+func NameTransformFor(spec *v1alpha1.ProviderConfigSpec) NameTransform {
+	return NameTransform{
+		Prefix: ptr.Deref(spec.NamePrefix, ""),
+		Suffix: ptr.Deref(spec.NameSuffix, ""),
+	}
+}
+
+// Name returns the ArgoCD object name of externalName. The name has to be a valid
+// DNS subdomain, as ArgoCD stores its objects as Kubernetes resources.
+func (t NameTransform) Name(externalName string) (string, error) {
+	name := t.Prefix + externalName + t.Suffix
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", errors.Errorf(errFmtInvalidName, name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// ExternalName returns the external name of the ArgoCD object name. It returns
+// false if name lacks the prefix or the suffix of the transform.
+func (t NameTransform) ExternalName(name string) (string, bool) {
+	if len(name) < len(t.Prefix)+len(t.Suffix) || !strings.HasPrefix(name, t.Prefix) || !strings.HasSuffix(name, t.Suffix) {
+		return "", false
+	}
+	return name[len(t.Prefix) : len(name)-len(t.Suffix)], true
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNameTransform(t *testing.T) {
+	type want struct {
+		name         string
+		invalid      bool
+		externalName string
+		ok           bool
+	}
+
+	cases := map[string]struct {
+		transform    NameTransform
+		externalName string
+		want         want
+	}{
+		"NoTransform": {
+			externalName: "guestbook",
+			want:         want{name: "guestbook", externalName: "guestbook", ok: true},
+		},
+		"PrefixAndSuffix": {
+			transform:    NameTransform{Prefix: "team-a-", Suffix: "-prod"},
+			externalName: "guestbook",
+			want:         want{name: "team-a-guestbook-prod", externalName: "guestbook", ok: true},
+		},
+		"InvalidPrefix": {
+			transform:    NameTransform{Prefix: "Team_A-"},
+			externalName: "guestbook",
+			want:         want{invalid: true},
+		},
+		"TooLong": {
+			transform:    NameTransform{Prefix: strings.Repeat("a", 250)},
+			externalName: "guestbook",
+			want:         want{invalid: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, err := tc.transform.Name(tc.externalName)
+			got := want{name: n, invalid: err != nil}
+			if !got.invalid {
+				got.externalName, got.ok = tc.transform.ExternalName(n)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("NameTransform: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNameTransformExternalName(t *testing.T) {
+	transform := NameTransform{Prefix: "team-a-", Suffix: "-prod"}
+	for _, name := range []string{"guestbook", "team-a-guestbook", "guestbook-prod", "team-a-prod"} {
+		if got, ok := transform.ExternalName(name); ok {
+			t.Errorf("ExternalName(%q): want no external name, got %q", name, got)
+		}
+	}
+}
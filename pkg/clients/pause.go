@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// AnnotationKeyPaused freezes the ArgoCD object of a resource if set to "true". The resource
+	// is still observed, so that its status stays current, but it is never created, updated or
+	// deleted in ArgoCD. Unlike crossplane.io/paused, the resource is not ignored entirely.
+	AnnotationKeyPaused = "argocd.crossplane.io/paused"
+
+	// TypePaused indicates whether changes to the ArgoCD object of a resource are paused
+	TypePaused xpv1.ConditionType = "Paused"
+
+	// ReasonPaused is set when changes to the ArgoCD object of a resource are paused
+	ReasonPaused xpv1.ConditionReason = "PausedByAnnotation"
+	// ReasonDeletionPaused is set when a resource being deleted waits for its changes to be resumed
+	ReasonDeletionPaused xpv1.ConditionReason = "DeletionPausedByAnnotation"
+	// ReasonResumed is set when changes to the ArgoCD object of a resource were resumed
+	ReasonResumed xpv1.ConditionReason = "Resumed"
+)
+
+// IsPaused returns whether changes to the ArgoCD object of o are paused
+func IsPaused(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyPaused] == "true"
+}
+
+// Paused returns a condition that indicates that changes to the ArgoCD object of a resource are paused
+func Paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+		Message:            "changes are paused by the " + AnnotationKeyPaused + " annotation",
+	}
+}
+
+// DeletionPaused returns a condition that indicates that the deletion of the ArgoCD object of a
+// resource waits until its changes are resumed
+func DeletionPaused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionPaused,
+		Message:            "deletion waits until the " + AnnotationKeyPaused + " annotation is removed",
+	}
+}
+
+// Resumed returns a condition that indicates that changes to the ArgoCD object of a resource were resumed
+func Resumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// WithPauseHandling wraps an ExternalClient so that resources with the AnnotationKeyPaused
+// annotation are observed, but never created, updated or deleted. A paused resource is reported
+// as existing and up to date, so that the managed reconciler doesn't attempt to change it. A paused
+// resource being deleted keeps its finalizer until it is resumed, which the DeletionPaused condition
+// reports.
+func WithPauseHandling(c managed.ExternalClient) managed.ExternalClient {
+	return &pauseHandlingClient{client: c}
+}
+
+type pauseHandlingClient struct {
+	client managed.ExternalClient
+}
+
+func (c *pauseHandlingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	if !IsPaused(mg) {
+		if mg.GetCondition(TypePaused).Status == corev1.ConditionTrue {
+			mg.SetConditions(Resumed())
+		}
+		return o, err
+	}
+	if err != nil {
+		mg.SetConditions(Paused())
+		return o, err
+	}
+	switch {
+	case !meta.WasDeleted(mg):
+		mg.SetConditions(Paused())
+		o.ResourceExists = true
+	case o.ResourceExists:
+		mg.SetConditions(DeletionPaused())
+	default:
+		// a resource being deleted which doesn't exist anymore can be released, as that
+		// doesn't touch ArgoCD
+		mg.SetConditions(Paused())
+	}
+	o.ResourceUpToDate = true
+	return o, nil
+}
+
+func (c *pauseHandlingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if IsPaused(mg) {
+		return managed.ExternalCreation{}, nil
+	}
+	return c.client.Create(ctx, mg)
+}
+
+func (c *pauseHandlingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if IsPaused(mg) {
+		return managed.ExternalUpdate{}, nil
+	}
+	return c.client.Update(ctx, mg)
+}
+
+func (c *pauseHandlingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	if IsPaused(mg) {
+		return nil
+	}
+	return c.client.Delete(ctx, mg)
+}
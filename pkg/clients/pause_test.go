@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestPauseHandling(t *testing.T) {
+	paused := func(mg *fake.Managed) { meta.AddAnnotations(mg, map[string]string{AnnotationKeyPaused: "true"}) }
+	deleted := func(mg *fake.Managed) { mg.SetDeletionTimestamp(&metav1.Time{}) }
+	withCondition := func(c xpv1.Condition) func(*fake.Managed) {
+		return func(mg *fake.Managed) { mg.SetConditions(c) }
+	}
+
+	type want struct {
+		observation managed.ExternalObservation
+		mutations   []string
+		conditions  []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		mg       []func(*fake.Managed)
+		observed managed.ExternalObservation
+		want     want
+	}{
+		"NotPaused": {
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				mutations:   []string{"create", "update", "delete"},
+			},
+		},
+		"PausedOutdated": {
+			mg:       []func(*fake.Managed){paused},
+			observed: managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				conditions:  []xpv1.Condition{Paused()},
+			},
+		},
+		"PausedMissing": {
+			mg: []func(*fake.Managed){paused},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				conditions:  []xpv1.Condition{Paused()},
+			},
+		},
+		"PausedDeletedMissing": {
+			mg: []func(*fake.Managed){paused, deleted},
+			want: want{
+				observation: managed.ExternalObservation{ResourceUpToDate: true},
+				conditions:  []xpv1.Condition{Paused()},
+			},
+		},
+		"PausedDeleted": {
+			mg:       []func(*fake.Managed){paused, deleted},
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				conditions:  []xpv1.Condition{DeletionPaused()},
+			},
+		},
+		"Resumed": {
+			mg:       []func(*fake.Managed){withCondition(Paused())},
+			observed: managed.ExternalObservation{ResourceExists: true},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true},
+				mutations:   []string{"create", "update", "delete"},
+				conditions:  []xpv1.Condition{Resumed()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var mutations []string
+			c := WithPauseHandling(&managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return tc.observed, nil
+				},
+				CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+					mutations = append(mutations, "create")
+					return managed.ExternalCreation{}, nil
+				},
+				UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+					mutations = append(mutations, "update")
+					return managed.ExternalUpdate{}, nil
+				},
+				DeleteFn: func(_ context.Context, _ resource.Managed) error {
+					mutations = append(mutations, "delete")
+					return nil
+				},
+			})
+			mg := &fake.Managed{}
+			for _, f := range tc.mg {
+				f(mg)
+			}
+
+			o, err := c.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if _, err := c.Create(context.Background(), mg); err != nil {
+				t.Fatalf("Create(...): %s", err)
+			}
+			if _, err := c.Update(context.Background(), mg); err != nil {
+				t.Fatalf("Update(...): %s", err)
+			}
+			if err := c.Delete(context.Background(), mg); err != nil {
+				t.Fatalf("Delete(...): %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.observation, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mutations, mutations); diff != "" {
+				t.Errorf("mutating calls: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("conditions: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// TypePermissions indicates whether the ArgoCD token of the provider is permitted to manage a resource
+	TypePermissions xpv1.ConditionType = "ArgoCDPermissions"
+
+	// ReasonInsufficientPermissions is set when ArgoCD denied an operation on a resource
+	ReasonInsufficientPermissions xpv1.ConditionReason = "InsufficientPermissions"
+	// ReasonPermitted is set when an operation previously denied by ArgoCD succeeds
+	ReasonPermitted xpv1.ConditionReason = "Permitted"
+
+	actionGet    = "get"
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+
+	errFmtInsufficientPermissions = "insufficient ArgoCD RBAC for %s on %s %s"
+	errorPermissionDenied         = "code = PermissionDenied"
+)
+
+// IsErrorPermissionDenied returns whether err is a PermissionDenied error returned by ArgoCD
+func IsErrorPermissionDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(errors.Cause(err)); ok && s.Code() == codes.PermissionDenied {
+		return true
+	}
+	return strings.Contains(err.Error(), errorPermissionDenied)
+}
+
+// InsufficientPermissions returns a condition that indicates that ArgoCD denied an operation on a resource
+func InsufficientPermissions(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePermissions,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInsufficientPermissions,
+		Message:            err.Error(),
+	}
+}
+
+// Permitted returns a condition that indicates that ArgoCD permits the operations on a resource
+func Permitted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePermissions,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermitted,
+	}
+}
+
+// WithPermissionHandling wraps an ExternalClient managing resources of the given kind. A PermissionDenied
+// error returned by ArgoCD is reported with the InsufficientPermissions condition. Observe is always
+// called, so that the condition clears once the RBAC of the token is fixed. A denied create, update or
+// delete is never retried for the same generation of the resource, it is only retried once the resource
+// changes, e.g. after the RBAC of the token is fixed and the resource is touched.
+func WithPermissionHandling(c managed.ExternalClient, kind string) managed.ExternalClient {
+	return &permissionHandlingClient{client: c, kind: kind}
+}
+
+type permissionHandlingClient struct {
+	client managed.ExternalClient
+	kind   string
+}
+
+func (c *permissionHandlingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	return o, c.handle(mg, actionGet, err)
+}
+
+func (c *permissionHandlingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if err := c.denied(mg, actionCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	o, err := c.client.Create(ctx, mg)
+	return o, c.handle(mg, actionCreate, err)
+}
+
+func (c *permissionHandlingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if err := c.denied(mg, actionUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	o, err := c.client.Update(ctx, mg)
+	return o, c.handle(mg, actionUpdate, err)
+}
+
+func (c *permissionHandlingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := c.denied(mg, actionDelete); err != nil {
+		return err
+	}
+	return c.handle(mg, actionDelete, c.client.Delete(ctx, mg))
+}
+
+// handle reports a PermissionDenied error of action on mg with the InsufficientPermissions condition,
+// and marks mg as permitted again once the denied action, or any change, succeeds. A successful get
+// does not clear a denied change, which is only retried for a new generation of mg.
+func (c *permissionHandlingClient) handle(mg resource.Managed, action string, err error) error {
+	switch {
+	case IsErrorPermissionDenied(err):
+		err = errors.Wrapf(err, errFmtInsufficientPermissions, action, c.kind, mg.GetName())
+	case err == nil && mg.GetCondition(TypePermissions).Reason == ReasonInsufficientPermissions && (action != actionGet || c.isDenied(mg, actionGet)):
+		mg.SetConditions(Permitted().WithObservedGeneration(mg.GetGeneration()))
+		return nil
+	default:
+		return err
+	}
+	mg.SetConditions(InsufficientPermissions(err).WithObservedGeneration(mg.GetGeneration()))
+	return err
+}
+
+// isDenied reports whether the InsufficientPermissions condition of mg records a denial of action
+func (c *permissionHandlingClient) isDenied(mg resource.Managed, action string) bool {
+	cond := mg.GetCondition(TypePermissions)
+	return cond.Reason == ReasonInsufficientPermissions &&
+		strings.HasPrefix(cond.Message, fmt.Sprintf(errFmtInsufficientPermissions, action, c.kind, mg.GetName()))
+}
+
+// denied returns the error of action if ArgoCD denied it for the current generation of mg
+func (c *permissionHandlingClient) denied(mg resource.Managed, action string) error {
+	cond := mg.GetCondition(TypePermissions)
+	if !c.isDenied(mg, action) || cond.ObservedGeneration != mg.GetGeneration() {
+		return nil
+	}
+	return errors.New(cond.Message)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var (
+	testDeniedAt  = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	errDenied     = status.Error(codes.PermissionDenied, "permission denied")
+	errDeniedRBAC = errors.Wrapf(errors.Wrap(errDenied, "cannot create"), errFmtInsufficientPermissions, "create", "Project", "test")
+	errDeniedGet  = errors.Wrapf(errors.Wrap(errDenied, "cannot get"), errFmtInsufficientPermissions, "get", "Project", "test")
+)
+
+// deniedSince returns the InsufficientPermissions condition of err for generation 1 which transitioned d ago
+func deniedSince(err error, d time.Duration) xpv1.Condition {
+	c := InsufficientPermissions(err).WithObservedGeneration(1)
+	c.LastTransitionTime = metav1.NewTime(testDeniedAt.Add(-d))
+	return c
+}
+
+func withGeneration(gen int64, c ...xpv1.Condition) *fake.Managed {
+	mg := &fake.Managed{}
+	mg.SetName("test")
+	mg.SetGeneration(gen)
+	mg.SetConditions(c...)
+	return mg
+}
+
+func TestPermissionHandlingCreate(t *testing.T) {
+	type want struct {
+		calls      int
+		err        error
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		mg   *fake.Managed
+		err  error
+		want want
+	}{
+		"PermissionDenied": {
+			mg:  withGeneration(1),
+			err: errors.Wrap(errDenied, "cannot create"),
+			want: want{
+				calls:      1,
+				err:        errDeniedRBAC,
+				conditions: []xpv1.Condition{InsufficientPermissions(errDeniedRBAC).WithObservedGeneration(1)},
+			},
+		},
+		"PermissionDeniedNotRetried": {
+			mg:  withGeneration(1, deniedSince(errDeniedRBAC, time.Minute)),
+			err: nil,
+			want: want{
+				calls:      0,
+				err:        errors.New(errDeniedRBAC.Error()),
+				conditions: []xpv1.Condition{InsufficientPermissions(errDeniedRBAC).WithObservedGeneration(1)},
+			},
+		},
+		"PermissionDeniedNeverRetried": {
+			mg:  withGeneration(1, deniedSince(errDeniedRBAC, 24*time.Hour)),
+			err: nil,
+			want: want{
+				calls:      0,
+				err:        errors.New(errDeniedRBAC.Error()),
+				conditions: []xpv1.Condition{InsufficientPermissions(errDeniedRBAC).WithObservedGeneration(1)},
+			},
+		},
+		"RetriedOnNewGeneration": {
+			mg:  withGeneration(2, deniedSince(errDeniedRBAC, time.Minute)),
+			err: nil,
+			want: want{
+				calls:      1,
+				err:        nil,
+				conditions: []xpv1.Condition{Permitted().WithObservedGeneration(2)},
+			},
+		},
+		"OtherActionNotBlocked": {
+			mg:  withGeneration(1, deniedSince(errDeniedGet, time.Minute)),
+			err: nil,
+			want: want{
+				calls:      1,
+				err:        nil,
+				conditions: []xpv1.Condition{Permitted().WithObservedGeneration(1)},
+			},
+		},
+		"OtherError": {
+			mg:  withGeneration(1),
+			err: errors.New("boom"),
+			want: want{
+				calls: 1,
+				err:   errors.New("boom"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			c := &permissionHandlingClient{
+				client: managed.ExternalClientFns{
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						calls++
+						return managed.ExternalCreation{}, tc.err
+					},
+				},
+				kind: "Project",
+			}
+			_, err := c.Create(context.Background(), tc.mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Create(...): -want calls, +got calls:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.mg.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Create(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPermissionHandlingObserve(t *testing.T) {
+	type want struct {
+		err        error
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		mg   *fake.Managed
+		err  error
+		want want
+	}{
+		"PermissionDenied": {
+			mg:  withGeneration(1),
+			err: errors.Wrap(errDenied, "cannot get"),
+			want: want{
+				err:        errDeniedGet,
+				conditions: []xpv1.Condition{InsufficientPermissions(errDeniedGet).WithObservedGeneration(1)},
+			},
+		},
+		"DeniedGetCleared": {
+			mg: withGeneration(1, deniedSince(errDeniedGet, time.Minute)),
+			want: want{
+				conditions: []xpv1.Condition{Permitted().WithObservedGeneration(1)},
+			},
+		},
+		"DeniedChangeKept": {
+			mg: withGeneration(1, deniedSince(errDeniedRBAC, time.Minute)),
+			want: want{
+				conditions: []xpv1.Condition{InsufficientPermissions(errDeniedRBAC).WithObservedGeneration(1)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			c := &permissionHandlingClient{
+				client: managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						calls++
+						return managed.ExternalObservation{}, tc.err
+					},
+				},
+				kind: "Project",
+			}
+			_, err := c.Observe(context.Background(), tc.mg)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(1, calls); diff != "" {
+				t.Errorf("Observe(...): -want calls, +got calls:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.mg.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Observe(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsErrorPermissionDenied(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Nil":         {err: nil, want: false},
+		"Status":      {err: errors.Wrap(errDenied, "cannot get"), want: true},
+		"Message":     {err: errors.New("rpc error: code = PermissionDenied desc = permission denied"), want: true},
+		"OtherStatus": {err: status.Error(codes.NotFound, "not found"), want: false},
+		"PlainError":  {err: errors.New("boom"), want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsErrorPermissionDenied(tc.err)); diff != "" {
+				t.Errorf("IsErrorPermissionDenied(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package projects
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+const (
+	// AnnotationKeyListCache enables observing a project through the shared ListCache if set to "true".
+	// Projects observed within ListCacheTTL are read from a single list of all projects instead of
+	// being fetched one by one, which reduces the requests to ArgoCD for large numbers of projects.
+	AnnotationKeyListCache = "argocd.crossplane.io/list-cache"
+
+	// ListCacheTTL is the duration for which a list of projects is served from the ListCache
+	ListCacheTTL = 10 * time.Second
+)
+
+// IsListCacheEnabled returns whether o is observed through the ListCache
+func IsListCacheEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyListCache] == "true"
+}
+
+// ListCache serves projects from a list of all projects of an ArgoCD instance for a short time.
+// The lists are keyed by the ArgoCD instance and credentials they were fetched with, so that
+// all managed resources can share one ListCache.
+type ListCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	clock clock.PassiveClock
+	lists map[string]*projectList
+}
+
+type projectList struct {
+	listedAt time.Time
+	projects map[string]*v1alpha1.AppProject
+}
+
+// NewListCache returns a ListCache serving each list for ttl.
+func NewListCache(ttl time.Duration, c clock.PassiveClock) *ListCache {
+	return &ListCache{ttl: ttl, clock: c, lists: map[string]*projectList{}}
+}
+
+// ListCacheKey returns the key of the list of projects fetched with cfg. It identifies the
+// ArgoCD instance and the hashed credentials, since the projects listed depend on both.
+func ListCacheKey(cfg *apiclient.ClientOptions) string {
+	return cfg.ServerAddr + "/" + clients.Hash([]byte(cfg.AuthToken))
+}
+
+// Get returns the project name from the list cached under key and lists all projects with client
+// if there is no list or it expired. Projects missing from the list, e.g. because they were
+// invalidated or created after it was listed, are fetched with client.
+func (c *ListCache) Get(ctx context.Context, client ProjectServiceClient, key, name string) (*v1alpha1.AppProject, error) {
+	p, err := c.lookup(ctx, client, key, name)
+	if err != nil || p != nil {
+		return p, err
+	}
+	return client.Get(ctx, &project.ProjectQuery{Name: name})
+}
+
+// lookup returns a copy of the project name from the list cached under key. Concurrent lookups
+// wait for the list being fetched, so that a burst of observes lists the projects only once.
+func (c *ListCache) lookup(ctx context.Context, client ProjectServiceClient, key, name string) (*v1alpha1.AppProject, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.lists[key]
+	if !ok || c.clock.Since(l.listedAt) >= c.ttl {
+		resp, err := client.List(ctx, &project.ProjectQuery{})
+		if err != nil {
+			return nil, err
+		}
+		l = &projectList{listedAt: c.clock.Now(), projects: make(map[string]*v1alpha1.AppProject, len(resp.Items))}
+		for i := range resp.Items {
+			l.projects[resp.Items[i].Name] = &resp.Items[i]
+		}
+		c.lists[key] = l
+	}
+	if p, ok := l.projects[name]; ok {
+		return p.DeepCopy(), nil
+	}
+	return nil, nil
+}
+
+// Invalidate removes the project name from the list cached under key, so that it is fetched
+// again once it was created, updated or deleted.
+func (c *ListCache) Invalidate(key, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if l, ok := c.lists[key]; ok {
+		delete(l.projects, name)
+	}
+}
@@ -0,0 +1,51 @@
+package projects
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// callClient waits for the rate limit of the ProviderConfig in use before every call and
+// records the deprecations ArgoCD reports for it in the clients.Deprecations of the context.
+type callClient struct {
+	client ProjectServiceClient
+}
+
+// withCalls returns a client which rate limits the calls of c and records their deprecations
+func withCalls(c ProjectServiceClient) ProjectServiceClient {
+	return &callClient{client: c}
+}
+
+func (c *callClient) Create(ctx context.Context, in *project.ProjectCreateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Call(ctx, c.client.Create, in, opts...)
+}
+
+func (c *callClient) Get(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Call(ctx, c.client.Get, in, opts...)
+}
+
+func (c *callClient) List(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*v1alpha1.AppProjectList, error) {
+	return clients.Call(ctx, c.client.List, in, opts...)
+}
+
+func (c *callClient) Update(ctx context.Context, in *project.ProjectUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Call(ctx, c.client.Update, in, opts...)
+}
+
+func (c *callClient) Delete(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*project.EmptyResponse, error) {
+	return clients.Call(ctx, c.client.Delete, in, opts...)
+}
+
+func (c *callClient) CreateToken(ctx context.Context, in *project.ProjectTokenCreateRequest, opts ...grpc.CallOption) (*project.ProjectTokenResponse, error) {
+	return clients.Call(ctx, c.client.CreateToken, in, opts...)
+}
+
+func (c *callClient) DeleteToken(ctx context.Context, in *project.ProjectTokenDeleteRequest, opts ...grpc.CallOption) (*project.EmptyResponse, error) {
+	return clients.Call(ctx, c.client.DeleteToken, in, opts...)
+}
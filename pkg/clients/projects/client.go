@@ -14,6 +14,9 @@ import (
 
 const (
 	errorProjectNotFound = "code = NotFound desc = appprojects"
+	errorAlreadyExists   = "code = AlreadyExists"
+	// ArgoCD rejects a token ID that is already in use with InvalidArgument
+	errorTokenIDUsed = "has been used"
 )
 
 // ProjectServiceClient wraps the functions to connect to argocd repositories
@@ -22,6 +25,8 @@ type ProjectServiceClient interface {
 	Create(ctx context.Context, in *project.ProjectCreateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error)
 	// Get returns a project by name
 	Get(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*v1alpha1.AppProject, error)
+	// List returns all projects
+	List(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*v1alpha1.AppProjectList, error)
 	// Update updates a project
 	Update(ctx context.Context, in *project.ProjectUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error)
 	// Delete deletes a project
@@ -33,9 +38,9 @@ type ProjectServiceClient interface {
 }
 
 // NewProjectServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewProjectServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, project.ProjectServiceClient) {
+func NewProjectServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, ProjectServiceClient) {
 	conn, repoIf := apiclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
-	return conn, repoIf
+	return conn, withCalls(repoIf)
 }
 
 // IsErrorProjectNotFound helper function to test for errorProjectNotFound error.
@@ -45,3 +50,11 @@ func IsErrorProjectNotFound(err error) bool {
 	}
 	return strings.Contains(err.Error(), errorProjectNotFound)
 }
+
+// IsErrorTokenAlreadyExists helper function to test if a token with the requested ID already exists.
+func IsErrorTokenAlreadyExists(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), errorAlreadyExists) || strings.Contains(err.Error(), errorTokenIDUsed)
+}
@@ -0,0 +1,56 @@
+package projects
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyDeletionPropagation controls how the applications of a project are handled when
+	// the project is deleted. ArgoCD refuses to delete a project used by applications if not set.
+	AnnotationKeyDeletionPropagation = "argocd.crossplane.io/deletion-propagation"
+
+	errFmtInvalidDeletionPropagation = "invalid deletion propagation %q in annotation %s, must be one of Refuse, Foreground, Background or Orphan"
+)
+
+// A DeletionPropagation controls how the applications of a project are handled when it is deleted
+type DeletionPropagation string
+
+const (
+	// DeletionPropagationNone leaves the applications to ArgoCD, which refuses the deletion
+	DeletionPropagationNone DeletionPropagation = ""
+	// DeletionPropagationRefuse refuses the deletion as long as applications use the project
+	DeletionPropagationRefuse DeletionPropagation = "Refuse"
+	// DeletionPropagationForeground deletes the applications and their resources before the project.
+	// An application is removed once all of its resources are deleted.
+	DeletionPropagationForeground DeletionPropagation = "Foreground"
+	// DeletionPropagationBackground deletes the applications and their resources before the project.
+	// An application is removed right away, while its resources are deleted in the background.
+	DeletionPropagationBackground DeletionPropagation = "Background"
+	// DeletionPropagationOrphan deletes the applications before the project, but keeps their resources
+	DeletionPropagationOrphan DeletionPropagation = "Orphan"
+)
+
+// DeletionPropagationOf returns the DeletionPropagation given by the AnnotationKeyDeletionPropagation
+// annotation of o
+func DeletionPropagationOf(o metav1.Object) (DeletionPropagation, error) {
+	switch p := DeletionPropagation(o.GetAnnotations()[AnnotationKeyDeletionPropagation]); p {
+	case DeletionPropagationNone, DeletionPropagationRefuse, DeletionPropagationForeground, DeletionPropagationBackground, DeletionPropagationOrphan:
+		return p, nil
+	default:
+		return "", errors.Errorf(errFmtInvalidDeletionPropagation, p, AnnotationKeyDeletionPropagation)
+	}
+}
+
+// Cascade returns whether the resources of the applications are deleted along with them
+func (p DeletionPropagation) Cascade() bool {
+	return p == DeletionPropagationForeground || p == DeletionPropagationBackground
+}
+
+// PropagationPolicy returns the propagation policy of the delete request of an application
+func (p DeletionPropagation) PropagationPolicy() string {
+	if p == DeletionPropagationBackground {
+		return "background"
+	}
+	return "foreground"
+}
@@ -0,0 +1,17 @@
+package projects
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyAllowEmptySpec disables the guard against an empty observed project spec if set
+	// to "true". By default an empty spec observed for a project whose desired spec is not empty is
+	// treated as a transient error, so that a partial response of ArgoCD never wipes the project.
+	AnnotationKeyAllowEmptySpec = "argocd.crossplane.io/allow-empty-spec"
+)
+
+// IsEmptySpecAllowed returns whether an empty observed spec of o is converged like any other drift
+func IsEmptySpecAllowed(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyAllowEmptySpec] == "true"
+}
@@ -0,0 +1,41 @@
+package projects
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationKeyExportDesiredSpec enables writing the AppProjectSpec the provider would send
+	// to ArgoCD to the AnnotationKeyDesiredSpec annotation if set to "true"
+	AnnotationKeyExportDesiredSpec = "argocd.crossplane.io/export-desired-spec"
+
+	// AnnotationKeyDesiredSpec holds the JSON encoded AppProjectSpec the provider would send to ArgoCD
+	AnnotationKeyDesiredSpec = "argocd.crossplane.io/desired-spec"
+
+	// AnnotationKeySnapshotInitialSpec enables recording the AppProjectSpec observed in ArgoCD before
+	// the provider changed it to the AnnotationKeyInitialSpec annotation if set to "true"
+	AnnotationKeySnapshotInitialSpec = "argocd.crossplane.io/snapshot-initial-spec"
+
+	// AnnotationKeyInitialSpec holds the JSON encoded AppProjectSpec first observed in ArgoCD. It is
+	// written once and serves as rollback reference for adopted projects.
+	AnnotationKeyInitialSpec = "argocd.crossplane.io/initial-spec"
+
+	// AnnotationKeyObserveApplicationCount enables counting the applications using a project on
+	// every observe if set to "true". Counting lists the applications of the project.
+	AnnotationKeyObserveApplicationCount = "argocd.crossplane.io/observe-application-count"
+)
+
+// IsDesiredSpecExportEnabled returns whether the desired AppProjectSpec of o is exported as annotation
+func IsDesiredSpecExportEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyExportDesiredSpec] == "true"
+}
+
+// IsApplicationCountEnabled returns whether the applications using o are counted on observe
+func IsApplicationCountEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyObserveApplicationCount] == "true"
+}
+
+// IsInitialSpecSnapshotEnabled returns whether the first observed AppProjectSpec of o is recorded as annotation
+func IsInitialSpecSnapshotEnabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeySnapshotInitialSpec] == "true"
+}
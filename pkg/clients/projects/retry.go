@@ -0,0 +1,59 @@
+package projects
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// AnnotationKeyTokenCreateRetries overrides how often the creation of a token of a resource is
+// retried after ArgoCD failed transiently, e.g. "0" to disable retries.
+const AnnotationKeyTokenCreateRetries = "argocd.crossplane.io/token-create-retries"
+
+// DefaultTokenCreateBackoff is the backoff between the attempts to create a token. The creation is
+// retried twice, independently of the retries of the ArgoCD client, before the reconcile fails.
+var DefaultTokenCreateBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    3,
+}
+
+// TokenCreateBackoff returns backoff b with the number of attempts to create a token of o
+// overridden by its AnnotationKeyTokenCreateRetries annotation, if valid.
+func TokenCreateBackoff(o metav1.Object, b wait.Backoff) wait.Backoff {
+	if n, err := strconv.Atoi(o.GetAnnotations()[AnnotationKeyTokenCreateRetries]); err == nil && n >= 0 {
+		b.Steps = n + 1
+	}
+	return b
+}
+
+// IsErrorTokenCreateRetriable returns whether the creation of a token failed transiently, i.e.
+// ArgoCD was unavailable or failed internally. Invalid requests are never retried.
+func IsErrorTokenCreateRetriable(err error) bool {
+	s, ok := status.FromError(errors.Cause(err))
+	return err != nil && ok && (s.Code() == codes.Unavailable || s.Code() == codes.Internal)
+}
+
+// CreateToken creates the token requested by req and retries transient failures with backoff b.
+// The token is created once if b has no steps.
+func CreateToken(ctx context.Context, c ProjectServiceClient, req *project.ProjectTokenCreateRequest, b wait.Backoff) (*project.ProjectTokenResponse, error) {
+	for {
+		resp, err := c.CreateToken(ctx, req)
+		if !IsErrorTokenCreateRetriable(err) || b.Steps <= 1 {
+			return resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(b.Step()):
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	errRateLimited = "rate limit of the ProviderConfig exceeded, retrying later"
+)
+
+// providerConfigs is the resource of the ProviderConfig whose rate limit is exceeded
+var providerConfigs = schema.GroupResource{Group: v1alpha1.Group, Resource: "providerconfigs"}
+
+// limiters holds the rate limiters of all ProviderConfigs by name. They are shared by
+// all controllers, so that the rate limit applies to the ArgoCD instance as a whole.
+var limiters = struct {
+	sync.Mutex
+	byName map[string]*rate.Limiter
+}{byName: map[string]*rate.Limiter{}}
+
+// rateLimiterFor returns the rate limiter of the ProviderConfig name configured by spec,
+// or nil if spec sets no rate limit. A changed rate limit is applied to the existing
+// limiter, so that operations already waiting for it are throttled as well.
+func rateLimiterFor(name string, spec *v1alpha1.ProviderConfigSpec) *rate.Limiter {
+	limiters.Lock()
+	defer limiters.Unlock()
+	if spec.RateLimit == nil {
+		delete(limiters.byName, name)
+		return nil
+	}
+	limit := rate.Limit(spec.RateLimit.RequestsPerSecond)
+	burst := int(ptr.Deref(spec.RateLimit.Burst, spec.RateLimit.RequestsPerSecond))
+	l, ok := limiters.byName[name]
+	if !ok {
+		l = rate.NewLimiter(limit, burst)
+		limiters.byName[name] = l
+		return l
+	}
+	if l.Limit() != limit {
+		l.SetLimit(limit)
+	}
+	if l.Burst() != burst {
+		l.SetBurst(burst)
+	}
+	return l
+}
+
+type rateLimitKey struct{}
+
+// rateLimit is the rate limiter of the ProviderConfig name
+type rateLimit struct {
+	name    string
+	limiter *rate.Limiter
+}
+
+// withRateLimit returns a context whose calls to ArgoCD wait for the rate limiter l of the
+// ProviderConfig name. A nil limiter never blocks.
+func withRateLimit(ctx context.Context, name string, l *rate.Limiter) context.Context {
+	return context.WithValue(ctx, rateLimitKey{}, rateLimit{name: name, limiter: l})
+}
+
+// WaitRateLimit blocks until the rate limiter of the ProviderConfig in use by ctx allows a call
+// to ArgoCD. If the call would not be allowed before ctx expires, it fails with a Conflict, so
+// that the managed reconciler requeues the resource with backoff instead of reporting an error.
+func WaitRateLimit(ctx context.Context) error {
+	rl, ok := ctx.Value(rateLimitKey{}).(rateLimit)
+	if !ok || rl.limiter == nil {
+		return nil
+	}
+	if err := rl.limiter.Wait(ctx); err != nil {
+		return kerrors.NewConflict(providerConfigs, rl.name, errors.Wrap(err, errRateLimited))
+	}
+	return nil
+}
+
+// Call calls fn with in once the rate limit of the ProviderConfig in use by ctx allows it, and
+// records the deprecations ArgoCD reports for the call in the Deprecations of ctx.
+func Call[I, O any](ctx context.Context, fn func(context.Context, I, ...grpc.CallOption) (O, error), in I, opts ...grpc.CallOption) (O, error) {
+	if err := WaitRateLimit(ctx); err != nil {
+		var zero O
+		return zero, err
+	}
+	return fn(ctx, in, append(opts, DeprecationTrailer(ctx))...)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+func TestRateLimiterFor(t *testing.T) {
+	spec := func(rps int32, burst *int32) *v1alpha1.ProviderConfigSpec {
+		return &v1alpha1.ProviderConfigSpec{RateLimit: &v1alpha1.RateLimit{RequestsPerSecond: rps, Burst: burst}}
+	}
+
+	if l := rateLimiterFor("unlimited", &v1alpha1.ProviderConfigSpec{}); l != nil {
+		t.Errorf("rateLimiterFor(...): want nil limiter without rate limit, got %v", l)
+	}
+
+	l := rateLimiterFor("limited", spec(5, nil))
+	if diff := cmp.Diff(rate.Limit(5), l.Limit()); diff != "" {
+		t.Errorf("rateLimiterFor(...): -want limit, +got limit:\n%s", diff)
+	}
+	if diff := cmp.Diff(5, l.Burst()); diff != "" {
+		t.Errorf("rateLimiterFor(...): -want burst, +got burst:\n%s", diff)
+	}
+
+	shared := rateLimiterFor("limited", spec(10, ptr.To[int32](20)))
+	if shared != l {
+		t.Errorf("rateLimiterFor(...): want the limiter to be shared by ProviderConfig")
+	}
+	if diff := cmp.Diff(rate.Limit(10), l.Limit()); diff != "" {
+		t.Errorf("rateLimiterFor(...): -want updated limit, +got limit:\n%s", diff)
+	}
+	if diff := cmp.Diff(20, l.Burst()); diff != "" {
+		t.Errorf("rateLimiterFor(...): -want updated burst, +got burst:\n%s", diff)
+	}
+
+	if other := rateLimiterFor("other", spec(10, nil)); other == l {
+		t.Errorf("rateLimiterFor(...): want a limiter per ProviderConfig")
+	}
+}
+
+func TestFallbackClientRateLimit(t *testing.T) {
+	calls := 0
+	// every observe makes two calls to ArgoCD, e.g. a get and a list
+	observe := func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+		for i := 0; i < 2; i++ {
+			if _, err := Call(ctx, func(_ context.Context, _ string, _ ...grpc.CallOption) (string, error) {
+				calls++
+				return "", nil
+			}, "in"); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+		}
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+	c := &FallbackClient{
+		names:   []string{"primary"},
+		client:  managed.ExternalClientFns{ObserveFn: observe},
+		limiter: rate.NewLimiter(rate.Limit(20), 2),
+	}
+	mg := &fake.Managed{}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Observe(context.Background(), mg); err != nil {
+			t.Fatalf("Observe(...): %s", err)
+		}
+	}
+	// The first two calls use the burst, the remaining four wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Observe(...): want calls to be throttled to 20 per second, 6 calls took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.limiter = rate.NewLimiter(rate.Limit(1), 1)
+	_, err := c.Observe(ctx, mg)
+	if !kerrors.IsConflict(err) || !strings.Contains(err.Error(), errRateLimited) {
+		t.Errorf("Observe(...): want rate limit conflict, got %v", err)
+	}
+	if diff := cmp.Diff(7, calls); diff != "" {
+		t.Errorf("Observe(...): -want calls, +got calls:\n%s", diff)
+	}
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// callClient waits for the rate limit of the ProviderConfig in use before every call and
+// records the deprecations ArgoCD reports for it in the clients.Deprecations of the context.
+type callClient struct {
+	client RepositoryServiceClient
+}
+
+// withCalls returns a client which rate limits the calls of c and records their deprecations
+func withCalls(c RepositoryServiceClient) RepositoryServiceClient {
+	return &callClient{client: c}
+}
+
+func (c *callClient) Get(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Call(ctx, c.client.Get, in, opts...)
+}
+
+func (c *callClient) ListRepositories(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryList, error) {
+	return clients.Call(ctx, c.client.ListRepositories, in, opts...)
+}
+
+func (c *callClient) CreateRepository(ctx context.Context, in *repository.RepoCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Call(ctx, c.client.CreateRepository, in, opts...)
+}
+
+func (c *callClient) UpdateRepository(ctx context.Context, in *repository.RepoUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Call(ctx, c.client.UpdateRepository, in, opts...)
+}
+
+func (c *callClient) DeleteRepository(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*repository.RepoResponse, error) {
+	return clients.Call(ctx, c.client.DeleteRepository, in, opts...)
+}
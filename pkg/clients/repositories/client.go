@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v2/util/git"
 	"github.com/argoproj/argo-cd/v2/util/io"
 
 	"google.golang.org/grpc"
@@ -31,10 +33,51 @@ type RepositoryServiceClient interface {
 	DeleteRepository(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*repository.RepoResponse, error)
 }
 
+// RepoCredsServiceClient wraps the functions to read argocd repository credential templates
+type RepoCredsServiceClient interface {
+	// ListRepositoryCredentials gets a list of all configured repository credential templates
+	ListRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsQuery, opts ...grpc.CallOption) (*v1alpha1.RepoCredsList, error)
+}
+
 // NewRepositoryServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewRepositoryServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, repository.RepositoryServiceClient) {
+func NewRepositoryServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, RepositoryServiceClient) {
 	conn, repoIf := apiclient.NewClientOrDie(clientOpts).NewRepoClientOrDie()
-	return conn, repoIf
+	return conn, withCalls(repoIf)
+}
+
+// NewRepoCredsServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
+func NewRepoCredsServiceClient(clientOpts *apiclient.ClientOptions) (io.Closer, RepoCredsServiceClient) {
+	return apiclient.NewClientOrDie(clientOpts).NewRepoCredsClientOrDie()
+}
+
+// MatchCredentialTemplate returns the credential template whose URL is the longest prefix of the
+// repository URL, which is the template ArgoCD inherits the credentials of a repository from.
+func MatchCredentialTemplate(repoURL string, templates []v1alpha1.RepoCreds) *v1alpha1.RepoCreds {
+	var match *v1alpha1.RepoCreds
+	repoURL = git.NormalizeGitURL(repoURL)
+	for i := range templates {
+		prefix := git.NormalizeGitURL(templates[i].URL)
+		if strings.HasPrefix(repoURL, prefix) && (match == nil || len(prefix) > len(git.NormalizeGitURL(match.URL))) {
+			match = &templates[i]
+		}
+	}
+	return match
+}
+
+// MatchRepository returns the repository whose URL matches the first of urls that matches any
+// repository. URLs are compared normalized, so that e.g. a trailing .git does not prevent a match.
+func MatchRepository(repos v1alpha1.Repositories, urls ...string) *v1alpha1.Repository {
+	for _, u := range urls {
+		if u = git.NormalizeGitURL(u); u == "" {
+			continue
+		}
+		for _, r := range repos {
+			if r != nil && git.NormalizeGitURL(r.Repo) == u {
+				return r
+			}
+		}
+	}
+	return nil
 }
 
 // IsErrorRepositoryNotFound helper function to test for errorRepositoryNotFound error.
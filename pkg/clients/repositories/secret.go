@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/argoproj/argo-cd/v2/common"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationKeyDeclarativeNamespace reconciles a repository through a declarative repository
+// secret in the given namespace of ArgoCD instead of the repository API, e.g. for ArgoCD
+// instances which don't allow managing repositories through the API. ArgoCD must run in
+// the cluster of the provider. The repositories of the legacy argocd-cm ConfigMap are not
+// supported, as ArgoCD deprecated them in favor of repository secrets.
+const AnnotationKeyDeclarativeNamespace = "argocd.crossplane.io/declarative-namespace"
+
+const (
+	secretNamePrefix = "repo"
+
+	errListSecrets   = "cannot list repository secrets"
+	errCreateSecret  = "cannot create repository secret"
+	errUpdateSecret  = "cannot update repository secret"
+	errDeleteSecret  = "cannot delete repository secret"
+	errFmtParseKey   = "cannot parse key %s of repository secret"
+	errFmtRepoExists = "repository %s already exists"
+)
+
+// DeclarativeNamespace returns the namespace of the declarative repository secret of o, if any
+func DeclarativeNamespace(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyDeclarativeNamespace]
+}
+
+// SecretClient is a RepositoryServiceClient which manages the declarative repository secrets
+// of ArgoCD in a namespace.
+type SecretClient struct {
+	kube      client.Client
+	namespace string
+}
+
+// NewSecretClient returns a client for the repository secrets in namespace
+func NewSecretClient(kube client.Client, namespace string) *SecretClient {
+	return &SecretClient{kube: kube, namespace: namespace}
+}
+
+// Get returns the repository of the secret for in.Repo
+func (c *SecretClient) Get(ctx context.Context, in *repository.RepoQuery, _ ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	s, err := c.getSecret(ctx, in.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return SecretToRepository(s)
+}
+
+// ListRepositories returns the repositories of all repository secrets
+func (c *SecretClient) ListRepositories(ctx context.Context, _ *repository.RepoQuery, _ ...grpc.CallOption) (*v1alpha1.RepositoryList, error) {
+	secrets, err := c.listSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l := &v1alpha1.RepositoryList{}
+	for i := range secrets {
+		r, err := SecretToRepository(&secrets[i])
+		if err != nil {
+			return nil, err
+		}
+		l.Items = append(l.Items, r)
+	}
+	return l, nil
+}
+
+// CreateRepository creates a repository secret for in.Repo
+func (c *SecretClient) CreateRepository(ctx context.Context, in *repository.RepoCreateRequest, _ ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	_, err := c.getSecret(ctx, in.Repo.Repo)
+	switch {
+	case err == nil:
+		return nil, status.Errorf(codes.AlreadyExists, errFmtRepoExists, in.Repo.Repo)
+	case !IsErrorRepositoryNotFound(err):
+		return nil, err
+	}
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(in.Repo.Repo),
+			Namespace: c.namespace,
+			Labels:    map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeRepository},
+		},
+		Data: RepositoryToSecretData(in.Repo),
+	}
+	if err := c.kube.Create(ctx, s); err != nil {
+		return nil, errors.Wrap(err, errCreateSecret)
+	}
+	return in.Repo, nil
+}
+
+// UpdateRepository replaces the data of the repository secret for in.Repo
+func (c *SecretClient) UpdateRepository(ctx context.Context, in *repository.RepoUpdateRequest, _ ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	s, err := c.getSecret(ctx, in.Repo.Repo)
+	if err != nil {
+		return nil, err
+	}
+	s.Data = RepositoryToSecretData(in.Repo)
+	if err := c.kube.Update(ctx, s); err != nil {
+		return nil, errors.Wrap(err, errUpdateSecret)
+	}
+	return in.Repo, nil
+}
+
+// DeleteRepository deletes the repository secret for in.Repo
+func (c *SecretClient) DeleteRepository(ctx context.Context, in *repository.RepoQuery, _ ...grpc.CallOption) (*repository.RepoResponse, error) {
+	s, err := c.getSecret(ctx, in.Repo)
+	if IsErrorRepositoryNotFound(err) {
+		return &repository.RepoResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := c.kube.Delete(ctx, s); client.IgnoreNotFound(err) != nil {
+		return nil, errors.Wrap(err, errDeleteSecret)
+	}
+	return &repository.RepoResponse{}, nil
+}
+
+func (c *SecretClient) listSecrets(ctx context.Context) ([]corev1.Secret, error) {
+	l := &corev1.SecretList{}
+	if err := c.kube.List(ctx, l, client.InNamespace(c.namespace), client.MatchingLabels{common.LabelKeySecretType: common.LabelValueSecretTypeRepository}); err != nil {
+		return nil, errors.Wrap(err, errListSecrets)
+	}
+	return l.Items, nil
+}
+
+// getSecret returns the repository secret of repo. It is looked up by its URL, so that
+// repository secrets which weren't created by the provider are found as well.
+func (c *SecretClient) getSecret(ctx context.Context, repo string) (*corev1.Secret, error) {
+	secrets, err := c.listSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		if string(secrets[i].Data["url"]) == repo {
+			return &secrets[i], nil
+		}
+	}
+	// the error matches IsErrorRepositoryNotFound like the one of the repository API
+	return nil, status.Errorf(codes.NotFound, "repo '%s' not found", repo)
+}
+
+// SecretName returns the name of a new repository secret of repo, as named by ArgoCD
+func SecretName(repo string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(repo))
+	return fmt.Sprintf("%s-%v", secretNamePrefix, h.Sum32())
+}
+
+// RepositoryToSecretData returns the data of the repository secret of r. Unset fields are omitted.
+func RepositoryToSecretData(r *v1alpha1.Repository) map[string][]byte {
+	d := map[string][]byte{}
+	setString := func(k, v string) {
+		if v != "" {
+			d[k] = []byte(v)
+		}
+	}
+	setBool := func(k string, v bool) {
+		if v {
+			d[k] = []byte(strconv.FormatBool(v))
+		}
+	}
+	setInt := func(k string, v int64) {
+		if v != 0 {
+			d[k] = []byte(strconv.FormatInt(v, 10))
+		}
+	}
+	setString("url", r.Repo)
+	setString("name", r.Name)
+	setString("project", r.Project)
+	setString("type", r.Type)
+	setString("username", r.Username)
+	setString("password", r.Password)
+	setString("sshPrivateKey", r.SSHPrivateKey)
+	setString("tlsClientCertData", r.TLSClientCertData)
+	setString("tlsClientCertKey", r.TLSClientCertKey)
+	setString("githubAppPrivateKey", r.GithubAppPrivateKey)
+	setInt("githubAppID", r.GithubAppId)
+	setInt("githubAppInstallationID", r.GithubAppInstallationId)
+	setString("githubAppEnterpriseBaseUrl", r.GitHubAppEnterpriseBaseURL)
+	setBool("insecure", r.Insecure)
+	setBool("enableLfs", r.EnableLFS)
+	setBool("enableOCI", r.EnableOCI)
+	setBool("forceHttpBasicAuth", r.ForceHttpBasicAuth)
+	return d
+}
+
+// SecretToRepository returns the repository declared by the repository secret s
+func SecretToRepository(s *corev1.Secret) (*v1alpha1.Repository, error) {
+	r := &v1alpha1.Repository{
+		Repo:                       string(s.Data["url"]),
+		Name:                       string(s.Data["name"]),
+		Project:                    string(s.Data["project"]),
+		Type:                       string(s.Data["type"]),
+		Username:                   string(s.Data["username"]),
+		Password:                   string(s.Data["password"]),
+		SSHPrivateKey:              string(s.Data["sshPrivateKey"]),
+		TLSClientCertData:          string(s.Data["tlsClientCertData"]),
+		TLSClientCertKey:           string(s.Data["tlsClientCertKey"]),
+		GithubAppPrivateKey:        string(s.Data["githubAppPrivateKey"]),
+		GitHubAppEnterpriseBaseURL: string(s.Data["githubAppEnterpriseBaseUrl"]),
+	}
+	var err error
+	parseBool := func(k string, v *bool) {
+		if b, ok := s.Data[k]; ok && err == nil {
+			*v, err = strconv.ParseBool(string(b))
+			err = errors.Wrapf(err, errFmtParseKey, k)
+		}
+	}
+	parseInt := func(k string, v *int64) {
+		if b, ok := s.Data[k]; ok && err == nil {
+			*v, err = strconv.ParseInt(string(b), 10, 64)
+			err = errors.Wrapf(err, errFmtParseKey, k)
+		}
+	}
+	parseInt("githubAppID", &r.GithubAppId)
+	parseInt("githubAppInstallationID", &r.GithubAppInstallationId)
+	parseBool("insecure", &r.Insecure)
+	parseBool("enableLfs", &r.EnableLFS)
+	parseBool("enableOCI", &r.EnableOCI)
+	parseBool("forceHttpBasicAuth", &r.ForceHttpBasicAuth)
+	return r, err
+}
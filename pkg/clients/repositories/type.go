@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// TypeGit is the type of git repositories
+	TypeGit = "git"
+	// TypeHelm is the type of helm chart repositories
+	TypeHelm = "helm"
+)
+
+// IsSupportedType returns whether ArgoCD supports repositories of type t
+func IsSupportedType(t string) bool {
+	return t == TypeGit || t == TypeHelm
+}
+
+// InferType infers the type of the repository at repo from its URL. OCI registries, which
+// are given without a scheme and with enableOCI, and hosts named charts.* are helm
+// repositories, all other repositories are assumed to be git repositories as by ArgoCD.
+func InferType(repo string, enableOCI bool) string {
+	if enableOCI {
+		return TypeHelm
+	}
+	u, err := url.Parse(repo)
+	if err != nil || u.Host == "" {
+		// scp-like git URLs such as git@github.com:org/repo.git have no scheme
+		return TypeGit
+	}
+	switch {
+	case strings.HasSuffix(strings.TrimSuffix(u.Path, "/"), ".git"):
+		return TypeGit
+	case (u.Scheme == "http" || u.Scheme == "https") && strings.HasPrefix(u.Hostname(), "charts."):
+		return TypeHelm
+	}
+	return TypeGit
+}
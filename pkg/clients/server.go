@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CanonicalServerURL returns the canonical form of a destination server URL, so that
+// variants ArgoCD treats identically compare equal. Scheme and host are lowercased,
+// default ports and trailing slashes are removed. Values that are not absolute URLs
+// are only trimmed.
+func CanonicalServerURL(server string) string {
+	s := strings.TrimRight(strings.TrimSpace(server), "/")
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return s
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	switch {
+	case u.Scheme == "https" && u.Port() == "443",
+		u.Scheme == "http" && u.Port() == "80":
+		u.Host = u.Hostname()
+		if strings.Contains(u.Host, ":") {
+			u.Host = "[" + u.Host + "]"
+		}
+	}
+	return u.String()
+}
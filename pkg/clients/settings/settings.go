@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package settings reads and writes the ArgoCD settings stored in the argocd-cm, argocd-rbac-cm and
+// argocd-cmd-params-cm ConfigMaps. These ConfigMaps are not exposed by the ArgoCD API, so they are
+// managed with the kube client of the provider, which therefore has to run in the cluster ArgoCD is
+// installed in. Resources whose ProviderConfig points at a remote ArgoCD are rejected, instead of
+// editing the settings of another ArgoCD. The ConfigMaps are read with the uncached API reader, so
+// that the provider doesn't cache all ConfigMaps of the cluster.
+package settings
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultNamespace is the namespace ArgoCD is installed in if none is given
+	DefaultNamespace = "argocd"
+	// ConfigMapName is the name of the ConfigMap holding the ArgoCD settings
+	ConfigMapName = "argocd-cm"
+	// GlobalProjectsKey is the key of the global projects setting
+	GlobalProjectsKey = "globalProjects"
+	// RBACConfigMapName is the name of the ConfigMap holding the ArgoCD RBAC settings
+	RBACConfigMapName = "argocd-rbac-cm"
+	// PolicyCSVKey is the key of the RBAC policy in CSV format
+	PolicyCSVKey = "policy.csv"
+	// PolicyDefaultKey is the key of the default role of authenticated users
+	PolicyDefaultKey = "policy.default"
+	// ScopesKey is the key of the OIDC scopes matched against group bindings
+	ScopesKey = "scopes"
+	// ResourceExclusionsKey is the key of the resources not tracked by ArgoCD
+	ResourceExclusionsKey = "resource.exclusions"
+	// ResourceInclusionsKey is the key of the resources tracked by ArgoCD
+	ResourceInclusionsKey = "resource.inclusions"
+	// HealthCustomizationKeyPrefix is the prefix of the keys of the custom resource health checks
+	HealthCustomizationKeyPrefix = "resource.customizations.health."
+	// IgnoreDifferencesCustomizationKeyPrefix is the prefix of the keys of the global ignored differences
+	IgnoreDifferencesCustomizationKeyPrefix = "resource.customizations.ignoreDifferences."
+	// CmdParamsConfigMapName is the name of the ConfigMap holding the command parameters of the ArgoCD components
+	CmdParamsConfigMapName = "argocd-cmd-params-cm"
+	// ControllerStatusProcessorsKey is the key of the number of status processors of the application controller
+	ControllerStatusProcessorsKey = "controller.status.processors"
+	// ControllerOperationProcessorsKey is the key of the number of operation processors of the application controller
+	ControllerOperationProcessorsKey = "controller.operation.processors"
+	// ControllerRepoServerTimeoutSecondsKey is the key of the repo server timeout of the application controller
+	ControllerRepoServerTimeoutSecondsKey = "controller.repo.server.timeout.seconds"
+	// ControllerSelfHealTimeoutSecondsKey is the key of the self heal timeout of the application controller
+	ControllerSelfHealTimeoutSecondsKey = "controller.self.heal.timeout.seconds"
+	// ControllerKubectlParallelismLimitKey is the key of the kubectl parallelism limit of the application controller
+	ControllerKubectlParallelismLimitKey = "controller.kubectl.parallelism.limit"
+	// ServerRepoServerTimeoutSecondsKey is the key of the repo server timeout of the API server
+	ServerRepoServerTimeoutSecondsKey = "server.repo.server.timeout.seconds"
+	// RepoServerParallelismLimitKey is the key of the parallelism limit of the repo server
+	RepoServerParallelismLimitKey = "reposerver.parallelism.limit"
+
+	errGetConfigMap         = "cannot get ArgoCD settings ConfigMap"
+	errGetProviderConfig    = "cannot get referenced ProviderConfig"
+	errFmtRemoteServer      = "ArgoCD %s of ProviderConfig %s is not in the cluster of the provider, its settings cannot be managed"
+	errParseGlobalProjects  = "cannot parse globalProjects setting"
+	errRenderGlobalProjects = "cannot render globalProjects setting"
+	errFmtParseFilter       = "cannot parse %s setting"
+	errFmtRenderFilter      = "cannot render %s setting"
+	errFmtParseIgnoreDiffs  = "cannot parse %s setting"
+	errFmtRenderIgnoreDiffs = "cannot render %s setting"
+)
+
+// GlobalProject is an entry of the globalProjects setting
+type GlobalProject struct {
+	ProjectName   string               `json:"projectName,omitempty"`
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// FilteredResource is an entry of the resource.exclusions and resource.inclusions settings
+type FilteredResource struct {
+	APIGroups []string `json:"apiGroups,omitempty"`
+	Kinds     []string `json:"kinds,omitempty"`
+	Clusters  []string `json:"clusters,omitempty"`
+}
+
+// IgnoreDifferences is the value of a resource.customizations.ignoreDifferences setting
+type IgnoreDifferences struct {
+	JSONPointers          []string `json:"jsonPointers,omitempty"`
+	JQPathExpressions     []string `json:"jqPathExpressions,omitempty"`
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// Namespace returns ns or DefaultNamespace if ns is not set
+func Namespace(ns *string) string {
+	if ns == nil || *ns == "" {
+		return DefaultNamespace
+	}
+	return *ns
+}
+
+// HealthCustomizationKey returns the key of the custom health check of the resources of group and kind,
+// e.g. resource.customizations.health.cert-manager.io_Certificate. The group is omitted for core resources.
+func HealthCustomizationKey(group, kind string) string {
+	return customizationKey(HealthCustomizationKeyPrefix, group, kind)
+}
+
+// IgnoreDifferencesCustomizationKey returns the key of the ignored differences of the resources of group
+// and kind, e.g. resource.customizations.ignoreDifferences.apps_Deployment. The group is omitted for core
+// resources and for the kind all, which applies to all resources.
+func IgnoreDifferencesCustomizationKey(group, kind string) string {
+	return customizationKey(IgnoreDifferencesCustomizationKeyPrefix, group, kind)
+}
+
+func customizationKey(prefix, group, kind string) string {
+	if group == "" {
+		return prefix + kind
+	}
+	return prefix + group + "_" + kind
+}
+
+// CheckInCluster returns an error unless the ProviderConfig referenced by mg points at an ArgoCD
+// installed in the cluster of the provider, whose settings are the ConfigMaps the kube client edits.
+func CheckInCluster(ctx context.Context, kube client.Reader, mg resource.Managed) error {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return errors.New(errGetProviderConfig)
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return errors.Wrap(err, errGetProviderConfig)
+	}
+	if !IsInClusterServer(pc.Spec.ServerAddr) {
+		return errors.Errorf(errFmtRemoteServer, pc.Spec.ServerAddr, ref.Name)
+	}
+	return nil
+}
+
+// IsInClusterServer returns whether the ArgoCD server address addr is a Service of the cluster of the
+// provider, e.g. argocd-server, argocd-server.argocd.svc:443 or argocd-server.argocd.svc.cluster.local,
+// or the local host. IP addresses of Services cannot be told apart from remote addresses.
+func IsInClusterServer(addr string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback()
+	}
+	return host == "localhost" || !strings.Contains(host, ".") || strings.Contains(host+".", ".svc.")
+}
+
+// GetConfigMap fetches the ConfigMap name from namespace ns
+func GetConfigMap(ctx context.Context, kube client.Reader, ns, name string) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, cm); err != nil {
+		return nil, errors.Wrap(err, errGetConfigMap)
+	}
+	return cm, nil
+}
+
+// GetGlobalProjects returns the entries of the globalProjects setting of cm
+func GetGlobalProjects(cm *corev1.ConfigMap) ([]GlobalProject, error) {
+	v := cm.Data[GlobalProjectsKey]
+	if v == "" {
+		return nil, nil
+	}
+	var gps []GlobalProject
+	if err := yaml.Unmarshal([]byte(v), &gps); err != nil {
+		return nil, errors.Wrap(err, errParseGlobalProjects)
+	}
+	return gps, nil
+}
+
+// SetGlobalProjects writes gps into the globalProjects setting of cm.
+// The setting is removed if gps is empty.
+func SetGlobalProjects(cm *corev1.ConfigMap, gps []GlobalProject) error {
+	if len(gps) == 0 {
+		delete(cm.Data, GlobalProjectsKey)
+		return nil
+	}
+	b, err := yaml.Marshal(gps)
+	if err != nil {
+		return errors.Wrap(err, errRenderGlobalProjects)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[GlobalProjectsKey] = string(b)
+	return nil
+}
+
+// GetResourceFilter returns the entries of the resource filter setting key of cm,
+// i.e. ResourceExclusionsKey or ResourceInclusionsKey
+func GetResourceFilter(cm *corev1.ConfigMap, key string) ([]FilteredResource, error) {
+	v := cm.Data[key]
+	if v == "" {
+		return nil, nil
+	}
+	var frs []FilteredResource
+	if err := yaml.Unmarshal([]byte(v), &frs); err != nil {
+		return nil, errors.Wrapf(err, errFmtParseFilter, key)
+	}
+	return frs, nil
+}
+
+// RenderResourceFilter renders frs in the format of the resource filter settings
+func RenderResourceFilter(key string, frs []FilteredResource) (string, error) {
+	if frs == nil {
+		frs = []FilteredResource{}
+	}
+	b, err := yaml.Marshal(frs)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtRenderFilter, key)
+	}
+	return string(b), nil
+}
+
+// GetIgnoreDifferences returns the ignored differences of the setting key of cm and whether the setting exists
+func GetIgnoreDifferences(cm *corev1.ConfigMap, key string) (IgnoreDifferences, bool, error) {
+	v, ok := cm.Data[key]
+	if !ok {
+		return IgnoreDifferences{}, false, nil
+	}
+	var d IgnoreDifferences
+	if err := yaml.Unmarshal([]byte(v), &d); err != nil {
+		return IgnoreDifferences{}, true, errors.Wrapf(err, errFmtParseIgnoreDiffs, key)
+	}
+	return d, true, nil
+}
+
+// RenderIgnoreDifferences renders d in the format of the resource.customizations.ignoreDifferences settings
+func RenderIgnoreDifferences(key string, d IgnoreDifferences) (string, error) {
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtRenderIgnoreDiffs, key)
+	}
+	return string(b), nil
+}
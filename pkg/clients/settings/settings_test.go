@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsInClusterServer(t *testing.T) {
+	cases := map[string]struct {
+		addr string
+		want bool
+	}{
+		"ServiceName":      {addr: "argocd-server", want: true},
+		"Service":          {addr: "argocd-server.argocd.svc:443", want: true},
+		"ServiceFQDN":      {addr: "argocd-server.argocd.svc.cluster.local.", want: true},
+		"Localhost":        {addr: "localhost:8080", want: true},
+		"Loopback":         {addr: "127.0.0.1:8080", want: true},
+		"RemoteHost":       {addr: "argocd.example.com", want: false},
+		"RemoteHostPort":   {addr: "argocd.example.com:443", want: false},
+		"RemoteIP":         {addr: "10.0.0.1:443", want: false},
+		"SvcLikeSubdomain": {addr: "argocd.svcs.example.com", want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsInClusterServer(tc.addr)); diff != "" {
+				t.Errorf("IsInClusterServer(%q): -want, +got:\n%s", tc.addr, diff)
+			}
+		})
+	}
+}
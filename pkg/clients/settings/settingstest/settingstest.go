@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package settingstest provides fixtures for testing the controllers of the ArgoCD settings.
+package settingstest
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+)
+
+// WithConfigMapData returns a MockGetFn which fills the fetched ConfigMap with data. It fails unless
+// the ConfigMap with the given name in the default namespace of ArgoCD is fetched.
+func WithConfigMapData(name string, data map[string]string) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name != name || key.Namespace != settings.DefaultNamespace {
+			return errors.Errorf("unexpected ConfigMap %s", key)
+		}
+		obj.(*corev1.ConfigMap).Data = data
+		return nil
+	}
+}
+
+// ExpectConfigMapData returns a MockUpdateFn which fails unless the updated ConfigMap holds data.
+func ExpectConfigMapData(data map[string]string) test.MockUpdateFn {
+	return func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		if diff := cmp.Diff(data, obj.(*corev1.ConfigMap).Data); diff != "" {
+			return errors.Errorf("unexpected ConfigMap data: -want, +got:\n%s", diff)
+		}
+		return nil
+	}
+}
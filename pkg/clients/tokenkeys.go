@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	errFmtInvalidTokenKeyTemplate = "invalid tokenSecretKeyTemplate %q"
+	errFmtRenderTokenKey          = "cannot render secret key of token %s of role %s"
+	errFmtInvalidTokenKey         = "invalid secret key %q of token %s of role %s: %s"
+)
+
+// TokenKey holds the fields available to a token secret key template
+type TokenKey struct {
+	// Role of the token
+	Role string
+	// ID of the token
+	ID string
+}
+
+// TokenSecretKeys forms the connection secret keys of project tokens using the
+// template of the ProviderConfig. The zero value forms keys like role.id.
+type TokenSecretKeys struct {
+	tmpl *template.Template
+}
+
+// TokenSecretKeysFor returns the TokenSecretKeys configured by spec
+func TokenSecretKeysFor(spec *v1alpha1.ProviderConfigSpec) (TokenSecretKeys, error) {
+	if spec.TokenSecretKeyTemplate == nil {
+		return TokenSecretKeys{}, nil
+	}
+	tmpl, err := template.New("tokenSecretKey").Option("missingkey=error").Parse(*spec.TokenSecretKeyTemplate)
+	if err != nil {
+		return TokenSecretKeys{}, errors.Wrapf(err, errFmtInvalidTokenKeyTemplate, *spec.TokenSecretKeyTemplate)
+	}
+	return TokenSecretKeys{tmpl: tmpl}, nil
+}
+
+// GetTokenSecretKeys returns the TokenSecretKeys of the ProviderConfig referenced by mg
+func GetTokenSecretKeys(ctx context.Context, c client.Client, mg resource.Managed) (TokenSecretKeys, error) {
+	if mg.GetProviderConfigReference() == nil {
+		return TokenSecretKeys{}, errors.New(errNoProviderConfigRef)
+	}
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return TokenSecretKeys{}, errors.Wrap(err, "cannot get referenced Provider")
+	}
+	return TokenSecretKeysFor(&pc.Spec)
+}
+
+// Key returns the connection secret key of token id of role. The key has to be
+// a valid secret key.
+func (k TokenSecretKeys) Key(role, id string) (string, error) {
+	key := role + "." + id
+	if k.tmpl != nil {
+		var b strings.Builder
+		if err := k.tmpl.Execute(&b, TokenKey{Role: role, ID: id}); err != nil {
+			return "", errors.Wrapf(err, errFmtRenderTokenKey, id, role)
+		}
+		key = b.String()
+	}
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return "", errors.Errorf(errFmtInvalidTokenKey, key, id, role, strings.Join(errs, ", "))
+	}
+	return key, nil
+}
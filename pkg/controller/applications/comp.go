@@ -1,17 +1,110 @@
 package applications
 
 import (
+	"bytes"
 	"maps"
+	"reflect"
 	"slices"
+	"strings"
 
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
 )
 
+// systemManagedKeys are labels and annotations set on an Application by ArgoCD
+// or kubectl. They are neither compared nor late-initialized.
+var systemManagedKeys = []string{
+	"app.kubernetes.io/instance",
+	"argocd.argoproj.io/instance",
+	"argocd.argoproj.io/refresh",
+	"argocd.argoproj.io/tracking-id",
+	"kubectl.kubernetes.io/last-applied-configuration",
+	notifiedAnnotationKey,
+}
+
+// withoutSystemManagedKeys returns a copy of m without system managed keys or nil if nothing remains
+func withoutSystemManagedKeys(m map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range m {
+		if slices.Contains(systemManagedKeys, k) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string, len(m))
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// withSystemManagedKeys returns a copy of desired with the system managed keys of observed. ArgoCD
+// replaces the labels and annotations of an application on update, which would otherwise remove them.
+func withSystemManagedKeys(desired, observed map[string]string) map[string]string {
+	var out map[string]string
+	for _, k := range systemManagedKeys {
+		v, ok := observed[k]
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(desired)
+			if out == nil {
+				out = make(map[string]string, len(observed))
+			}
+		}
+		out[k] = v
+	}
+	if out == nil {
+		return desired
+	}
+	return out
+}
+
+// normalizeSyncOptions returns the sorted and deduplicated sync options o or nil if there are none
+func normalizeSyncOptions(o argocdv1alpha1.SyncOptions) argocdv1alpha1.SyncOptions {
+	var out argocdv1alpha1.SyncOptions
+	for _, s := range o {
+		if s = strings.TrimSpace(s); !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// parseValuesObject returns the parsed helm values object v, or nil if it holds no values
+func parseValuesObject(v *runtime.RawExtension) (map[string]any, error) {
+	if v == nil || len(bytes.TrimSpace(v.Raw)) == 0 {
+		return nil, nil
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(v.Raw, &values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+// equalValuesObject compares the helm values objects a and b semantically, so that formatting and
+// the order of keys are irrelevant. Unparsable values objects are compared byte-wise.
+func equalValuesObject(a, b *runtime.RawExtension) bool {
+	va, errA := parseValuesObject(a)
+	vb, errB := parseValuesObject(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
 // IsApplicationUpToDate converts ApplicationParameters to its ArgoCD Counterpart and returns if they equal
 func IsApplicationUpToDate(cr *v1alpha1.ApplicationParameters, remote *argocdv1alpha1.Application) bool { // nolint:gocyclo
 	converter := applications.ConverterImpl{}
@@ -21,11 +114,44 @@ func IsApplicationUpToDate(cr *v1alpha1.ApplicationParameters, remote *argocdv1a
 		// explicitly ignore the unexported in this type instead of adding a generic allow on all type.
 		// the unexported fields should not bother here, since we don't copy them or write them
 		cmpopts.IgnoreUnexported(argocdv1alpha1.ApplicationDestination{}),
+		// server URLs ArgoCD treats identically are equal
+		cmp.Transformer("CanonicalServerURL", func(d argocdv1alpha1.ApplicationDestination) argocdv1alpha1.ApplicationDestination {
+			d.Server = clients.CanonicalServerURL(d.Server)
+			return d
+		}),
+		// jsonnet ext vars and TLAs are keyed by name, their order is irrelevant
+		cmpopts.SortSlices(func(a, b argocdv1alpha1.JsonnetVar) bool { return a.Name < b.Name }),
+		// plugin env and parameters are keyed by name as well, nil env entries sort first
+		cmpopts.SortSlices(func(a, b *argocdv1alpha1.EnvEntry) bool { return b != nil && (a == nil || a.Name < b.Name) }),
+		cmpopts.SortSlices(func(a, b argocdv1alpha1.ApplicationSourcePluginParameter) bool { return a.Name < b.Name }),
+		// managed fields managers are a set of trusted managers
+		cmp.Transformer("ManagedFieldsManagers", func(d argocdv1alpha1.ResourceIgnoreDifferences) argocdv1alpha1.ResourceIgnoreDifferences {
+			d.ManagedFieldsManagers = slices.Clone(d.ManagedFieldsManagers)
+			slices.Sort(d.ManagedFieldsManagers)
+			return d
+		}),
+		// sync options are a set, their order and duplicates are irrelevant
+		cmp.Transformer("SyncOptions", normalizeSyncOptions),
+		// helm values objects are equal if they hold the same values
+		cmp.Comparer(equalValuesObject),
+		// empty namespace labels and annotations are omitted by ArgoCD
+		cmp.Transformer("ManagedNamespaceMetadata", func(m argocdv1alpha1.ManagedNamespaceMetadata) argocdv1alpha1.ManagedNamespaceMetadata {
+			if len(m.Labels) == 0 {
+				m.Labels = nil
+			}
+			if len(m.Annotations) == 0 {
+				m.Annotations = nil
+			}
+			return m
+		}),
 	}
 
 	// Sort finalizer slices for comparison
 	slices.Sort(cr.Finalizers)
 	slices.Sort(remote.Finalizers)
 
-	return cmp.Equal(*cluster, remote.Spec, opts...) && maps.Equal(cr.Annotations, remote.Annotations) && slices.Equal(cr.Finalizers, remote.Finalizers)
+	return cmp.Equal(*cluster, remote.Spec, opts...) &&
+		maps.Equal(withoutSystemManagedKeys(cr.Labels), withoutSystemManagedKeys(remote.Labels)) &&
+		maps.Equal(withoutSystemManagedKeys(cr.Annotations), withoutSystemManagedKeys(remote.Annotations)) &&
+		slices.Equal(cr.Finalizers, remote.Finalizers)
 }
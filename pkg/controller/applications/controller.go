@@ -22,11 +22,15 @@ import (
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v2/util/io"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,12 +38,15 @@ import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
 )
 
@@ -50,6 +57,33 @@ const (
 	errCreateFailed     = "cannot create Argocd application"
 	errUpdateFailed     = "cannot update Argocd application"
 	errDeleteFailed     = "cannot delete Argocd application"
+	errInvalidSource    = "invalid source of Argocd application"
+	errIgnoreDiffs      = "invalid ignored differences of Argocd application"
+	errSyncOptions      = "invalid sync options of Argocd application"
+	errSyncFailed       = "cannot sync Argocd application"
+	errSyncResources    = "invalid sync resources annotation"
+	errSyncRequest      = "invalid options of requested sync"
+	errTerminateFailed  = "cannot terminate operation of Argocd application"
+	errResolveRevision  = "cannot resolve target revision of Argocd application"
+	errGetProjectFailed = "cannot get Argocd project of application"
+	errSourceRepos      = "invalid source repository of Argocd application"
+	errOwnedFields      = "invalid owned fields of Argocd application"
+	errPatchFailed      = "cannot patch owned fields of Argocd application"
+
+	errFmtProjectNotFound     = "cannot move Argocd application to project %s which does not exist"
+	errFmtAmbiguousTrackingID = "tracking id %s matches Argocd applications %s and %s, refusing to adopt either"
+	errFmtAdoptName           = "cannot adopt Argocd application %s without name prefix %q and suffix %q"
+
+	// syncCooldown is the minimum time between two syncs requested with the sync annotation
+	syncCooldown = time.Minute
+	// syncPollInterval is the interval at which an application is observed while a sync requested
+	// with the sync annotation is in progress
+	syncPollInterval = 10 * time.Second
+
+	// ReasonSyncing indicates that a sync requested with the sync annotation is in progress
+	ReasonSyncing xpv1.ConditionReason = "Syncing"
+	// ReasonSyncTimedOut indicates that a sync requested with the sync annotation did not complete in time
+	ReasonSyncTimedOut xpv1.ConditionReason = "SyncTimedOut"
 )
 
 // SetupApplication adds a controller that reconciles applications.
@@ -59,10 +93,12 @@ func SetupApplication(mgr ctrl.Manager, o xpcontroller.Options) error {
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: applications.NewApplicationServiceClient}),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: applications.NewApplicationServiceClient, newProjectClientFn: projects.NewProjectServiceClient}),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(pollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithTimeout(5 * time.Minute),
@@ -74,16 +110,17 @@ func SetupApplication(mgr ctrl.Manager, o xpcontroller.Options) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Application{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ApplicationGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
-	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, applications.ServiceClient)
-	conn              io.Closer
+	kube               client.Client
+	newArgocdClientFn  func(clientOpts *apiclient.ClientOptions) (io.Closer, applications.ServiceClient)
+	newProjectClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, projects.ProjectServiceClient)
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -91,23 +128,39 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotApplication)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	// the names are transformed by the ProviderConfig in use, which may be a fallback
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, spec *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		ext := &external{kube: c.kube, client: argocdClient, clock: clock.RealClock{}, names: clients.NameTransformFor(spec)}
+
+		// the project client is only needed to validate source repositories on create and
+		// the project an application is moved to
+		if !applications.IsSourceRepoValidationEnabled(cr) && !applications.IsProjectValidationEnabled(cr) {
+			return ext, conn
+		}
+		projectConn, projectClient := c.newProjectClientFn(cfg)
+		ext.projectClient = projectClient
+		return ext, clients.Closers{conn, projectConn}
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
-
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+	return clients.WithPauseHandling(clients.WithLastErrorRecording(clients.WithPermissionHandling(clients.WithAlreadyExistsHandling(fc), v1alpha1.ApplicationKind))), nil
 }
 
 type external struct {
-	kube   client.Client
-	client applications.ServiceClient
+	kube          client.Client
+	client        applications.ServiceClient
+	projectClient projects.ProjectServiceClient
+	clock         clock.PassiveClock
+	names         clients.NameTransform
+	// observedProject is the project of the application when it was last observed
+	observedProject string
+	// observed is the application when it was last observed, the owned fields are patched from it
+	observed *argocdv1alpha1.Application
+	// specUpToDate is whether the observed application matches the spec, so that a resource which is
+	// only outdated by a requested sync is synced without updating the application
+	specUpToDate bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -116,54 +169,157 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotApplication)
 	}
 
-	var name = meta.GetExternalName(cr)
-
-	if name == "" {
+	if meta.GetExternalName(cr) == "" {
 		return managed.ExternalObservation{}, nil
 	}
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
-	appQuery := application.ApplicationQuery{
-		Name: &name,
+	strategy, err := applications.MatchStrategy(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
-	// we have to use List() because Get() returns permission error
-	var apps *argocdv1alpha1.ApplicationList
-	apps, err := e.client.List(ctx, &appQuery)
+	// the application is found in any project, so that a change of the project is observed as drift
+	apps, err := e.findApplications(ctx, &application.ApplicationQuery{Name: &name}, func(item *argocdv1alpha1.Application) bool {
+		return item.Name == name
+	})
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errListFailed)
+		return managed.ExternalObservation{}, err
 	}
-	app := &argocdv1alpha1.Application{}
-	for _, item := range apps.Items {
-		if item.Name == name && item.Spec.Project == cr.Spec.ForProvider.Project {
-			app = item.DeepCopy()
+	adopted := false
+	if len(apps) == 0 && strategy == applications.MatchStrategyTrackingID {
+		id := cr.GetAnnotations()[applications.AnnotationKeyTrackingID]
+		apps, err = e.findApplications(ctx, &application.ApplicationQuery{Projects: []string{cr.Spec.ForProvider.Project}}, func(item *argocdv1alpha1.Application) bool {
+			return item.Spec.Project == cr.Spec.ForProvider.Project && applications.HasTrackingID(item, id)
+		})
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(apps) > 1 {
+			return managed.ExternalObservation{}, errors.Errorf(errFmtAmbiguousTrackingID, id, apps[0].Name, apps[1].Name)
+		}
+		if len(apps) == 1 {
+			// bind the managed resource to the tracked application instead of creating a duplicate
+			externalName, ok := e.names.ExternalName(apps[0].Name)
+			if !ok {
+				return managed.ExternalObservation{}, errors.Errorf(errFmtAdoptName, apps[0].Name, e.names.Prefix, e.names.Suffix)
+			}
+			meta.SetExternalName(cr, externalName)
+			adopted = true
 		}
 	}
-	if app.Name == "" {
+	if len(apps) == 0 {
 		return managed.ExternalObservation{}, nil
 	}
+	app := apps[len(apps)-1].DeepCopy()
+	e.observedProject = app.Spec.Project
+	e.observed = app
 
 	current := cr.Spec.ForProvider.DeepCopy()
 	lateInitialize(&cr.Spec.ForProvider, app)
 
+	lastSyncRequest, lastSyncRequestTime := cr.Status.AtProvider.LastSyncRequest, cr.Status.AtProvider.LastSyncRequestTime
 	cr.Status.AtProvider = generateApplicationObservation(app)
-	cr.Status.SetConditions(xpv1.Available())
+	cr.Status.AtProvider.LastSyncRequest, cr.Status.AtProvider.LastSyncRequestTime = lastSyncRequest, lastSyncRequestTime
+	observeOutOfSync(&cr.Status.AtProvider, app, e.clock)
+	cr.Status.SetConditions(applicationAvailability(&cr.Spec.ForProvider, app))
+	if err := e.observeRequestedSync(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if c, ok := dryRunSyncCondition(app); ok {
+		cr.Status.SetConditions(c)
+	}
+	if cr.Status.AtProvider.ResolvedRevision, err = e.resolveTargetRevision(ctx, cr, app); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider.ResolvedRevisions = resolvedSourceRevisions(cr, app)
+	stuck, err := e.isOperationStuck(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	values, err := e.resolveHelmValues(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider.HelmValuesHash = hashHelmValues(values)
+	owned, err := applications.OwnedFields(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOwnedFields)
+	}
+	desired := generateUpdateRepositoryOptions(cr, name).Application
+	injectHelmValues(&desired.Spec, values)
+	compared, err := withUnownedFields(app, desired, owned)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOwnedFields)
+	}
+
+	e.specUpToDate = IsApplicationUpToDate(&cr.Spec.ForProvider, withoutHelmValues(compared, values)) && isHelmValuesUpToDate(compared, values)
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        IsApplicationUpToDate(&cr.Spec.ForProvider, app),
-		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ResourceUpToDate:        e.specUpToDate && !e.isSyncRequested(cr) && !stuck,
+		ResourceLateInitialized: adopted || !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
 
+// findApplications lists the applications matching query and returns those accepted by match.
+func (e *external) findApplications(ctx context.Context, query *application.ApplicationQuery, match func(*argocdv1alpha1.Application) bool) ([]argocdv1alpha1.Application, error) {
+	// we have to use List() because Get() returns permission error
+	apps, err := e.client.List(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, errListFailed)
+	}
+	var matches []argocdv1alpha1.Application
+	for i := range apps.Items {
+		if match(&apps.Items[i]) {
+			matches = append(matches, apps.Items[i])
+		}
+	}
+	return matches, nil
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) { // nolint:gocyclo
 	cr, ok := mg.(*v1alpha1.Application)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotApplication)
 	}
+	if err := validateApplicationParameters(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidSource)
+	}
+	if err := validateIgnoreDifferences(cr.Spec.ForProvider.IgnoreDifferences); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errIgnoreDiffs)
+	}
+	if err := validateSyncOptions(cr.Spec.ForProvider.SyncPolicy); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSyncOptions)
+	}
+	if err := e.checkDependencies(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	values, err := e.resolveHelmValues(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	createRequest := generateCreateApplicationRequest(cr)
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	createRequest := generateCreateApplicationRequest(cr, name)
+	injectHelmValues(&createRequest.Application.Spec, values)
+	if applications.IsSourceRepoValidationEnabled(cr) {
+		proj, err := e.projectClient.Get(ctx, &project.ProjectQuery{Name: createRequest.Application.Spec.Project})
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetProjectFailed)
+		}
+		if err := validateSourceRepos(proj, &createRequest.Application.Spec); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errSourceRepos)
+		}
+	}
 
-	_, err := e.client.Create(ctx, createRequest)
+	_, err = e.client.Create(ctx, createRequest)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
@@ -176,25 +332,215 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotApplication)
 	}
-	updateRequest := generateUpdateRepositoryOptions(cr)
-	_, err := e.client.Update(ctx, updateRequest)
+	if err := validateApplicationParameters(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidSource)
+	}
+	if err := validateIgnoreDifferences(cr.Spec.ForProvider.IgnoreDifferences); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errIgnoreDiffs)
+	}
+	if err := validateSyncOptions(cr.Spec.ForProvider.SyncPolicy); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSyncOptions)
+	}
+	owned, err := applications.OwnedFields(cr)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOwnedFields)
 	}
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	stuck, err := e.isOperationStuck(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	values, err := e.resolveHelmValues(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := e.checkProjectMove(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	var syncRequest *application.ApplicationSyncRequest
+	if e.isSyncRequested(cr) {
+		resources, err := applications.ParseSyncResources(cr.GetAnnotations()[applications.AnnotationKeySyncResources])
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSyncResources)
+		}
+		syncRequest = &application.ApplicationSyncRequest{
+			Name:      ptr.To(name),
+			Project:   ptr.To(cr.Spec.ForProvider.Project),
+			Resources: resources,
+		}
+		if err := applications.SetSyncOptions(cr, syncRequest); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSyncRequest)
+		}
+	}
+	// the complete application, or all of its owned fields, is sent with a single request, so that
+	// changes to several fields, e.g. revisionHistoryLimit and syncPolicy, are never applied partially
+	updateRequest := generateUpdateRepositoryOptions(cr, name)
+	if e.observed != nil {
+		m := &updateRequest.Application.ObjectMeta
+		m.Labels = withSystemManagedKeys(m.Labels, e.observed.Labels)
+		m.Annotations = withSystemManagedKeys(m.Annotations, e.observed.Annotations)
+	}
+	injectHelmValues(&updateRequest.Application.Spec, values)
+	if !e.specUpToDate {
+		if err := e.updateApplication(ctx, updateRequest, owned); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	if stuck {
+		// the operation may have completed since it was observed, which is not an error
+		_, err := e.client.TerminateOperation(ctx, &application.OperationTerminateRequest{
+			Name:    ptr.To(name),
+			Project: ptr.To(cr.Spec.ForProvider.Project),
+		})
+		if err != nil && !applications.IsErrorNoOperationInProgress(err) {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTerminateFailed)
+		}
+	}
+	if syncRequest == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	if _, err := e.client.Sync(ctx, syncRequest); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSyncFailed)
+	}
+	t := metav1.NewTime(e.clock.Now())
+	cr.Status.AtProvider.LastSyncRequest = ptr.To(cr.GetAnnotations()[applications.AnnotationKeySync])
+	cr.Status.AtProvider.LastSyncRequestTime = &t
 
 	return managed.ExternalUpdate{}, nil
 }
 
+// updateApplication updates the application of req. If only some fields are owned, the owned fields
+// are changed with a merge patch and the other fields of the application are left untouched.
+func (e *external) updateApplication(ctx context.Context, req *application.ApplicationUpdateRequest, owned []string) error {
+	if len(owned) == 0 {
+		_, err := e.client.Update(ctx, req)
+		return errors.Wrap(err, errUpdateFailed)
+	}
+	patch, err := ownedFieldsPatch(e.observed, req.Application, owned)
+	if err != nil {
+		return errors.Wrap(err, errPatchFailed)
+	}
+	if string(patch) == "{}" {
+		return nil
+	}
+	_, err = e.client.Patch(ctx, &application.ApplicationPatchRequest{
+		Name:      ptr.To(req.Application.Name),
+		Patch:     ptr.To(string(patch)),
+		PatchType: ptr.To("merge"),
+	})
+	return errors.Wrap(err, errPatchFailed)
+}
+
+// checkProjectMove returns an error if the application is moved to a project which does not exist.
+// ArgoCD moves an application between projects on update, the check is only done if enabled with
+// the argocd.crossplane.io/validate-project annotation.
+func (e *external) checkProjectMove(ctx context.Context, cr *v1alpha1.Application) error {
+	target := cr.Spec.ForProvider.Project
+	if !applications.IsProjectValidationEnabled(cr) || e.observedProject == "" || e.observedProject == target {
+		return nil
+	}
+	_, err := e.projectClient.Get(ctx, &project.ProjectQuery{Name: target})
+	if projects.IsErrorProjectNotFound(err) {
+		return errors.Errorf(errFmtProjectNotFound, target)
+	}
+	return errors.Wrap(err, errGetProjectFailed)
+}
+
+// isSyncRequested reports whether the sync annotation of cr holds a value which has not been synced yet
+// and the last requested sync is older than the cooldown.
+func (e *external) isSyncRequested(cr *v1alpha1.Application) bool {
+	v := cr.GetAnnotations()[applications.AnnotationKeySync]
+	switch {
+	case v == "",
+		ptr.Deref(cr.Status.AtProvider.LastSyncRequest, "") == v,
+		cr.Status.AtProvider.LastSyncRequestTime != nil && e.clock.Since(cr.Status.AtProvider.LastSyncRequestTime.Time) < syncCooldown:
+		return false
+	}
+	return true
+}
+
+// resolveTargetRevision returns the commit the symbolic targetRevision of the source of cr
+// resolves to, if resolution is enabled. Helm charts and targetRevisions which already are
+// commits are not resolved.
+func (e *external) resolveTargetRevision(ctx context.Context, cr *v1alpha1.Application, app *argocdv1alpha1.Application) (*string, error) {
+	src := cr.Spec.ForProvider.Source
+	if !applications.IsTargetRevisionResolutionEnabled(cr) || src == nil || ptr.Deref(src.Chart, "") != "" {
+		return nil, nil
+	}
+	revision := ptr.Deref(src.TargetRevision, "")
+	if revision == "" {
+		revision = "HEAD"
+	}
+	if applications.IsCommitSHA(revision) {
+		return nil, nil
+	}
+	resp, err := e.client.GetManifests(ctx, &application.ApplicationManifestQuery{
+		Name:     ptr.To(app.Name),
+		Revision: ptr.To(revision),
+		Project:  ptr.To(app.Spec.Project),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveRevision)
+	}
+	if resp.Revision == "" {
+		return nil, nil
+	}
+	return ptr.To(resp.Revision), nil
+}
+
+// resolvedSourceRevisions returns the revisions the targetRevisions of the sources of cr resolved to
+// when ArgoCD last compared app, if resolution is enabled. ArgoCD reports one revision per source in
+// the order of the sources, revisions reported for a different number of sources are stale and not
+// returned. Helm charts are not resolved.
+func resolvedSourceRevisions(cr *v1alpha1.Application, app *argocdv1alpha1.Application) []string {
+	sources := cr.Spec.ForProvider.Sources
+	revisions := app.Status.Sync.Revisions
+	if !applications.IsTargetRevisionResolutionEnabled(cr) || len(sources) == 0 || len(revisions) != len(sources) {
+		return nil
+	}
+	resolved := make([]string, len(sources))
+	for i, s := range sources {
+		if ptr.Deref(s.Chart, "") == "" {
+			resolved[i] = revisions[i]
+		}
+	}
+	return resolved
+}
+
+// isOperationStuck reports whether the observed operation of cr is still running and has been
+// running for longer than the duration of the terminate annotation.
+func (e *external) isOperationStuck(cr *v1alpha1.Application) (bool, error) {
+	after, err := applications.TerminateOperationAfter(cr)
+	if err != nil || after == 0 {
+		return false, err
+	}
+	op := cr.Status.AtProvider.OperationState
+	if op == nil || op.Phase != v1alpha1.OperationPhase(synccommon.OperationRunning) || op.StartedAt == nil {
+		return false, nil
+	}
+	return e.clock.Since(op.StartedAt.Time) > after, nil
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.Application)
 	if !ok {
 		return errors.New(errNotApplication)
 	}
+	if err := e.checkDependents(ctx, cr); err != nil {
+		return err
+	}
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
 	query := application.ApplicationDeleteRequest{
-		Name: clients.StringToPtr(meta.GetExternalName(cr)),
+		Name: clients.StringToPtr(name),
 	}
 
-	_, err := e.client.Delete(ctx, &query)
+	_, err = e.client.Delete(ctx, &query)
 
 	return errors.Wrap(err, errDeleteFailed)
 }
@@ -206,7 +552,12 @@ func lateInitialize(applicationParameters *v1alpha1.ApplicationParameters, app *
 	if applicationParameters == nil {
 		return
 	}
-	// To be considered in future
+	if applicationParameters.Labels == nil {
+		applicationParameters.Labels = withoutSystemManagedKeys(app.Labels)
+	}
+	if applicationParameters.Annotations == nil {
+		applicationParameters.Annotations = withoutSystemManagedKeys(app.Annotations)
+	}
 }
 
 func generateApplicationObservation(app *argocdv1alpha1.Application) v1alpha1.ArgoApplicationStatus {
@@ -216,10 +567,87 @@ func generateApplicationObservation(app *argocdv1alpha1.Application) v1alpha1.Ar
 
 	converter := &applications.ConverterImpl{}
 	status := converter.FromArgoApplicationStatus(&app.Status)
+	if op := app.Status.OperationState; op != nil {
+		status.OperationPhase = ptr.To(string(op.Phase))
+		if op.Message != "" {
+			status.OperationMessage = ptr.To(op.Message)
+		}
+		switch {
+		case op.SyncResult != nil && op.SyncResult.Revision != "":
+			status.SyncRevision = ptr.To(op.SyncResult.Revision)
+		case op.Operation.Sync != nil && op.Operation.Sync.Revision != "":
+			status.SyncRevision = ptr.To(op.Operation.Sync.Revision)
+		}
+	}
+	status.SyncWaves, status.CurrentSyncWave = summarizeSyncWaves(app)
+	status.Notifications = summarizeNotifications(app)
+	if len(app.Status.Resources) > 0 || app.Status.ReconciledAt != nil {
+		status.ResourceCount = ptr.To(int32(len(app.Status.Resources)))
+	}
 	return *status
 }
 
-func generateCreateApplicationRequest(cr *v1alpha1.Application) *application.ApplicationCreateRequest {
+// applicationAvailability returns the Ready condition of an application. With
+// automated sync the application is only available once its last operation
+// succeeded, so that running and failed syncs are reflected by the managed resource.
+func applicationAvailability(p *v1alpha1.ApplicationParameters, app *argocdv1alpha1.Application) xpv1.Condition {
+	if p.SyncPolicy == nil || p.SyncPolicy.Automated == nil {
+		return xpv1.Available()
+	}
+	op := app.Status.OperationState
+	if op == nil || op.Phase == synccommon.OperationSucceeded {
+		return xpv1.Available()
+	}
+	msg := "operation " + string(op.Phase)
+	if op.Message != "" {
+		msg += ": " + op.Message
+	}
+	return xpv1.Unavailable().WithMessage(msg)
+}
+
+// observeRequestedSync reports the application as unavailable while the sync last requested with the
+// sync annotation has not completed, and as failed once it did not complete within the sync timeout.
+// The availability observed by applicationAvailability is kept once the sync completed.
+func (e *external) observeRequestedSync(cr *v1alpha1.Application) error {
+	requested := cr.Status.AtProvider.LastSyncRequestTime
+	if requested == nil {
+		return nil
+	}
+	timeout, err := applications.SyncTimeout(cr)
+	if err != nil {
+		return err
+	}
+	op := cr.Status.AtProvider.OperationState
+	// an operation started before the request belongs to an earlier sync
+	if op != nil && op.StartedAt != nil && !op.StartedAt.Before(requested) && synccommon.OperationPhase(op.Phase).Completed() {
+		return nil
+	}
+	c := xpv1.Unavailable()
+	switch {
+	case e.clock.Since(requested.Time) > timeout:
+		c.Reason, c.Message = ReasonSyncTimedOut, "requested sync did not complete within "+timeout.String()
+	case op != nil && op.Phase == v1alpha1.OperationPhase(synccommon.OperationRunning):
+		c.Reason, c.Message = ReasonSyncing, "operation "+string(op.Phase)
+		if m := ptr.Deref(op.Message, ""); m != "" {
+			c.Message += ": " + m
+		}
+	default:
+		c.Reason, c.Message = ReasonSyncing, "requested sync is pending"
+	}
+	cr.Status.SetConditions(c)
+	return nil
+}
+
+// pollInterval observes an application at syncPollInterval while a sync requested with the sync
+// annotation is in progress, so that its completion is reported without waiting for the next poll.
+func pollInterval(mg resource.Managed, d time.Duration) time.Duration {
+	if mg.GetCondition(xpv1.TypeReady).Reason == ReasonSyncing && syncPollInterval < d {
+		return syncPollInterval
+	}
+	return d
+}
+
+func generateCreateApplicationRequest(cr *v1alpha1.Application, name string) *application.ApplicationCreateRequest {
 	converter := &applications.ConverterImpl{}
 
 	spec := converter.ToArgoApplicationSpec(&cr.Spec.ForProvider)
@@ -227,7 +655,8 @@ func generateCreateApplicationRequest(cr *v1alpha1.Application) *application.App
 	app := &argocdv1alpha1.Application{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        meta.GetExternalName(cr),
+			Name:        name,
+			Labels:      cr.Spec.ForProvider.Labels,
 			Annotations: cr.Spec.ForProvider.Annotations,
 			Finalizers:  cr.Spec.ForProvider.Finalizers,
 		},
@@ -241,7 +670,7 @@ func generateCreateApplicationRequest(cr *v1alpha1.Application) *application.App
 	return repoCreateRequest
 }
 
-func generateUpdateRepositoryOptions(cr *v1alpha1.Application) *application.ApplicationUpdateRequest {
+func generateUpdateRepositoryOptions(cr *v1alpha1.Application, name string) *application.ApplicationUpdateRequest {
 	converter := applications.ConverterImpl{}
 
 	spec := converter.ToArgoApplicationSpec(&cr.Spec.ForProvider)
@@ -249,7 +678,8 @@ func generateUpdateRepositoryOptions(cr *v1alpha1.Application) *application.Appl
 	app := &argocdv1alpha1.Application{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        meta.GetExternalName(cr),
+			Name:        name,
+			Labels:      cr.Spec.ForProvider.Labels,
 			Annotations: cr.Spec.ForProvider.Annotations,
 			Finalizers:  cr.Spec.ForProvider.Finalizers,
 		},
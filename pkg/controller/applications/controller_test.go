@@ -18,14 +18,34 @@ package applications
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	argocdApplication "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	argocdProject "github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v2/reposerver/apiclient"
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gobwas/glob"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -33,27 +53,38 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/applications"
+	mockprojects "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/projects"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 )
 
 var (
-	errBoom                     = errors.New("boom")
-	testApplicationExternalName = "testapplication"
-	testProjectName             = "default"
-	testDestinationNamespace    = "default-at-destination"
-	emptyString                 = ""
-	repoURL                     = "https://github.com/stefanprodan/podinfo/"
-	chartPath                   = "charts/podinfo"
-	revision                    = "HEAD"
-	selfHealEnabled             = true
-	testApplicationAnnotations  = map[string]string{"annotation1": "value1", "annotation2": "value2"}
-	testApplicationFinalizers   = []string{"resources-finalizer.argocd.argoproj.io"}
+	errBoom                      = errors.New("boom")
+	testApplicationExternalName  = "testapplication"
+	testProjectName              = "default"
+	testDestinationNamespace     = "default-at-destination"
+	emptyString                  = ""
+	repoURL                      = "https://github.com/stefanprodan/podinfo/"
+	chartPath                    = "charts/podinfo"
+	revision                     = "HEAD"
+	selfHealEnabled              = true
+	testApplicationAnnotations   = map[string]string{"annotation1": "value1", "annotation2": "value2"}
+	testApplicationFinalizers    = []string{"resources-finalizer.argocd.argoproj.io"}
+	testNotificationSubscription = "notifications.argoproj.io/subscribe.on-sync-succeeded.slack"
+	testInvalidGlob              = "manifests/[prod"
+	testPluginName               = "my-plugin"
+	testPluginReplicas           = "2"
+	testNow                      = time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC)
+	testSyncResources            = "apps/Deployment/web,/Service/default/web"
 )
 
 type args struct {
-	client applications.ServiceClient
-	cr     *v1alpha1.Application
+	kube          client.Client
+	client        applications.ServiceClient
+	projectClient projects.ProjectServiceClient
+	cr            *v1alpha1.Application
 }
 
 type mockModifier func(*mockclient.MockServiceClient)
@@ -65,6 +96,13 @@ func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockServiceClien
 	return mock
 }
 
+func withMockProjectClient(t *testing.T, mod func(*mockprojects.MockProjectServiceClient)) *mockprojects.MockProjectServiceClient {
+	ctrl := gomock.NewController(t)
+	mock := mockprojects.NewMockProjectServiceClient(ctrl)
+	mod(mock)
+	return mock
+}
+
 func Application(m ...ApplicationModifier) *v1alpha1.Application {
 	cr := &v1alpha1.Application{}
 	for _, f := range m {
@@ -75,6 +113,14 @@ func Application(m ...ApplicationModifier) *v1alpha1.Application {
 
 type ApplicationModifier func(*v1alpha1.Application)
 
+func withDependsOn(names ...string) ApplicationModifier {
+	return func(r *v1alpha1.Application) {
+		for _, n := range names {
+			r.Spec.DependsOn = append(r.Spec.DependsOn, xpv1.Reference{Name: n})
+		}
+	}
+}
+
 func withExternalName(v string) ApplicationModifier {
 	return func(s *v1alpha1.Application) {
 		meta.SetExternalName(s, v)
@@ -95,10 +141,26 @@ func withObservation(p v1alpha1.ArgoApplicationStatus) ApplicationModifier {
 	return func(r *v1alpha1.Application) { r.Status.AtProvider = p }
 }
 
+func withAnnotations(a map[string]string) ApplicationModifier {
+	return func(r *v1alpha1.Application) { meta.AddAnnotations(r, a) }
+}
+
+func withLastSyncRequest(v string, t time.Time) ApplicationModifier {
+	return func(r *v1alpha1.Application) {
+		r.Status.AtProvider.LastSyncRequest = &v
+		r.Status.AtProvider.LastSyncRequestTime = &metav1.Time{Time: t}
+	}
+}
+
 func withConditions(c ...xpv1.Condition) ApplicationModifier {
 	return func(r *v1alpha1.Application) { r.Status.ConditionedStatus.Conditions = c }
 }
 
+func globCompileError(pattern string) error {
+	_, err := glob.Compile(pattern)
+	return err
+}
+
 func TestObserve(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.Application
@@ -291,7 +353,7 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"ListApplicationFailed": {
+		"SystemManagedKeysIgnored": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
 					mcs.EXPECT().List(
@@ -300,20 +362,44 @@ func TestObserve(t *testing.T) {
 							Name: &testApplicationExternalName,
 						},
 					).Return(
-						nil, errBoom)
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        testApplicationExternalName,
+									Labels:      map[string]string{"app.kubernetes.io/instance": "apps"},
+									Annotations: map[string]string{"annotation1": "value1", "argocd.argoproj.io/tracking-id": "apps:argoproj.io/Application:argocd/test"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
 				}),
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1"},
+					}),
 				),
 			},
 			want: want{
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
 				),
-				err: errors.Wrap(errBoom, errListFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
 			},
 		},
-		"NeedsCreation": {
+		"NotificationSubscriptionAdded": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
 					mcs.EXPECT().List(
@@ -323,159 +409,1895 @@ func TestObserve(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.ApplicationList{
-							TypeMeta: metav1.TypeMeta{},
-							ListMeta: metav1.ListMeta{},
-							Items:    []argocdv1alpha1.Application{},
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        testApplicationExternalName,
+									Annotations: map[string]string{"annotation1": "value1"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
 						}, nil)
 				}),
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1", testNotificationSubscription: "my-channel"},
+					}),
 				),
 			},
 			want: want{
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1", testNotificationSubscription: "my-channel"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
 				),
 				result: managed.ExternalObservation{
-					ResourceExists: false,
+					ResourceExists:   true,
+					ResourceUpToDate: false,
 				},
-				err: nil,
 			},
 		},
-		"NoExternalName -> NeedsCreation": {
+		"LateInitializeLabelsAndAnnotations": {
 			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        testApplicationExternalName,
+									Labels:      map[string]string{"app.kubernetes.io/instance": "apps", "team": "platform"},
+									Annotations: map[string]string{"annotation1": "value1", "argocd.argoproj.io/tracking-id": "apps:argoproj.io/Application:argocd/test"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
 				cr: Application(
-					withName(testApplicationExternalName),
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
 				),
 			},
 			want: want{
 				cr: Application(
-					withName(testApplicationExternalName),
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Labels:      map[string]string{"team": "platform"},
+						Annotations: map[string]string{"annotation1": "value1"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
 				),
 				result: managed.ExternalObservation{
-					ResourceExists: false,
-				},
-				err: nil,
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			o, err := e.Observe(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func initializedArgoAppStatus() v1alpha1.ArgoApplicationStatus {
-	return v1alpha1.ArgoApplicationStatus{
-		Resources: nil,
-		Sync: v1alpha1.SyncStatus{
-			Revision: &emptyString,
-			ComparedTo: v1alpha1.ComparedTo{
-				Source: v1alpha1.ApplicationSource{
-					Path:           &emptyString,
-					TargetRevision: &emptyString,
-					Chart:          &emptyString,
-					Ref:            &emptyString,
-				},
-				Destination: v1alpha1.ApplicationDestination{
-					Server:    &emptyString,
-					Namespace: &emptyString,
-					Name:      &emptyString,
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
 				},
 			},
 		},
-		Health: v1alpha1.HealthStatus{
-			Message: &emptyString,
-		},
-		SourceType:           "",
-		Summary:              v1alpha1.ApplicationSummary{},
-		ResourceHealthSource: "",
-	}
-}
-
-func TestCreate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.Application
-		result managed.ExternalCreation
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"DirectoryRecurseToggled": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().List(
 						context.Background(),
-						&argocdApplication.ApplicationCreateRequest{
-							Application: &argocdv1alpha1.Application{
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
 								ObjectMeta: metav1.ObjectMeta{
 									Name: testApplicationExternalName,
 								},
-							},
-						},
-					).Return(
-						&argocdv1alpha1.Application{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: testApplicationExternalName,
-							},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Directory: &argocdv1alpha1.ApplicationSourceDirectory{
+											Recurse: false,
+										},
+									},
+								},
+							}},
 						}, nil)
 				}),
-				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Recurse: &selfHealEnabled,
+							},
+						},
+					}),
+				),
 			},
 			want: want{
 				cr: Application(
 					withExternalName(testApplicationExternalName),
-					withName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Recurse: &selfHealEnabled,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
 				),
-				result: managed.ExternalCreation{},
-				err:    nil,
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
 			},
 		},
-		"CreateSystemFailed": {
+		"JsonnetExtVarAdded": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().List(
 						context.Background(),
-						&argocdApplication.ApplicationCreateRequest{
-							Application: &argocdv1alpha1.Application{
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
 								ObjectMeta: metav1.ObjectMeta{
 									Name: testApplicationExternalName,
 								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Directory: &argocdv1alpha1.ApplicationSourceDirectory{
+											Jsonnet: argocdv1alpha1.ApplicationSourceJsonnet{
+												ExtVars: []argocdv1alpha1.JsonnetVar{{Name: "env", Value: "prod"}},
+											},
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Jsonnet: v1alpha1.ApplicationSourceJsonnet{
+									ExtVars: []v1alpha1.JsonnetVar{{Name: "env", Value: "prod"}, {Name: "region", Value: "eu"}},
+								},
 							},
 						},
-					).Return(
-						nil, errBoom)
-				}),
-				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+					}),
+				),
 			},
 			want: want{
-				cr:     Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
-				result: managed.ExternalCreation{},
-				err:    errors.Wrap(errBoom, errCreateFailed),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Jsonnet: v1alpha1.ApplicationSourceJsonnet{
+									ExtVars: []v1alpha1.JsonnetVar{{Name: "env", Value: "prod"}, {Name: "region", Value: "eu"}},
+								},
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
+		"JsonnetExtVarsReordered": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Directory: &argocdv1alpha1.ApplicationSourceDirectory{
+											Jsonnet: argocdv1alpha1.ApplicationSourceJsonnet{
+												ExtVars: []argocdv1alpha1.JsonnetVar{{Name: "env", Value: "prod"}, {Name: "region", Value: "eu"}},
+											},
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Jsonnet: v1alpha1.ApplicationSourceJsonnet{
+									ExtVars: []v1alpha1.JsonnetVar{{Name: "region", Value: "eu"}, {Name: "env", Value: "prod"}},
+								},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Jsonnet: v1alpha1.ApplicationSourceJsonnet{
+									ExtVars: []v1alpha1.JsonnetVar{{Name: "region", Value: "eu"}, {Name: "env", Value: "prod"}},
+								},
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"PluginParameterChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Plugin: &argocdv1alpha1.ApplicationSourcePlugin{
+											Name: testPluginName,
+											Env: argocdv1alpha1.Env{
+												{Name: "FOO", Value: "foo"},
+												{Name: "BAR", Value: "bar"},
+											},
+											Parameters: argocdv1alpha1.ApplicationSourcePluginParameters{
+												{Name: "replicas", String_: &testPluginReplicas},
+												{Name: "regions", OptionalArray: &argocdv1alpha1.OptionalArray{Array: []string{"eu"}}},
+											},
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Env: v1alpha1.Env{
+									{Name: "BAR", Value: "bar"},
+									{Name: "FOO", Value: "foo"},
+								},
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("regions"), OptionalArray: &v1alpha1.OptionalArray{Array: []string{"eu"}}},
+									{Name: ptr.To("replicas"), String_: ptr.To("3")},
+								},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Env: v1alpha1.Env{
+									{Name: "BAR", Value: "bar"},
+									{Name: "FOO", Value: "foo"},
+								},
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("regions"), OptionalArray: &v1alpha1.OptionalArray{Array: []string{"eu"}}},
+									{Name: ptr.To("replicas"), String_: ptr.To("3")},
+								},
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"PluginEnvAndParametersReordered": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Plugin: &argocdv1alpha1.ApplicationSourcePlugin{
+											Name: testPluginName,
+											Env: argocdv1alpha1.Env{
+												{Name: "FOO", Value: "foo"},
+												{Name: "BAR", Value: "bar"},
+											},
+											Parameters: argocdv1alpha1.ApplicationSourcePluginParameters{
+												{Name: "replicas", String_: &testPluginReplicas},
+												{Name: "regions", OptionalArray: &argocdv1alpha1.OptionalArray{Array: []string{"eu"}}},
+											},
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Env: v1alpha1.Env{
+									{Name: "BAR", Value: "bar"},
+									{Name: "FOO", Value: "foo"},
+								},
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("regions"), OptionalArray: &v1alpha1.OptionalArray{Array: []string{"eu"}}},
+									{Name: ptr.To("replicas"), String_: &testPluginReplicas},
+								},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Env: v1alpha1.Env{
+									{Name: "BAR", Value: "bar"},
+									{Name: "FOO", Value: "foo"},
+								},
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("regions"), OptionalArray: &v1alpha1.OptionalArray{Array: []string{"eu"}}},
+									{Name: ptr.To("replicas"), String_: &testPluginReplicas},
+								},
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"DestinationServerVariantUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Destination: argocdv1alpha1.ApplicationDestination{
+										Server: "https://kubernetes.default.svc",
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Server: ptr.To("HTTPS://Kubernetes.Default.svc:443/"),
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Server: ptr.To("HTTPS://Kubernetes.Default.svc:443/"),
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"DestinationServerChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Destination: argocdv1alpha1.ApplicationDestination{
+										Server: "https://kubernetes.default.svc",
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Server: ptr.To("https://kubernetes.default.svc:6443"),
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Server: ptr.To("https://kubernetes.default.svc:6443"),
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ListApplicationFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+				err: errors.Wrap(errBoom, errListFailed),
+			},
+		},
+		"NeedsCreation": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							TypeMeta: metav1.TypeMeta{},
+							ListMeta: metav1.ListMeta{},
+							Items:    []argocdv1alpha1.Application{},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+				err: nil,
+			},
+		},
+		"NoExternalName -> NeedsCreation": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withName(testApplicationExternalName),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
 			e := &external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func initializedArgoAppStatus() v1alpha1.ArgoApplicationStatus {
+	return v1alpha1.ArgoApplicationStatus{
+		Resources: nil,
+		Sync: v1alpha1.SyncStatus{
+			Revision: &emptyString,
+			ComparedTo: v1alpha1.ComparedTo{
+				Source: v1alpha1.ApplicationSource{
+					Path:           &emptyString,
+					TargetRevision: &emptyString,
+					Chart:          &emptyString,
+					Ref:            &emptyString,
+				},
+				Destination: v1alpha1.ApplicationDestination{
+					Server:    &emptyString,
+					Namespace: &emptyString,
+					Name:      &emptyString,
+				},
+			},
+		},
+		Health: v1alpha1.HealthStatus{
+			Message: &emptyString,
+		},
+		SourceType:           "",
+		Summary:              v1alpha1.ApplicationSummary{},
+		ResourceHealthSource: "",
+	}
+}
+
+func TestObserveMatchStrategy(t *testing.T) {
+	testTrackingID := "apps:argoproj.io/Application:argocd/legacy-app"
+	byName := &argocdApplication.ApplicationQuery{Name: &testApplicationExternalName}
+	byProject := &argocdApplication.ApplicationQuery{Projects: []string{testProjectName}}
+	trackedApp := func(name string, annotations, labels map[string]string) argocdv1alpha1.Application {
+		return argocdv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations, Labels: labels},
+			Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+		}
+	}
+
+	type want struct {
+		externalName string
+		exists       bool
+		adopted      bool
+		err          error
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		client      mockModifier
+		want        want
+	}{
+		"NameIgnoresTrackingID": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{}, nil)
+			},
+			want: want{externalName: testApplicationExternalName},
+		},
+		"NameMatch": {
+			annotations: map[string]string{applications.AnnotationKeyMatchStrategy: applications.MatchStrategyName},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{trackedApp(testApplicationExternalName, nil, nil)},
+				}, nil)
+			},
+			want: want{externalName: testApplicationExternalName, exists: true},
+		},
+		"TrackingIDAnnotation": {
+			annotations: map[string]string{
+				applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+				applications.AnnotationKeyTrackingID:    testTrackingID,
+			},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), byProject).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{
+						trackedApp("other-app", map[string]string{"argocd.argoproj.io/tracking-id": "apps:argoproj.io/Application:argocd/other-app"}, nil),
+						trackedApp("legacy-app", map[string]string{"argocd.argoproj.io/tracking-id": testTrackingID}, nil),
+					},
+				}, nil)
+			},
+			want: want{externalName: "legacy-app", exists: true, adopted: true},
+		},
+		"TrackingIDLabel": {
+			annotations: map[string]string{
+				applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+				applications.AnnotationKeyTrackingID:    "legacy-app",
+			},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), byProject).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{
+						trackedApp("legacy-app", nil, map[string]string{"app.kubernetes.io/instance": "legacy-app"}),
+					},
+				}, nil)
+			},
+			want: want{externalName: "legacy-app", exists: true, adopted: true},
+		},
+		"TrackingIDPrefersName": {
+			annotations: map[string]string{
+				applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+				applications.AnnotationKeyTrackingID:    testTrackingID,
+			},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{trackedApp(testApplicationExternalName, nil, nil)},
+				}, nil)
+			},
+			want: want{externalName: testApplicationExternalName, exists: true},
+		},
+		"TrackingIDNotFound": {
+			annotations: map[string]string{
+				applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+				applications.AnnotationKeyTrackingID:    testTrackingID,
+			},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), byProject).Return(&argocdv1alpha1.ApplicationList{}, nil)
+			},
+			want: want{externalName: testApplicationExternalName},
+		},
+		"TrackingIDAmbiguous": {
+			annotations: map[string]string{
+				applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+				applications.AnnotationKeyTrackingID:    "legacy-app",
+			},
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), byName).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), byProject).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{
+						trackedApp("legacy-app", nil, map[string]string{"app.kubernetes.io/instance": "legacy-app"}),
+						trackedApp("legacy-app-copy", nil, map[string]string{"app.kubernetes.io/instance": "legacy-app"}),
+					},
+				}, nil)
+			},
+			want: want{
+				externalName: testApplicationExternalName,
+				err:          errors.Errorf(errFmtAmbiguousTrackingID, "legacy-app", "legacy-app", "legacy-app-copy"),
+			},
+		},
+		"TrackingIDMissing": {
+			annotations: map[string]string{applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID},
+			client:      func(mcs *mockclient.MockServiceClient) {},
+			want: want{
+				externalName: testApplicationExternalName,
+				err:          errors.Errorf("annotation %s is required to match applications by tracking id", applications.AnnotationKeyTrackingID),
+			},
+		},
+		"InvalidStrategy": {
+			annotations: map[string]string{applications.AnnotationKeyMatchStrategy: "label"},
+			client:      func(mcs *mockclient.MockServiceClient) {},
+			want: want{
+				externalName: testApplicationExternalName,
+				err:          errors.Errorf("invalid value %q of annotation %s, expected name or tracking-id", "label", applications.AnnotationKeyMatchStrategy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Application(
+				withExternalName(testApplicationExternalName),
+				withAnnotations(tc.annotations),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+			)
+			e := &external{client: withMockClient(t, tc.client), clock: clocktesting.NewFakePassiveClock(testNow)}
+			o, err := e.Observe(context.Background(), cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			got := want{externalName: meta.GetExternalName(cr), exists: o.ResourceExists, adopted: o.ResourceLateInitialized, err: tc.want.err}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNameTransform(t *testing.T) {
+	names := clients.NameTransform{Prefix: "team-a-", Suffix: "-prod"}
+	prefixedName := "team-a-" + testApplicationExternalName + "-prod"
+	app := func(name string, annotations map[string]string) argocdv1alpha1.Application {
+		return argocdv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+			Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+		}
+	}
+	trackedBy := map[string]string{
+		applications.AnnotationKeyMatchStrategy: applications.MatchStrategyTrackingID,
+		applications.AnnotationKeyTrackingID:    "legacy",
+	}
+	_, errInvalidName := names.Name("My_App")
+
+	type want struct {
+		externalName string
+		exists       bool
+		err          error
+	}
+
+	cases := map[string]struct {
+		externalName string
+		annotations  map[string]string
+		client       mockModifier
+		want         want
+	}{
+		"GetPrefixedName": {
+			externalName: testApplicationExternalName,
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Name: &prefixedName}).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{
+						app(testApplicationExternalName, nil),
+						app(prefixedName, nil),
+					},
+				}, nil)
+			},
+			want: want{externalName: testApplicationExternalName, exists: true},
+		},
+		"AdoptStripsPrefix": {
+			externalName: testApplicationExternalName,
+			annotations:  trackedBy,
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Name: &prefixedName}).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Projects: []string{testProjectName}}).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{app("team-a-legacy-prod", map[string]string{"argocd.argoproj.io/tracking-id": "legacy"})},
+				}, nil)
+			},
+			want: want{externalName: "legacy", exists: true},
+		},
+		"AdoptWithoutPrefix": {
+			externalName: testApplicationExternalName,
+			annotations:  trackedBy,
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Name: &prefixedName}).Return(&argocdv1alpha1.ApplicationList{}, nil)
+				mcs.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Projects: []string{testProjectName}}).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{app("legacy", map[string]string{"argocd.argoproj.io/tracking-id": "legacy"})},
+				}, nil)
+			},
+			want: want{
+				externalName: testApplicationExternalName,
+				err:          errors.Errorf(errFmtAdoptName, "legacy", "team-a-", "-prod"),
+			},
+		},
+		"InvalidName": {
+			externalName: "My_App",
+			client:       func(mcs *mockclient.MockServiceClient) {},
+			want: want{
+				externalName: "My_App",
+				err:          errInvalidName,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Application(
+				withExternalName(tc.externalName),
+				withAnnotations(tc.annotations),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+			)
+			e := &external{client: withMockClient(t, tc.client), clock: clocktesting.NewFakePassiveClock(testNow), names: names}
+			o, err := e.Observe(context.Background(), cr)
+
+			got := want{externalName: meta.GetExternalName(cr), exists: o.ResourceExists, err: err}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("CreatePrefixedName", func(t *testing.T) {
+		cr := Application(withExternalName(testApplicationExternalName))
+		client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+			mcs.EXPECT().Create(context.Background(), &argocdApplication.ApplicationCreateRequest{
+				Application: &argocdv1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: prefixedName}},
+			}).Return(&argocdv1alpha1.Application{}, nil)
+		})
+		e := &external{client: client, names: names}
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Errorf("Create(...): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestIsApplicationUpToDateManagedNamespaceMetadata(t *testing.T) {
+	createNamespace := argocdv1alpha1.SyncOptions{"CreateNamespace=true"}
+
+	cases := map[string]struct {
+		metadata *v1alpha1.ManagedNamespaceMetadata
+		remote   *argocdv1alpha1.ManagedNamespaceMetadata
+		want     bool
+	}{
+		"Equal": {
+			metadata: &v1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{"team": "a"}, Annotations: map[string]string{"owner": "team-a"}},
+			remote:   &argocdv1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{"team": "a"}, Annotations: map[string]string{"owner": "team-a"}},
+			want:     true,
+		},
+		"LabelAdded": {
+			metadata: &v1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{"team": "a", "env": "prod"}},
+			remote:   &argocdv1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{"team": "a"}},
+			want:     false,
+		},
+		"EmptyMaps": {
+			metadata: &v1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{}, Annotations: map[string]string{}},
+			remote:   &argocdv1alpha1.ManagedNamespaceMetadata{},
+			want:     true,
+		},
+		"Cleared": {
+			remote: &argocdv1alpha1.ManagedNamespaceMetadata{Labels: map[string]string{"team": "a"}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				SyncPolicy: &v1alpha1.SyncPolicy{
+					SyncOptions:              v1alpha1.SyncOptions(createNamespace),
+					ManagedNamespaceMetadata: tc.metadata,
+				},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				SyncPolicy: &argocdv1alpha1.SyncPolicy{
+					SyncOptions:              createNamespace,
+					ManagedNamespaceMetadata: tc.remote,
+				},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateTargetRevision(t *testing.T) {
+	sha := "4f1c3d8a9b2e7f6051a3c9d8e7b6a5f4e3d2c1b0"
+
+	cases := map[string]struct {
+		revision string
+		remote   string
+		want     bool
+	}{
+		"SameSymbolicRef":  {revision: "HEAD", remote: "HEAD", want: true},
+		"SameCommit":       {revision: sha, remote: sha, want: true},
+		"HeadPinnedToSHA":  {revision: sha, remote: "HEAD", want: false},
+		"SHAUnpinned":      {revision: "HEAD", remote: sha, want: false},
+		"TagChanged":       {revision: "v1.1.0", remote: "v1.0.0", want: false},
+		"BranchToTag":      {revision: "v1.0.0", remote: "main", want: false},
+		"RevisionRemoved":  {remote: "main", want: false},
+		"RevisionNotGiven": {want: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				Source:  &v1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps"},
+			}
+			if tc.revision != "" {
+				p.Source.TargetRevision = ptr.To(tc.revision)
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				Source:  &argocdv1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: tc.remote},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateSourcesTargetRevision(t *testing.T) {
+	revision := func(r string) *string {
+		if r == "" {
+			return nil
+		}
+		return ptr.To(r)
+	}
+
+	cases := map[string]struct {
+		first  string
+		second string
+		want   bool
+	}{
+		"SameRevisions":        {first: "main", second: "v2.8.0", want: true},
+		"FirstSourceChanged":   {first: "develop", second: "v2.8.0", want: false},
+		"SecondSourceChanged":  {first: "main", second: "v2.9.0", want: false},
+		"RevisionsSwapped":     {first: "v2.8.0", second: "main", want: false},
+		"SecondSourceUnpinned": {first: "main", want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				Sources: v1alpha1.ApplicationSources{
+					{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: revision(tc.first)},
+					{RepoURL: "https://github.com/argoproj/argo-cd", TargetRevision: revision(tc.second)},
+				},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				Sources: argocdv1alpha1.ApplicationSources{
+					{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: "main"},
+					{RepoURL: "https://github.com/argoproj/argo-cd", TargetRevision: "v2.8.0"},
+				},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateHelmValuesObject(t *testing.T) {
+	cases := map[string]struct {
+		values string
+		remote string
+		want   bool
+	}{
+		"Equal":            {values: `{"replicaCount":2,"image":{"tag":"v1"}}`, remote: `{"replicaCount":2,"image":{"tag":"v1"}}`, want: true},
+		"KeysReordered":    {values: `{"replicaCount":2,"image":{"tag":"v1"}}`, remote: `{"image":{"tag":"v1"},"replicaCount":2}`, want: true},
+		"Reformatted":      {values: `{"replicaCount": 2, "image": {"tag": "v1"}}`, remote: "{\n  \"image\": {\n    \"tag\": \"v1\"\n  },\n  \"replicaCount\": 2\n}", want: true},
+		"YAML":             {values: `{"replicaCount":2,"image":{"tag":"v1"}}`, remote: "image:\n  tag: v1\nreplicaCount: 2\n", want: true},
+		"EmptyObject":      {values: `{}`, want: true},
+		"ValueChanged":     {values: `{"replicaCount":3,"image":{"tag":"v1"}}`, remote: `{"replicaCount":2,"image":{"tag":"v1"}}`, want: false},
+		"NestedKeyRemoved": {values: `{"replicaCount":2,"image":{}}`, remote: `{"replicaCount":2,"image":{"tag":"v1"}}`, want: false},
+		"ValuesAdded":      {values: `{"replicaCount":2}`, want: false},
+		"ValuesRemoved":    {remote: `{"replicaCount":2}`, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				Source: &v1alpha1.ApplicationSource{
+					RepoURL: "https://charts.example.com",
+					Chart:   ptr.To("guestbook"),
+					Helm:    &v1alpha1.ApplicationSourceHelm{ValuesObject: extv1.JSON{Raw: []byte(tc.values)}},
+				},
+			}
+			helm := &argocdv1alpha1.ApplicationSourceHelm{}
+			if tc.remote != "" {
+				helm.ValuesObject = &runtime.RawExtension{Raw: []byte(tc.remote)}
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				Source: &argocdv1alpha1.ApplicationSource{
+					RepoURL: "https://charts.example.com",
+					Chart:   "guestbook",
+					Helm:    helm,
+				},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateHelmFlags(t *testing.T) {
+	type flags struct {
+		skipCrds, passCredentials, ignoreMissingValueFiles *bool
+	}
+
+	cases := map[string]struct {
+		flags  flags
+		remote argocdv1alpha1.ApplicationSourceHelm
+		want   bool
+	}{
+		"Unset":                           {want: true},
+		"ExplicitlyFalse":                 {flags: flags{skipCrds: ptr.To(false), passCredentials: ptr.To(false), ignoreMissingValueFiles: ptr.To(false)}, want: true},
+		"SkipCrdsEnabled":                 {flags: flags{skipCrds: ptr.To(true)}, want: false},
+		"SkipCrdsApplied":                 {flags: flags{skipCrds: ptr.To(true)}, remote: argocdv1alpha1.ApplicationSourceHelm{SkipCrds: true}, want: true},
+		"SkipCrdsDisabled":                {flags: flags{skipCrds: ptr.To(false)}, remote: argocdv1alpha1.ApplicationSourceHelm{SkipCrds: true}, want: false},
+		"PassCredentialsEnabled":          {flags: flags{passCredentials: ptr.To(true)}, want: false},
+		"PassCredentialsApplied":          {flags: flags{passCredentials: ptr.To(true)}, remote: argocdv1alpha1.ApplicationSourceHelm{PassCredentials: true}, want: true},
+		"PassCredentialsUnset":            {remote: argocdv1alpha1.ApplicationSourceHelm{PassCredentials: true}, want: false},
+		"IgnoreMissingValueFilesEnabled":  {flags: flags{ignoreMissingValueFiles: ptr.To(true)}, want: false},
+		"IgnoreMissingValueFilesApplied":  {flags: flags{ignoreMissingValueFiles: ptr.To(true)}, remote: argocdv1alpha1.ApplicationSourceHelm{IgnoreMissingValueFiles: true}, want: true},
+		"IgnoreMissingValueFilesDisabled": {flags: flags{ignoreMissingValueFiles: ptr.To(false)}, remote: argocdv1alpha1.ApplicationSourceHelm{IgnoreMissingValueFiles: true}, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				Source: &v1alpha1.ApplicationSource{
+					RepoURL: "https://charts.example.com",
+					Chart:   ptr.To("guestbook"),
+					Helm: &v1alpha1.ApplicationSourceHelm{
+						SkipCrds:                tc.flags.skipCrds,
+						PassCredentials:         tc.flags.passCredentials,
+						IgnoreMissingValueFiles: tc.flags.ignoreMissingValueFiles,
+					},
+				},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				Source: &argocdv1alpha1.ApplicationSource{
+					RepoURL: "https://charts.example.com",
+					Chart:   "guestbook",
+					Helm:    &tc.remote,
+				},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateManagedFieldsManagers(t *testing.T) {
+	cases := map[string]struct {
+		managers []string
+		remote   []string
+		want     bool
+	}{
+		"Equal": {
+			managers: []string{"kube-controller-manager"},
+			remote:   []string{"kube-controller-manager"},
+			want:     true,
+		},
+		"OrderChanged": {
+			managers: []string{"kube-controller-manager", "vpa-recommender"},
+			remote:   []string{"vpa-recommender", "kube-controller-manager"},
+			want:     true,
+		},
+		"ManagerAdded": {
+			managers: []string{"kube-controller-manager", "vpa-recommender"},
+			remote:   []string{"kube-controller-manager"},
+			want:     false,
+		},
+		"ManagerRemoved": {
+			managers: []string{"kube-controller-manager"},
+			remote:   []string{"kube-controller-manager", "vpa-recommender"},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				IgnoreDifferences: []v1alpha1.ResourceIgnoreDifferences{
+					{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: tc.managers},
+				},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				IgnoreDifferences: argocdv1alpha1.IgnoreDifferences{
+					{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: tc.remote},
+				},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDatePluginEnv(t *testing.T) {
+	foo, bar := &v1alpha1.EnvEntry{Name: "FOO", Value: "foo"}, &v1alpha1.EnvEntry{Name: "BAR", Value: "bar"}
+	remoteFoo, remoteBar := &argocdv1alpha1.EnvEntry{Name: "FOO", Value: "foo"}, &argocdv1alpha1.EnvEntry{Name: "BAR", Value: "bar"}
+
+	cases := map[string]struct {
+		env    v1alpha1.Env
+		remote argocdv1alpha1.Env
+		want   bool
+	}{
+		"OrderChanged": {
+			env:    v1alpha1.Env{bar, foo},
+			remote: argocdv1alpha1.Env{remoteFoo, remoteBar},
+			want:   true,
+		},
+		"NilEntries": {
+			env:    v1alpha1.Env{foo, nil, bar},
+			remote: argocdv1alpha1.Env{nil, remoteBar, remoteFoo},
+			want:   true,
+		},
+		"NilEntryAdded": {
+			env:    v1alpha1.Env{foo, bar},
+			remote: argocdv1alpha1.Env{remoteFoo, nil, remoteBar},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project: testProjectName,
+				Source:  &v1alpha1.ApplicationSource{RepoURL: repoURL, Plugin: &v1alpha1.ApplicationSourcePlugin{Name: &testPluginName, Env: tc.env}},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project: testProjectName,
+				Source:  &argocdv1alpha1.ApplicationSource{RepoURL: repoURL, Plugin: &argocdv1alpha1.ApplicationSourcePlugin{Name: testPluginName, Env: tc.remote}},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsApplicationUpToDateSyncOptions(t *testing.T) {
+	cases := map[string]struct {
+		options []string
+		remote  []string
+		want    bool
+	}{
+		"Equal": {
+			options: []string{"CreateNamespace=true", "RespectIgnoreDifferences=true"},
+			remote:  []string{"CreateNamespace=true", "RespectIgnoreDifferences=true"},
+			want:    true,
+		},
+		"OrderChanged": {
+			options: []string{"ApplyOutOfSyncOnly=true", "Validate=false", "FailOnSharedResource=true", "PruneLast=true"},
+			remote:  []string{"PruneLast=true", "FailOnSharedResource=true", "Validate=false", "ApplyOutOfSyncOnly=true"},
+			want:    true,
+		},
+		"DuplicateIgnored": {
+			options: []string{"Replace=true", "Replace=true"},
+			remote:  []string{"Replace=true"},
+			want:    true,
+		},
+		"Empty": {
+			options: []string{},
+			want:    true,
+		},
+		"OptionToggled": {
+			options: []string{"CreateNamespace=true", "Validate=true"},
+			remote:  []string{"CreateNamespace=true", "Validate=false"},
+			want:    false,
+		},
+		"OptionAdded": {
+			options: []string{"CreateNamespace=true", "RespectIgnoreDifferences=true"},
+			remote:  []string{"CreateNamespace=true"},
+			want:    false,
+		},
+		"OptionRemoved": {
+			options: []string{"CreateNamespace=true"},
+			remote:  []string{"CreateNamespace=true", "FailOnSharedResource=true"},
+			want:    false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project:    testProjectName,
+				SyncPolicy: &v1alpha1.SyncPolicy{SyncOptions: tc.options},
+			}
+			remote := &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+				Project:    testProjectName,
+				SyncPolicy: &argocdv1alpha1.SyncPolicy{SyncOptions: tc.remote},
+			}}
+			if diff := cmp.Diff(tc.want, IsApplicationUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHelm(t *testing.T) {
+	ref := &v1alpha1.HelmValuesReference{Kind: "ConfigMap", Name: "values", Namespace: "default"}
+
+	cases := map[string]struct {
+		helm  *v1alpha1.ApplicationSourceHelm
+		valid bool
+		want  error
+	}{
+		"NoHelm": {valid: true},
+		"ValuesObject": {
+			helm:  &v1alpha1.ApplicationSourceHelm{ValuesObject: extv1.JSON{Raw: []byte(`{"replicaCount":2,"image":{"tag":"v1"}}`)}},
+			valid: true,
+		},
+		"ValuesObjectNotAMap": {
+			helm: &v1alpha1.ApplicationSourceHelm{ValuesObject: extv1.JSON{Raw: []byte(`["replicaCount"]`)}},
+		},
+		"ValuesObjectInvalid": {
+			helm: &v1alpha1.ApplicationSourceHelm{ValuesObject: extv1.JSON{Raw: []byte(`{"replicaCount":`)}},
+		},
+		"ValuesAndValuesRef": {
+			helm: &v1alpha1.ApplicationSourceHelm{Values: ptr.To("replicaCount: 2"), ValuesRef: ref},
+			want: errors.New("helm values and valuesRef are mutually exclusive"),
+		},
+		"ValuesObjectAndValuesRef": {
+			helm: &v1alpha1.ApplicationSourceHelm{ValuesObject: extv1.JSON{Raw: []byte(`{"replicaCount":2}`)}, ValuesRef: ref},
+			want: errors.New("helm valuesObject and valuesRef are mutually exclusive"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateHelm(tc.helm)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("validateHelm(...): -want valid, +got:\n%s\n%v", diff, err)
+			}
+			if tc.want == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("validateHelm(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSyncOptions(t *testing.T) {
+	cases := map[string]struct {
+		options []string
+		want    error
+	}{
+		"AllKnown": {
+			options: []string{
+				"Validate=false", "CreateNamespace=true", "PruneLast=true", "Replace=true", "ServerSideApply=true",
+				"ApplyOutOfSyncOnly=true", "RespectIgnoreDifferences=true", "FailOnSharedResource=true",
+				"PrunePropagationPolicy=background",
+			},
+		},
+		"UnknownOption": {
+			options: []string{"CreateNamespaces=true"},
+			want:    errors.Errorf("unknown sync option %q", "CreateNamespaces"),
+		},
+		"InvalidValue": {
+			options: []string{"Validate=no"},
+			want:    errors.Errorf("invalid value %q of sync option %s, must be one of %s", "no", "Validate", "true, false"),
+		},
+		"CapitalizedValue": {
+			options: []string{"RespectIgnoreDifferences=True"},
+			want:    errors.Errorf("invalid value %q of sync option %s, must be one of %s", "True", "RespectIgnoreDifferences", "true, false"),
+		},
+		"InvalidPropagationPolicy": {
+			options: []string{"PrunePropagationPolicy=cascade"},
+			want:    errors.Errorf("invalid value %q of sync option %s, must be one of %s", "cascade", "PrunePropagationPolicy", "foreground, background, orphan"),
+		},
+		"MissingValue": {
+			options: []string{"PruneLast"},
+			want:    errors.Errorf("sync option %q must be in the format <name>=<value>", "PruneLast"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := validateSyncOptions(&v1alpha1.SyncPolicy{SyncOptions: tc.options})
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("validateSyncOptions(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveTargetRevision(t *testing.T) {
+	sha := "4f1c3d8a9b2e7f6051a3c9d8e7b6a5f4e3d2c1b0"
+	resolve := withAnnotations(map[string]string{applications.AnnotationKeyResolveTargetRevision: "true"})
+	source := func(revision *string) ApplicationModifier {
+		return withSpec(v1alpha1.ApplicationParameters{
+			Project: testProjectName,
+			Source:  &v1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: revision},
+		})
+	}
+	query := func(revision string) *argocdApplication.ApplicationManifestQuery {
+		return &argocdApplication.ApplicationManifestQuery{
+			Name:     ptr.To(testApplicationExternalName),
+			Revision: ptr.To(revision),
+			Project:  ptr.To(testProjectName),
+		}
+	}
+
+	type want struct {
+		revision *string
+		err      error
+	}
+
+	cases := map[string]struct {
+		cr       *v1alpha1.Application
+		query    *argocdApplication.ApplicationManifestQuery
+		resolved error
+		want
+	}{
+		"ResolveHead": {
+			cr:    Application(resolve, source(ptr.To("HEAD"))),
+			query: query("HEAD"),
+			want:  want{revision: ptr.To(sha)},
+		},
+		"ResolveDefaultToHead": {
+			cr:    Application(resolve, source(nil)),
+			query: query("HEAD"),
+			want:  want{revision: ptr.To(sha)},
+		},
+		"ResolveBranch": {
+			cr:    Application(resolve, source(ptr.To("main"))),
+			query: query("main"),
+			want:  want{revision: ptr.To(sha)},
+		},
+		"PinnedToCommit": {
+			cr:   Application(resolve, source(ptr.To(sha))),
+			want: want{},
+		},
+		"ResolutionDisabled": {
+			cr:   Application(source(ptr.To("HEAD"))),
+			want: want{},
+		},
+		"ResolveFailed": {
+			cr:       Application(resolve, source(ptr.To("HEAD"))),
+			query:    query("HEAD"),
+			resolved: errBoom,
+			want:     want{err: errors.Wrap(errBoom, errResolveRevision)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				if tc.query != nil {
+					mcs.EXPECT().GetManifests(context.Background(), tc.query).Return(&repoapiclient.ManifestResponse{Revision: sha}, tc.resolved)
+				}
+			})
+			e := &external{client: mc}
+			app := &argocdv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+				Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+			}
+			got, err := e.resolveTargetRevision(context.Background(), tc.cr, app)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("resolveTargetRevision(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.revision, got); diff != "" {
+				t.Errorf("resolveTargetRevision(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolvedSourceRevisions(t *testing.T) {
+	first := "4f1c3d8a9b2e7f6051a3c9d8e7b6a5f4e3d2c1b0"
+	second := "0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c"
+	resolve := withAnnotations(map[string]string{applications.AnnotationKeyResolveTargetRevision: "true"})
+	sources := withSpec(v1alpha1.ApplicationParameters{
+		Project: testProjectName,
+		Sources: v1alpha1.ApplicationSources{
+			{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: ptr.To("main")},
+			{RepoURL: "https://github.com/argoproj/argo-cd", TargetRevision: ptr.To("v2.8.0")},
+		},
+	})
+	chart := withSpec(v1alpha1.ApplicationParameters{
+		Project: testProjectName,
+		Sources: v1alpha1.ApplicationSources{
+			{RepoURL: "https://github.com/argoproj/argocd-example-apps", TargetRevision: ptr.To("main")},
+			{RepoURL: "https://argoproj.github.io/argo-helm", Chart: ptr.To("argo-cd"), TargetRevision: ptr.To("5.46.0")},
+		},
+	})
+
+	cases := map[string]struct {
+		cr        *v1alpha1.Application
+		revisions []string
+		want      []string
+	}{
+		"ResolveSources": {
+			cr:        Application(resolve, sources),
+			revisions: []string{first, second},
+			want:      []string{first, second},
+		},
+		"ChartNotResolved": {
+			cr:        Application(resolve, chart),
+			revisions: []string{first, "5.46.0"},
+			want:      []string{first, ""},
+		},
+		"StaleRevisions": {
+			cr:        Application(resolve, sources),
+			revisions: []string{first},
+		},
+		"ResolutionDisabled": {
+			cr:        Application(sources),
+			revisions: []string{first, second},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := &argocdv1alpha1.Application{Status: argocdv1alpha1.ApplicationStatus{
+				Sync: argocdv1alpha1.SyncStatus{Revisions: tc.revisions},
+			}}
+			if diff := cmp.Diff(tc.want, resolvedSourceRevisions(tc.cr, app)); diff != "" {
+				t.Errorf("resolvedSourceRevisions(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateDependencies(t *testing.T) {
+	dependency := func(c xpv1.Condition) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != "parent" {
+				return kerrors.NewNotFound(schema.GroupResource{Group: v1alpha1.Group, Resource: "applications"}, key.Name)
+			}
+			obj.(*v1alpha1.Application).SetConditions(c)
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		kube      client.Client
+		dependsOn []xpv1.Reference
+		created   bool
+		want      error
+	}{
+		"NoDependencies": {
+			created: true,
+		},
+		"DependencyReady": {
+			kube:      &test.MockClient{MockGet: dependency(xpv1.Available())},
+			dependsOn: []xpv1.Reference{{Name: "parent"}},
+			created:   true,
+		},
+		"DependencyNotReady": {
+			kube:      &test.MockClient{MockGet: dependency(xpv1.Creating())},
+			dependsOn: []xpv1.Reference{{Name: "parent"}},
+			want:      errors.Errorf(errFmtDependencyNotReady, "parent"),
+		},
+		"DependencyMissing": {
+			kube:      &test.MockClient{MockGet: dependency(xpv1.Available())},
+			dependsOn: []xpv1.Reference{{Name: "parent"}, {Name: "other"}},
+			want:      errors.Errorf(errFmtDependencyNotReady, "other"),
+		},
+		"OptionalDependencyMissing": {
+			kube:      &test.MockClient{MockGet: dependency(xpv1.Available())},
+			dependsOn: []xpv1.Reference{{Name: "other", Policy: &xpv1.Policy{Resolution: ptr.To(xpv1.ResolutionPolicyOptional)}}},
+			created:   true,
+		},
+		"GetDependencyFailed": {
+			kube:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			dependsOn: []xpv1.Reference{{Name: "parent"}},
+			want:      errors.Wrap(errBoom, errGetDependency),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Application(withExternalName(testApplicationExternalName))
+			cr.Spec.DependsOn = tc.dependsOn
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				if tc.created {
+					mcs.EXPECT().Create(context.Background(), gomock.Any()).Return(&argocdv1alpha1.Application{}, nil)
+				}
+			})
+			e := &external{kube: tc.kube, client: mc}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Application
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"CreateSystemFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+							},
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+			},
+			want: want{
+				cr:     Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+		"InvalidDirectoryGlob": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Include: &testInvalidGlob,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Directory: &v1alpha1.ApplicationSourceDirectory{
+								Include: &testInvalidGlob,
+							},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.Wrapf(errors.Wrapf(globCompileError(testInvalidGlob), "invalid directory include pattern %q", testInvalidGlob), "source %s", repoURL), errInvalidSource),
+			},
+		},
+		"InvalidPluginParameter": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("replicas"), String_: &testPluginReplicas, OptionalArray: &v1alpha1.OptionalArray{Array: []string{"2"}}},
+								},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Name: &testPluginName,
+								Parameters: v1alpha1.ApplicationSourcePluginParameters{
+									{Name: ptr.To("replicas"), String_: &testPluginReplicas, OptionalArray: &v1alpha1.OptionalArray{Array: []string{"2"}}},
+								},
+							},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.Wrapf(errors.New("plugin parameter replicas must set exactly one of string, array or map"), "source %s", repoURL), errInvalidSource),
+			},
+		},
+		"EmptyManagedFieldsManager": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source:  &v1alpha1.ApplicationSource{RepoURL: repoURL},
+						IgnoreDifferences: []v1alpha1.ResourceIgnoreDifferences{
+							{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"kube-controller-manager", ""}},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source:  &v1alpha1.ApplicationSource{RepoURL: repoURL},
+						IgnoreDifferences: []v1alpha1.ResourceIgnoreDifferences{
+							{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"kube-controller-manager", ""}},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.New("managed fields manager 1 of ignored differences of kind Deployment has no name"), errIgnoreDiffs),
+			},
+		},
+		"SourceRepoPermitted": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+									},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				projectClient: withMockProjectClient(t, func(mcs *mockprojects.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdProject.ProjectQuery{Name: testProjectName},
+					).Return(&argocdv1alpha1.AppProject{
+						ObjectMeta: metav1.ObjectMeta{Name: testProjectName},
+						Spec:       argocdv1alpha1.AppProjectSpec{SourceRepos: []string{"https://github.com/stefanprodan/*"}},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SourceRepoDenied": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				}),
+				projectClient: withMockProjectClient(t, func(mcs *mockprojects.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdProject.ProjectQuery{Name: testProjectName},
+					).Return(&argocdv1alpha1.AppProject{
+						ObjectMeta: metav1.ObjectMeta{Name: testProjectName},
+						Spec:       argocdv1alpha1.AppProjectSpec{SourceRepos: []string{"https://gitlab.com/example-group/*"}},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.Errorf("source repository %s is not permitted by project %s, add it to the sourceRepos of the project", repoURL, testProjectName), errSourceRepos),
+			},
+		},
+		"SourceRepoProjectNotFound": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				}),
+				projectClient: withMockProjectClient(t, func(mcs *mockprojects.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdProject.ProjectQuery{Name: testProjectName},
+					).Return(nil, errBoom)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{applications.AnnotationKeyValidateSourceRepos: "true"}),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errBoom, errGetProjectFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, projectClient: tc.projectClient}
 			o, err := e.Create(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -484,135 +2306,1186 @@ func TestCreate(t *testing.T) {
 			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Application
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		observed     *argocdv1alpha1.Application
+		specUpToDate bool
+		want
+	}{
+		"KeepsSystemManagedKeys": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					// ArgoCD replaces labels and annotations, the keys set by ArgoCD are sent back
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"app.kubernetes.io/instance": "test"},
+									Annotations: map[string]string{
+										"annotation1":                    "value1",
+										"argocd.argoproj.io/tracking-id": "apps:argoproj.io/Application:argocd/test",
+									},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1"},
+					}),
+				),
+			},
+			observed: &argocdv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   testApplicationExternalName,
+					Labels: map[string]string{"app.kubernetes.io/instance": "test"},
+					Annotations: map[string]string{
+						"annotation1":                    "value0",
+						"argocd.argoproj.io/tracking-id": "apps:argoproj.io/Application:argocd/test",
+					},
+				},
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: map[string]string{"annotation1": "value1"},
+					}),
+				),
+			},
+		},
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        testApplicationExternalName,
+									Annotations: testApplicationAnnotations,
+									Finalizers:  testApplicationFinalizers,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Destination: argocdv1alpha1.ApplicationDestination{
+										Namespace: testDestinationNamespace,
+									},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        testApplicationExternalName,
+							Annotations: testApplicationAnnotations,
+							Finalizers:  testApplicationFinalizers,
+						},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+						Annotations: testApplicationAnnotations,
+						Finalizers:  testApplicationFinalizers,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+						Annotations: testApplicationAnnotations,
+						Finalizers:  testApplicationFinalizers,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"ManagedNamespaceMetadataCleared": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					// the complete spec is sent, so that a cleared block stops the metadata management
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									SyncPolicy: &argocdv1alpha1.SyncPolicy{
+										SyncOptions: argocdv1alpha1.SyncOptions{"CreateNamespace=true"},
+									},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						SyncPolicy: &v1alpha1.SyncPolicy{
+							SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						SyncPolicy: &v1alpha1.SyncPolicy{
+							SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"},
+						},
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"RevisionHistoryLimitAndSyncPolicy": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					// both changes are carried by one request, so that they are never applied partially
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project:              testProjectName,
+									RevisionHistoryLimit: ptr.To[int64](3),
+									SyncPolicy: &argocdv1alpha1.SyncPolicy{
+										Automated: &argocdv1alpha1.SyncPolicyAutomated{
+											Prune:    true,
+											SelfHeal: true,
+										},
+									},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil).Times(1)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:              testProjectName,
+						RevisionHistoryLimit: ptr.To[int64](3),
+						SyncPolicy: &v1alpha1.SyncPolicy{
+							Automated: &v1alpha1.SyncPolicyAutomated{
+								Prune:    ptr.To(true),
+								SelfHeal: ptr.To(true),
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:              testProjectName,
+						RevisionHistoryLimit: ptr.To[int64](3),
+						SyncPolicy: &v1alpha1.SyncPolicy{
+							Automated: &v1alpha1.SyncPolicyAutomated{
+								Prune:    ptr.To(true),
+								SelfHeal: ptr.To(true),
+							},
+						},
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        testApplicationExternalName,
+									Annotations: testApplicationAnnotations,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(nil, errBoom)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: testApplicationAnnotations,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:     testProjectName,
+						Annotations: testApplicationAnnotations,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errBoom, errUpdateFailed),
+			},
+		},
+		"SyncSelectedResources": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+					mcs.EXPECT().Sync(
+						context.Background(),
+						&argocdApplication.ApplicationSyncRequest{
+							Name:    &testApplicationExternalName,
+							Project: &testProjectName,
+							Resources: []*argocdv1alpha1.SyncOperationResource{
+								{Group: "apps", Kind: "Deployment", Name: "web"},
+								{Kind: "Service", Namespace: "default", Name: "web"},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v1", testNow.Add(-time.Hour)),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v2", testNow),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SyncAlreadyRequested": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v1",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v1", testNow.Add(-time.Hour)),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v1",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v1", testNow.Add(-time.Hour)),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SyncCooldown": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v1", testNow.Add(-10*time.Second)),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+					withLastSyncRequest("v1", testNow.Add(-10*time.Second)),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SyncFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+					mcs.EXPECT().Sync(
+						context.Background(),
+						&argocdApplication.ApplicationSyncRequest{
+							Name:    &testApplicationExternalName,
+							Project: &testProjectName,
+							Resources: []*argocdv1alpha1.SyncOperationResource{
+								{Group: "apps", Kind: "Deployment", Name: "web"},
+								{Kind: "Service", Namespace: "default", Name: "web"},
+							},
+						},
+					).Return(nil, errBoom)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: testSyncResources,
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errBoom, errSyncFailed),
+			},
+		},
+		"SyncWithOptions": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+					mcs.EXPECT().Sync(
+						context.Background(),
+						&argocdApplication.ApplicationSyncRequest{
+							Name:     &testApplicationExternalName,
+							Project:  &testProjectName,
+							Prune:    ptr.To(true),
+							DryRun:   ptr.To(true),
+							Strategy: &argocdv1alpha1.SyncStrategy{Apply: &argocdv1alpha1.SyncStrategyApply{}},
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:         "v2",
+						applications.AnnotationKeySyncPrune:    "true",
+						applications.AnnotationKeySyncDryRun:   "true",
+						applications.AnnotationKeySyncStrategy: applications.SyncStrategyApply,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:         "v2",
+						applications.AnnotationKeySyncPrune:    "true",
+						applications.AnnotationKeySyncDryRun:   "true",
+						applications.AnnotationKeySyncStrategy: applications.SyncStrategyApply,
+					}),
+					withLastSyncRequest("v2", testNow),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"DryRunSyncOnly": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					// the application is up to date, only the sync is sent
+					mcs.EXPECT().Sync(
+						context.Background(),
+						&argocdApplication.ApplicationSyncRequest{
+							Name:    &testApplicationExternalName,
+							Project: &testProjectName,
+							DryRun:  ptr.To(true),
+						},
+					).Return(&argocdv1alpha1.Application{}, nil)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:       "v2",
+						applications.AnnotationKeySyncDryRun: "true",
+					}),
+				),
+			},
+			observed: &argocdv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+				Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+			},
+			specUpToDate: true,
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:       "v2",
+						applications.AnnotationKeySyncDryRun: "true",
+					}),
+					withLastSyncRequest("v2", testNow),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SyncStrategyInvalid": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:         "v2",
+						applications.AnnotationKeySyncStrategy: "replace",
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:         "v2",
+						applications.AnnotationKeySyncStrategy: "replace",
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errors.New(`invalid sync strategy "replace" in annotation argocd.crossplane.io/sync-strategy, expected apply or hook`), errSyncRequest),
+			},
+		},
+		"SyncResourcesInvalid": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: "apps/web",
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+					withAnnotations(map[string]string{
+						applications.AnnotationKeySync:          "v2",
+						applications.AnnotationKeySyncResources: "apps/web",
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errors.New(`invalid resource "apps/web" in annotation argocd.crossplane.io/sync-resources, expected group/kind/name or group/kind/namespace/name`), errSyncResources),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, clock: clocktesting.NewFakePassiveClock(testNow), observed: tc.observed, specUpToDate: tc.specUpToDate}
+			u, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, u); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestUpdate(t *testing.T) {
+func TestDryRunSyncCondition(t *testing.T) {
+	app := func(dryRun bool, phase synccommon.OperationPhase, message string) *argocdv1alpha1.Application {
+		a := &argocdv1alpha1.Application{}
+		a.Status.OperationState = &argocdv1alpha1.OperationState{
+			Operation: argocdv1alpha1.Operation{Sync: &argocdv1alpha1.SyncOperation{DryRun: dryRun}},
+			Phase:     phase,
+			Message:   message,
+			SyncResult: &argocdv1alpha1.SyncOperationResult{
+				Revision: "abc123",
+				Resources: argocdv1alpha1.ResourceResults{
+					{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web", Message: "deployment.apps/web configured (dry run)"},
+					{Kind: "Namespace", Name: "web", Message: "namespace/web created (dry run)"},
+				},
+			},
+		}
+		return a
+	}
+	type want struct {
+		c  xpv1.Condition
+		ok bool
+	}
+
+	cases := map[string]struct {
+		app  *argocdv1alpha1.Application
+		want want
+	}{
+		"NoOperation": {
+			app: &argocdv1alpha1.Application{},
+		},
+		"NotDryRun": {
+			app: app(false, synccommon.OperationSucceeded, ""),
+		},
+		"DryRunRunning": {
+			app: app(true, synccommon.OperationRunning, ""),
+		},
+		"DryRunSucceeded": {
+			app: app(true, synccommon.OperationSucceeded, "successfully synced (all tasks run)"),
+			want: want{
+				c: xpv1.Condition{
+					Type:    applications.TypeDryRunSync,
+					Status:  corev1.ConditionTrue,
+					Reason:  applications.ReasonDryRunSucceeded,
+					Message: "would sync 2 resources at revision abc123; Deployment.apps default/web: deployment.apps/web configured (dry run); Namespace web: namespace/web created (dry run)",
+				},
+				ok: true,
+			},
+		},
+		"DryRunFailed": {
+			app: app(true, synccommon.OperationFailed, "one or more objects failed to apply"),
+			want: want{
+				c: xpv1.Condition{
+					Type:    applications.TypeDryRunSync,
+					Status:  corev1.ConditionFalse,
+					Reason:  applications.ReasonDryRunFailed,
+					Message: "one or more objects failed to apply; would sync 2 resources at revision abc123; Deployment.apps default/web: deployment.apps/web configured (dry run); Namespace web: namespace/web created (dry run)",
+				},
+				ok: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, ok := dryRunSyncCondition(tc.app)
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("dryRunSyncCondition(...): -want ok, +got ok:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.c, c, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("dryRunSyncCondition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsSyncRequested(t *testing.T) {
+	cases := map[string]struct {
+		cr   *v1alpha1.Application
+		want bool
+	}{
+		"NoAnnotation": {
+			cr:   Application(),
+			want: false,
+		},
+		"NewRequest": {
+			cr: Application(
+				withAnnotations(map[string]string{applications.AnnotationKeySync: "v2"}),
+				withLastSyncRequest("v1", testNow.Add(-time.Hour)),
+			),
+			want: true,
+		},
+		"FirstRequest": {
+			cr: Application(
+				withAnnotations(map[string]string{applications.AnnotationKeySync: "v1"}),
+			),
+			want: true,
+		},
+		"AlreadySynced": {
+			cr: Application(
+				withAnnotations(map[string]string{applications.AnnotationKeySync: "v1"}),
+				withLastSyncRequest("v1", testNow.Add(-time.Hour)),
+			),
+			want: false,
+		},
+		"Cooldown": {
+			cr: Application(
+				withAnnotations(map[string]string{applications.AnnotationKeySync: "v2"}),
+				withLastSyncRequest("v1", testNow.Add(-10*time.Second)),
+			),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{clock: clocktesting.NewFakePassiveClock(testNow)}
+			if diff := cmp.Diff(tc.want, e.isSyncRequested(tc.cr)); diff != "" {
+				t.Errorf("isSyncRequested(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerminateOperation(t *testing.T) {
+	running := func(startedAt time.Time) ApplicationModifier {
+		return withObservation(v1alpha1.ArgoApplicationStatus{
+			OperationState: &v1alpha1.OperationState{
+				Phase:     "Running",
+				StartedAt: &metav1.Time{Time: startedAt},
+			},
+		})
+	}
+	terminateAfter := withAnnotations(map[string]string{applications.AnnotationKeyTerminateOperationAfter: "30m"})
+	terminateRequest := &argocdApplication.OperationTerminateRequest{
+		Name:    ptr.To(testApplicationExternalName),
+		Project: ptr.To(""),
+	}
+
+	cases := map[string]struct {
+		cr         *v1alpha1.Application
+		invalid    bool
+		terminate  bool
+		terminated error
+		want       error
+	}{
+		"StuckOperation": {
+			cr:        Application(withExternalName(testApplicationExternalName), terminateAfter, running(testNow.Add(-time.Hour))),
+			terminate: true,
+		},
+		"OperationWithinTimeout": {
+			cr: Application(withExternalName(testApplicationExternalName), terminateAfter, running(testNow.Add(-10*time.Minute))),
+		},
+		"OperationCompleted": {
+			cr: Application(withExternalName(testApplicationExternalName), terminateAfter, withObservation(v1alpha1.ArgoApplicationStatus{
+				OperationState: &v1alpha1.OperationState{
+					Phase:     "Succeeded",
+					StartedAt: &metav1.Time{Time: testNow.Add(-time.Hour)},
+				},
+			})),
+		},
+		"NoOperation": {
+			cr: Application(withExternalName(testApplicationExternalName), terminateAfter),
+		},
+		"NoAnnotation": {
+			cr: Application(withExternalName(testApplicationExternalName), running(testNow.Add(-time.Hour))),
+		},
+		"NoOperationInProgress": {
+			cr:         Application(withExternalName(testApplicationExternalName), terminateAfter, running(testNow.Add(-time.Hour))),
+			terminate:  true,
+			terminated: errors.New("rpc error: code = InvalidArgument desc = Unable to terminate operation. No operation is in progress"),
+		},
+		"TerminateFailed": {
+			cr:         Application(withExternalName(testApplicationExternalName), terminateAfter, running(testNow.Add(-time.Hour))),
+			terminate:  true,
+			terminated: errBoom,
+			want:       errors.Wrap(errBoom, errTerminateFailed),
+		},
+		"InvalidAnnotation": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withAnnotations(map[string]string{applications.AnnotationKeyTerminateOperationAfter: "soon"}),
+				running(testNow.Add(-time.Hour)),
+			),
+			invalid: true,
+			want:    errors.New(`invalid duration "soon" in annotation argocd.crossplane.io/terminate-operation-after`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				if !tc.invalid {
+					mcs.EXPECT().Update(context.Background(), gomock.Any()).Return(&argocdv1alpha1.Application{}, nil)
+				}
+				if tc.terminate {
+					mcs.EXPECT().TerminateOperation(context.Background(), terminateRequest).Return(&argocdApplication.OperationTerminateResponse{}, tc.terminated)
+				}
+			})
+			e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperationState(t *testing.T) {
+	automated := &v1alpha1.ApplicationParameters{
+		SyncPolicy: &v1alpha1.SyncPolicy{Automated: &v1alpha1.SyncPolicyAutomated{}},
+	}
+	type want struct {
+		phase     *string
+		message   *string
+		revision  *string
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		params *v1alpha1.ApplicationParameters
+		op     *argocdv1alpha1.OperationState
+		want   want
+	}{
+		"NoOperation": {
+			params: automated,
+			want:   want{condition: xpv1.Available()},
+		},
+		"Running": {
+			params: automated,
+			op: &argocdv1alpha1.OperationState{
+				Operation: argocdv1alpha1.Operation{Sync: &argocdv1alpha1.SyncOperation{Revision: "abc123"}},
+				Phase:     synccommon.OperationRunning,
+				Message:   "waiting for healthy state of apps/Deployment/web",
+			},
+			want: want{
+				phase:     ptr.To("Running"),
+				message:   ptr.To("waiting for healthy state of apps/Deployment/web"),
+				revision:  ptr.To("abc123"),
+				condition: xpv1.Unavailable().WithMessage("operation Running: waiting for healthy state of apps/Deployment/web"),
+			},
+		},
+		"Succeeded": {
+			params: automated,
+			op: &argocdv1alpha1.OperationState{
+				Operation:  argocdv1alpha1.Operation{Sync: &argocdv1alpha1.SyncOperation{Revision: "HEAD"}},
+				Phase:      synccommon.OperationSucceeded,
+				Message:    "successfully synced (all tasks run)",
+				SyncResult: &argocdv1alpha1.SyncOperationResult{Revision: "abc123"},
+			},
+			want: want{
+				phase:     ptr.To("Succeeded"),
+				message:   ptr.To("successfully synced (all tasks run)"),
+				revision:  ptr.To("abc123"),
+				condition: xpv1.Available(),
+			},
+		},
+		"Failed": {
+			params: automated,
+			op: &argocdv1alpha1.OperationState{
+				Phase:      synccommon.OperationFailed,
+				Message:    "one or more objects failed to apply",
+				SyncResult: &argocdv1alpha1.SyncOperationResult{Revision: "abc123"},
+			},
+			want: want{
+				phase:     ptr.To("Failed"),
+				message:   ptr.To("one or more objects failed to apply"),
+				revision:  ptr.To("abc123"),
+				condition: xpv1.Unavailable().WithMessage("operation Failed: one or more objects failed to apply"),
+			},
+		},
+		"FailedManualSync": {
+			params: &v1alpha1.ApplicationParameters{},
+			op: &argocdv1alpha1.OperationState{
+				Phase:   synccommon.OperationFailed,
+				Message: "one or more objects failed to apply",
+			},
+			want: want{
+				phase:     ptr.To("Failed"),
+				message:   ptr.To("one or more objects failed to apply"),
+				condition: xpv1.Available(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := &argocdv1alpha1.Application{Status: argocdv1alpha1.ApplicationStatus{OperationState: tc.op}}
+			status := generateApplicationObservation(app)
+			got := want{
+				phase:     status.OperationPhase,
+				message:   status.OperationMessage,
+				revision:  status.SyncRevision,
+				condition: applicationAvailability(tc.params, app),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveRequestedSync(t *testing.T) {
+	requestedAt := testNow.Add(-time.Minute)
+	operation := func(phase string, startedAt time.Time) *v1alpha1.OperationState {
+		return &v1alpha1.OperationState{Phase: v1alpha1.OperationPhase(phase), Message: ptr.To("waiting for healthy state"), StartedAt: &metav1.Time{Time: startedAt}}
+	}
+	type step struct {
+		op        *v1alpha1.OperationState
+		elapsed   time.Duration
+		reason    xpv1.ConditionReason
+		available bool
+		poll      time.Duration
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		steps       []step
+		err         error
+	}{
+		"RunningThenSucceeded": {
+			steps: []step{
+				{op: operation("Succeeded", requestedAt.Add(-time.Hour)), reason: ReasonSyncing, poll: syncPollInterval},
+				{op: operation("Running", requestedAt), elapsed: time.Minute, reason: ReasonSyncing, poll: syncPollInterval},
+				{op: operation("Succeeded", requestedAt), elapsed: 2 * time.Minute, reason: xpv1.ReasonAvailable, available: true, poll: time.Minute},
+			},
+		},
+		"RunningThenTimeout": {
+			annotations: map[string]string{applications.AnnotationKeySyncTimeout: "5m"},
+			steps: []step{
+				{op: operation("Running", requestedAt), reason: ReasonSyncing, poll: syncPollInterval},
+				{op: operation("Running", requestedAt), elapsed: 5 * time.Minute, reason: ReasonSyncTimedOut, poll: time.Minute},
+			},
+		},
+		"InvalidTimeout": {
+			annotations: map[string]string{applications.AnnotationKeySyncTimeout: "soon"},
+			steps:       []step{{op: operation("Running", requestedAt)}},
+			err:         errors.New(`invalid duration "soon" in annotation argocd.crossplane.io/sync-timeout`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clock := clocktesting.NewFakePassiveClock(testNow)
+			e := &external{clock: clock}
+			for i, s := range tc.steps {
+				clock.SetTime(testNow.Add(s.elapsed))
+				cr := Application(withAnnotations(tc.annotations), withLastSyncRequest("v1", requestedAt), withConditions(xpv1.Available()))
+				cr.Status.AtProvider.OperationState = s.op
+
+				err := e.observeRequestedSync(cr)
+				if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+					t.Fatalf("step %d: observeRequestedSync(...): -want error, +got error:\n%s", i, diff)
+				}
+				if err != nil {
+					continue
+				}
+				ready := cr.GetCondition(xpv1.TypeReady)
+				if diff := cmp.Diff(s.reason, ready.Reason); diff != "" {
+					t.Errorf("step %d: observeRequestedSync(...): -want reason, +got reason:\n%s", i, diff)
+				}
+				if diff := cmp.Diff(s.available, ready.Status == corev1.ConditionTrue); diff != "" {
+					t.Errorf("step %d: observeRequestedSync(...): -want available, +got available:\n%s", i, diff)
+				}
+				if diff := cmp.Diff(s.poll, pollInterval(cr, time.Minute)); diff != "" {
+					t.Errorf("step %d: pollInterval(...): -want, +got:\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	cases := map[string]struct {
+		summary argocdv1alpha1.ApplicationSummary
+		want    v1alpha1.ApplicationSummary
+	}{
+		"Empty": {
+			want: v1alpha1.ApplicationSummary{},
+		},
+		"ImagesAndExternalURLs": {
+			summary: argocdv1alpha1.ApplicationSummary{
+				Images:       []string{"nginx:1.27", "ghcr.io/example/guestbook:v2"},
+				ExternalURLs: []string{"https://guestbook.example.com"},
+			},
+			want: v1alpha1.ApplicationSummary{
+				Images:       []string{"nginx:1.27", "ghcr.io/example/guestbook:v2"},
+				ExternalURLs: []string{"https://guestbook.example.com"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := &argocdv1alpha1.Application{Status: argocdv1alpha1.ApplicationStatus{Summary: tc.summary}}
+			got := generateApplicationObservation(app).Summary
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceCount(t *testing.T) {
+	reconciledAt := metav1.NewTime(testNow)
+	cases := map[string]struct {
+		status argocdv1alpha1.ApplicationStatus
+		want   *int32
+	}{
+		"NotReconciled": {
+			status: argocdv1alpha1.ApplicationStatus{},
+		},
+		"NoResources": {
+			status: argocdv1alpha1.ApplicationStatus{ReconciledAt: &reconciledAt},
+			want:   ptr.To(int32(0)),
+		},
+		"Resources": {
+			status: argocdv1alpha1.ApplicationStatus{
+				ReconciledAt: &reconciledAt,
+				Resources: []argocdv1alpha1.ResourceStatus{
+					{Kind: "Service", Namespace: "guestbook", Name: "guestbook-ui"},
+					{Group: "apps", Kind: "Deployment", Namespace: "guestbook", Name: "guestbook-ui"},
+				},
+			},
+			want: ptr.To(int32(2)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generateApplicationObservation(&argocdv1alpha1.Application{Status: tc.status}).ResourceCount
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ResourceCount: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncWaves(t *testing.T) {
+	healthy := &argocdv1alpha1.HealthStatus{Status: health.HealthStatusHealthy}
+	progressing := &argocdv1alpha1.HealthStatus{Status: health.HealthStatusProgressing}
+	resources := []argocdv1alpha1.ResourceStatus{
+		{Kind: "Namespace", Name: "guestbook", SyncWave: -1, Health: healthy},
+		{Kind: "ConfigMap", Namespace: "guestbook", Name: "config", Health: healthy},
+		{Group: "apps", Kind: "Deployment", Namespace: "guestbook", Name: "api", Health: progressing},
+		{Group: "apps", Kind: "Deployment", Namespace: "guestbook", Name: "frontend", SyncWave: 5},
+	}
+	results := argocdv1alpha1.ResourceResults{
+		{Kind: "Namespace", Name: "guestbook", Status: synccommon.ResultCodeSynced},
+		{Kind: "ConfigMap", Namespace: "guestbook", Name: "config", Status: synccommon.ResultCodeSynced},
+		{Group: "apps", Kind: "Deployment", Namespace: "guestbook", Name: "api", Status: synccommon.ResultCodeSyncFailed},
+	}
+
 	type want struct {
-		cr     *v1alpha1.Application
-		result managed.ExternalUpdate
-		err    error
+		waves   []v1alpha1.SyncWaveSummary
+		current *int64
 	}
 
 	cases := map[string]struct {
-		args
+		resources []argocdv1alpha1.ResourceStatus
+		operation *argocdv1alpha1.OperationState
 		want
 	}{
-		"Successful": {
-			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Update(
-						context.Background(),
-						&argocdApplication.ApplicationUpdateRequest{
-							Application: &argocdv1alpha1.Application{
-								ObjectMeta: metav1.ObjectMeta{
-									Name:        testApplicationExternalName,
-									Annotations: testApplicationAnnotations,
-									Finalizers:  testApplicationFinalizers,
-								},
-								Spec: argocdv1alpha1.ApplicationSpec{
-									Project: testProjectName,
-									Destination: argocdv1alpha1.ApplicationDestination{
-										Namespace: testDestinationNamespace,
-									},
-								},
-							},
-						},
-					).Return(&argocdv1alpha1.Application{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        testApplicationExternalName,
-							Annotations: testApplicationAnnotations,
-							Finalizers:  testApplicationFinalizers,
-						},
-					}, nil)
-				}),
-				cr: Application(
-					withExternalName(testApplicationExternalName),
-					withSpec(v1alpha1.ApplicationParameters{
-						Project: testProjectName,
-						Destination: v1alpha1.ApplicationDestination{
-							Namespace: &testDestinationNamespace,
-						},
-						Annotations: testApplicationAnnotations,
-						Finalizers:  testApplicationFinalizers,
-					}),
-					withExternalName(testApplicationExternalName),
-				),
+		"NoResources": {
+			want: want{},
+		},
+		"NoWaves": {
+			resources: []argocdv1alpha1.ResourceStatus{
+				{Kind: "ConfigMap", Namespace: "guestbook", Name: "config", Health: healthy},
+				{Group: "apps", Kind: "Deployment", Namespace: "guestbook", Name: "api", Health: healthy},
+			},
+			want: want{},
+		},
+		"RunningOperation": {
+			resources: resources,
+			operation: &argocdv1alpha1.OperationState{
+				Phase:      synccommon.OperationRunning,
+				SyncResult: &argocdv1alpha1.SyncOperationResult{Resources: results},
 			},
 			want: want{
-				cr: Application(
-					withExternalName(testApplicationExternalName),
-					withSpec(v1alpha1.ApplicationParameters{
-						Project: testProjectName,
-						Destination: v1alpha1.ApplicationDestination{
-							Namespace: &testDestinationNamespace,
-						},
-						Annotations: testApplicationAnnotations,
-						Finalizers:  testApplicationFinalizers,
-					}),
-					withExternalName(testApplicationExternalName),
-				),
-				result: managed.ExternalUpdate{},
-				err:    nil,
+				waves: []v1alpha1.SyncWaveSummary{
+					{Wave: -1, Resources: 1, Synced: 1, Healthy: 1},
+					{Wave: 0, Resources: 2, Synced: 1, Failed: 1, Healthy: 1},
+					{Wave: 5, Resources: 1},
+				},
+				current: ptr.To[int64](0),
 			},
 		},
-		"UpdateFailed": {
-			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Update(
-						context.Background(),
-						&argocdApplication.ApplicationUpdateRequest{
-							Application: &argocdv1alpha1.Application{
-								ObjectMeta: metav1.ObjectMeta{
-									Name:        testApplicationExternalName,
-									Annotations: testApplicationAnnotations,
-								},
-								Spec: argocdv1alpha1.ApplicationSpec{
-									Project: testProjectName,
-								},
-							},
-						},
-					).Return(nil, errBoom)
-				}),
-				cr: Application(
-					withSpec(v1alpha1.ApplicationParameters{
-						Project:     testProjectName,
-						Annotations: testApplicationAnnotations,
-					}),
-					withExternalName(testApplicationExternalName),
-				),
+		"CompletedOperation": {
+			resources: resources,
+			operation: &argocdv1alpha1.OperationState{
+				Phase:      synccommon.OperationFailed,
+				SyncResult: &argocdv1alpha1.SyncOperationResult{Resources: results},
 			},
 			want: want{
-				cr: Application(
-					withSpec(v1alpha1.ApplicationParameters{
-						Project:     testProjectName,
-						Annotations: testApplicationAnnotations,
-					}),
-					withExternalName(testApplicationExternalName),
-				),
-				result: managed.ExternalUpdate{},
-				err:    errors.Wrap(errBoom, errUpdateFailed),
+				waves: []v1alpha1.SyncWaveSummary{
+					{Wave: -1, Resources: 1, Synced: 1, Healthy: 1},
+					{Wave: 0, Resources: 2, Synced: 1, Failed: 1, Healthy: 1},
+					{Wave: 5, Resources: 1},
+				},
+			},
+		},
+		"NoOperation": {
+			resources: resources,
+			want: want{
+				waves: []v1alpha1.SyncWaveSummary{
+					{Wave: -1, Resources: 1, Healthy: 1},
+					{Wave: 0, Resources: 2, Healthy: 1},
+					{Wave: 5, Resources: 1},
+				},
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			u, err := e.Update(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
+			app := &argocdv1alpha1.Application{Status: argocdv1alpha1.ApplicationStatus{Resources: tc.resources, OperationState: tc.operation}}
+			got := generateApplicationObservation(app)
+			if diff := cmp.Diff(tc.want.waves, got.SyncWaves); diff != "" {
+				t.Errorf("SyncWaves: -want, +got:\n%s", diff)
 			}
-			if diff := cmp.Diff(tc.want.result, u); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
+			if diff := cmp.Diff(tc.want.current, got.CurrentSyncWave); diff != "" {
+				t.Errorf("CurrentSyncWave: -want, +got:\n%s", diff)
 			}
 		})
 	}
@@ -674,11 +3547,37 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errBoom, errDeleteFailed),
 			},
 		},
+		"DependentExists": {
+			args: args{
+				kube: &test.MockClient{MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*v1alpha1.ApplicationList).Items = []v1alpha1.Application{
+						*Application(withName("child"), withDependsOn(testApplicationExternalName)),
+					}
+					return nil
+				}},
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+				err: errors.Errorf(errFmtDependentExists, "child"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			kube := tc.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
+			e := &external{kube: kube, client: tc.client}
 			err := e.Delete(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -690,3 +3589,441 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestHelmValuesRef(t *testing.T) {
+	values := "replicas: 2\n"
+	rotated := "replicas: 3\n"
+	kube := func(configMap, secret string) client.Client {
+		return &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Namespace != "crossplane-system" || key.Name != "values" {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"values.yaml": configMap}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"custom.yaml": []byte(secret)}
+			}
+			return nil
+		}}
+	}
+	source := func(ref *v1alpha1.HelmValuesReference) *v1alpha1.ApplicationSource {
+		return &v1alpha1.ApplicationSource{
+			RepoURL: "https://charts.example.org",
+			Chart:   ptr.To("app"),
+			Helm:    &v1alpha1.ApplicationSourceHelm{ValuesRef: ref},
+		}
+	}
+	configMapRef := &v1alpha1.HelmValuesReference{Kind: "ConfigMap", Name: "values", Namespace: "crossplane-system"}
+	secretRef := &v1alpha1.HelmValuesReference{Kind: "Secret", Name: "values", Namespace: "crossplane-system", Key: "custom.yaml"}
+
+	type want struct {
+		upToDate bool
+		hash     *string
+		values   string
+		err      error
+	}
+
+	cases := map[string]struct {
+		kube   client.Client
+		params v1alpha1.ApplicationParameters
+		remote string
+		want
+	}{
+		"ConfigMapUpToDate": {
+			kube:   kube(values, ""),
+			params: v1alpha1.ApplicationParameters{Project: testProjectName, Source: source(configMapRef)},
+			remote: values,
+			want:   want{upToDate: true, hash: hashHelmValues(map[int]string{sourceIndex: values}), values: values},
+		},
+		"SecretUpToDate": {
+			kube:   kube("", values),
+			params: v1alpha1.ApplicationParameters{Project: testProjectName, Sources: v1alpha1.ApplicationSources{*source(secretRef)}},
+			remote: values,
+			want:   want{upToDate: true, hash: hashHelmValues(map[int]string{0: values}), values: values},
+		},
+		"ConfigMapRotated": {
+			kube:   kube(rotated, ""),
+			params: v1alpha1.ApplicationParameters{Project: testProjectName, Source: source(configMapRef)},
+			remote: values,
+			want:   want{hash: hashHelmValues(map[int]string{sourceIndex: rotated}), values: rotated},
+		},
+		"SecretRotated": {
+			kube:   kube("", rotated),
+			params: v1alpha1.ApplicationParameters{Project: testProjectName, Sources: v1alpha1.ApplicationSources{*source(secretRef)}},
+			remote: values,
+			want:   want{hash: hashHelmValues(map[int]string{0: rotated}), values: rotated},
+		},
+		"MissingKey": {
+			kube:   kube("", values),
+			params: v1alpha1.ApplicationParameters{Project: testProjectName, Sources: v1alpha1.ApplicationSources{*source(&v1alpha1.HelmValuesReference{Kind: "Secret", Name: "values", Namespace: "crossplane-system"})}},
+			remote: values,
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtHelmValuesMissing, "Secret", "crossplane-system", "values", "values.yaml"), errGetHelmValues)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			converter := &applications.ConverterImpl{}
+			remote := argocdv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+				Spec:       *converter.ToArgoApplicationSpec(&tc.params),
+			}
+			injectHelmValues(&remote.Spec, map[int]string{sourceIndex: tc.remote, 0: tc.remote})
+
+			var sent string
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.ApplicationList{Items: []argocdv1alpha1.Application{remote}}, nil)
+				if tc.want.err == nil {
+					mcs.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *argocdApplication.ApplicationUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.Application, error) {
+						h := sourceHelm(&req.Application.Spec, sourceIndex)
+						if h == nil {
+							h = sourceHelm(&req.Application.Spec, 0)
+						}
+						sent = h.Values
+						return req.Application, nil
+					})
+				}
+			})
+			e := &external{kube: tc.kube, client: mc}
+			cr := Application(withExternalName(testApplicationExternalName), withSpec(tc.params))
+
+			o, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want up to date, +got up to date:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.hash, cr.Status.AtProvider.HelmValuesHash); diff != "" {
+				t.Errorf("Observe(...): -want hash, +got hash:\n%s", diff)
+			}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("Update(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want.values, sent); diff != "" {
+				t.Errorf("Update(...): -want values, +got values:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveOutOfSync(t *testing.T) {
+	at := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: testNow.Add(-d)} }
+	app := func(status argocdv1alpha1.SyncStatusCode, m func(*argocdv1alpha1.Application)) *argocdv1alpha1.Application {
+		a := &argocdv1alpha1.Application{}
+		a.CreationTimestamp = *at(48 * time.Hour)
+		a.Status.Sync.Status = status
+		if m != nil {
+			m(a)
+		}
+		return a
+	}
+	type want struct {
+		since    *metav1.Time
+		duration *metav1.Duration
+	}
+
+	cases := map[string]struct {
+		app  *argocdv1alpha1.Application
+		want want
+	}{
+		"Synced": {
+			app: app(argocdv1alpha1.SyncStatusCodeSynced, func(a *argocdv1alpha1.Application) {
+				a.Status.History = argocdv1alpha1.RevisionHistories{{DeployedAt: *at(time.Hour)}}
+			}),
+		},
+		"SinceLastSucceededOperation": {
+			app: app(argocdv1alpha1.SyncStatusCodeOutOfSync, func(a *argocdv1alpha1.Application) {
+				a.Status.OperationState = &argocdv1alpha1.OperationState{Phase: synccommon.OperationSucceeded, FinishedAt: at(90 * time.Minute)}
+				a.Status.History = argocdv1alpha1.RevisionHistories{{DeployedAt: *at(2 * time.Hour)}}
+			}),
+			want: want{since: at(90 * time.Minute), duration: &metav1.Duration{Duration: 90 * time.Minute}},
+		},
+		"SinceLastDeploymentAfterFailedOperation": {
+			app: app(argocdv1alpha1.SyncStatusCodeOutOfSync, func(a *argocdv1alpha1.Application) {
+				a.Status.OperationState = &argocdv1alpha1.OperationState{Phase: synccommon.OperationFailed, FinishedAt: at(time.Minute)}
+				a.Status.History = argocdv1alpha1.RevisionHistories{{DeployedAt: *at(3 * time.Hour)}, {DeployedAt: *at(2*time.Hour + 30*time.Second + 500*time.Millisecond)}}
+			}),
+			want: want{since: at(2*time.Hour + 30*time.Second + 500*time.Millisecond), duration: &metav1.Duration{Duration: 2*time.Hour + 30*time.Second}},
+		},
+		"NeverSynced": {
+			app:  app(argocdv1alpha1.SyncStatusCodeOutOfSync, nil),
+			want: want{since: at(48 * time.Hour), duration: &metav1.Duration{Duration: 48 * time.Hour}},
+		},
+		"NeverSyncedNotCreated": {
+			app: app(argocdv1alpha1.SyncStatusCodeOutOfSync, func(a *argocdv1alpha1.Application) {
+				a.CreationTimestamp = metav1.Time{}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := &v1alpha1.ArgoApplicationStatus{}
+			observeOutOfSync(status, tc.app, clocktesting.NewFakePassiveClock(testNow))
+			if diff := cmp.Diff(tc.want.since, status.OutOfSyncSince); diff != "" {
+				t.Errorf("observeOutOfSync(...): -want since, +got since:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.duration, status.OutOfSyncDuration); diff != "" {
+				t.Errorf("observeOutOfSync(...): -want duration, +got duration:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeNotifications(t *testing.T) {
+	delivery := func(trigger, service, recipient string, t int64) v1alpha1.NotificationDelivery {
+		return v1alpha1.NotificationDelivery{Trigger: trigger, Service: service, Recipient: recipient, DeliveredAt: metav1.NewTime(time.Unix(t, 0))}
+	}
+	bounded := map[string]int64{}
+	var recent []v1alpha1.NotificationDelivery
+	for i := 0; i < maxNotificationDeliveries+2; i++ {
+		channel := fmt.Sprintf("channel-%02d", i)
+		bounded["on-deployed:[0].y7b5sbwa2Q329JYH755peeq-fBs:slack:"+channel] = int64(1700000000 + i)
+	}
+	for i := maxNotificationDeliveries + 1; i > 1; i-- {
+		recent = append(recent, delivery("on-deployed", "slack", fmt.Sprintf("channel-%02d", i), int64(1700000000+i)))
+	}
+	annotation := func(notified map[string]int64) map[string]string {
+		b, err := json.Marshal(notified)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return map[string]string{notifiedAnnotationKey: string(b)}
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        []v1alpha1.NotificationDelivery
+	}{
+		"NoAnnotation": {},
+		"Deliveries": {
+			annotations: annotation(map[string]int64{
+				"on-sync-succeeded:[0].y7b5sbwa2Q329JYH755peeq-fBs:slack:my-channel":                                  1700000100,
+				"4f1c3d8a9b2e7f6051a3c9d8e7b6a5f4e3d2c1b0:on-deployed:[0].y7b5sbwa2Q329JYH755peeq-fBs:webhook:github": 1700000200,
+			}),
+			want: []v1alpha1.NotificationDelivery{
+				delivery("on-deployed", "webhook", "github", 1700000200),
+				delivery("on-sync-succeeded", "slack", "my-channel", 1700000100),
+			},
+		},
+		"MalformedRecordSkipped": {
+			annotations: annotation(map[string]int64{
+				"on-sync-succeeded:slack": 1700000100,
+				"on-sync-failed:[0].y7b5sbwa2Q329JYH755peeq-fBs:email:ops@example.com": 1700000300,
+			}),
+			want: []v1alpha1.NotificationDelivery{
+				delivery("on-sync-failed", "email", "ops@example.com", 1700000300),
+			},
+		},
+		"InvalidAnnotation": {
+			annotations: map[string]string{notifiedAnnotationKey: "not-json"},
+		},
+		"Bounded": {
+			annotations: annotation(bounded),
+			want:        recent,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := &argocdv1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if diff := cmp.Diff(tc.want, summarizeNotifications(app)); diff != "" {
+				t.Errorf("summarizeNotifications(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProjectMove(t *testing.T) {
+	validate := withAnnotations(map[string]string{applications.AnnotationKeyValidateProject: "true"})
+	params := v1alpha1.ApplicationParameters{
+		Project: "target",
+		Source:  &v1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: ptr.To("guestbook")},
+	}
+
+	cases := map[string]struct {
+		cr      *v1alpha1.Application
+		project error
+		checked bool
+		updated bool
+		want    error
+	}{
+		"Move": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params)),
+			updated: true,
+		},
+		"MoveToExistingProject": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params), validate),
+			checked: true,
+			updated: true,
+		},
+		"MoveToMissingProject": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params), validate),
+			checked: true,
+			project: errors.New(`rpc error: code = NotFound desc = appprojects.argoproj.io "target" not found`),
+			want:    errors.Errorf(errFmtProjectNotFound, "target"),
+		},
+		"GetProjectFailed": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params), validate),
+			checked: true,
+			project: errBoom,
+			want:    errors.Wrap(errBoom, errGetProjectFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			converter := &applications.ConverterImpl{}
+			remote := argocdv1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+				Spec:       *converter.ToArgoApplicationSpec(&params),
+			}
+			remote.Spec.Project = testProjectName
+
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(gomock.Any(), &argocdApplication.ApplicationQuery{Name: ptr.To(testApplicationExternalName)}).
+					Return(&argocdv1alpha1.ApplicationList{Items: []argocdv1alpha1.Application{remote}}, nil)
+				if tc.updated {
+					mcs.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *argocdApplication.ApplicationUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.Application, error) {
+						if req.Application.Spec.Project != "target" {
+							t.Errorf("Update(...): want project target, got %s", req.Application.Spec.Project)
+						}
+						return req.Application, nil
+					})
+				}
+			})
+			pc := withMockProjectClient(t, func(mcs *mockprojects.MockProjectServiceClient) {
+				if tc.checked {
+					mcs.EXPECT().Get(gomock.Any(), &argocdProject.ProjectQuery{Name: "target"}).Return(&argocdv1alpha1.AppProject{}, tc.project)
+				}
+			})
+			e := &external{client: mc, projectClient: pc}
+
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if !o.ResourceExists || o.ResourceUpToDate {
+				t.Errorf("Observe(...): want existing application with drift, got %+v", o)
+			}
+			_, err = e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnedFields(t *testing.T) {
+	owned := func(fields string) ApplicationModifier {
+		return withAnnotations(map[string]string{applications.AnnotationKeyOwnedFields: fields})
+	}
+	params := v1alpha1.ApplicationParameters{
+		Project: testProjectName,
+		Source:  &v1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: ptr.To("guestbook")},
+	}
+	// syncPolicy and the team label are managed by another controller
+	remote := func(path string) argocdv1alpha1.Application {
+		return argocdv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName, Labels: map[string]string{"team": "platform"}},
+			Spec: argocdv1alpha1.ApplicationSpec{
+				Project:    testProjectName,
+				Source:     &argocdv1alpha1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: path},
+				SyncPolicy: &argocdv1alpha1.SyncPolicy{Automated: &argocdv1alpha1.SyncPolicyAutomated{Prune: true}},
+			},
+		}
+	}
+
+	type want struct {
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		cr      *v1alpha1.Application
+		remote  argocdv1alpha1.Application
+		updated bool
+		patched bool
+		want
+	}{
+		"OwnedFieldChanged": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params), owned("source")),
+			remote:  remote("helm-guestbook"),
+			patched: true,
+		},
+		"UnownedFieldsChanged": {
+			cr:     Application(withExternalName(testApplicationExternalName), withSpec(params), owned("source, project")),
+			remote: remote("guestbook"),
+			want:   want{upToDate: true},
+		},
+		"AllFieldsOwned": {
+			cr:      Application(withExternalName(testApplicationExternalName), withSpec(params)),
+			remote:  remote("guestbook"),
+			updated: true,
+		},
+		"UnknownOwnedField": {
+			cr:     Application(withExternalName(testApplicationExternalName), withSpec(params), owned("source,sourceRepos")),
+			remote: remote("guestbook"),
+			want: want{err: errors.Wrap(errors.Errorf(`unknown field "sourceRepos" in annotation %s, expected one of %s`,
+				applications.AnnotationKeyOwnedFields, strings.Join(applications.ParameterFields(), ", ")), errOwnedFields)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(gomock.Any(), &argocdApplication.ApplicationQuery{Name: ptr.To(testApplicationExternalName)}).
+					Return(&argocdv1alpha1.ApplicationList{Items: []argocdv1alpha1.Application{tc.remote}}, nil)
+				if tc.updated {
+					mcs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Application{}, nil)
+				}
+				if tc.patched {
+					mcs.EXPECT().Patch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *argocdApplication.ApplicationPatchRequest, _ ...grpc.CallOption) (*argocdv1alpha1.Application, error) {
+						// apply the patch like ArgoCD does
+						original, err := json.Marshal(tc.remote)
+						if err != nil {
+							t.Fatal(err)
+						}
+						b, err := jsonpatch.MergePatch(original, []byte(ptr.Deref(req.Patch, "")))
+						if err != nil {
+							t.Fatalf("Patch(...): invalid merge patch %s: %v", ptr.Deref(req.Patch, ""), err)
+						}
+						patched := &argocdv1alpha1.Application{}
+						if err := json.Unmarshal(b, patched); err != nil {
+							t.Fatal(err)
+						}
+						want := tc.remote.DeepCopy()
+						want.Spec.Source.Path = "guestbook"
+						if diff := cmp.Diff(want, patched); diff != "" {
+							t.Errorf("Patch(...): -want, +got:\n%s", diff)
+						}
+						return patched, nil
+					})
+				}
+			})
+			e := &external{client: mc}
+
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want up to date, +got:\n%s", diff)
+			}
+			if o.ResourceUpToDate {
+				return
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Errorf("Update(...): %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package applications
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+)
+
+const (
+	errGetDependency         = "cannot get Application dependency"
+	errListDependents        = "cannot list dependent Applications"
+	errFmtDependencyNotReady = "waiting for Application dependency %s to become ready"
+	errFmtDependentExists    = "waiting for dependent Application %s to be deleted"
+)
+
+// checkDependencies returns an error unless all Applications cr depends on are ready.
+// Missing dependencies with an optional resolution policy are ignored.
+func (e *external) checkDependencies(ctx context.Context, cr *v1alpha1.Application) error {
+	for _, ref := range cr.Spec.DependsOn {
+		dep := &v1alpha1.Application{}
+		err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, dep)
+		switch {
+		case kerrors.IsNotFound(err) && ref.Policy.IsResolutionPolicyOptional():
+			continue
+		case kerrors.IsNotFound(err):
+			return errors.Errorf(errFmtDependencyNotReady, ref.Name)
+		case err != nil:
+			return errors.Wrap(err, errGetDependency)
+		}
+		if dep.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			return errors.Errorf(errFmtDependencyNotReady, ref.Name)
+		}
+	}
+	return nil
+}
+
+// checkDependents returns an error if an Application depending on cr still exists
+func (e *external) checkDependents(ctx context.Context, cr *v1alpha1.Application) error {
+	l := &v1alpha1.ApplicationList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListDependents)
+	}
+	for _, a := range l.Items {
+		for _, ref := range a.Spec.DependsOn {
+			if ref.Name == cr.GetName() {
+				return errors.Errorf(errFmtDependentExists, a.GetName())
+			}
+		}
+	}
+	return nil
+}
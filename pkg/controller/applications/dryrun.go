@@ -0,0 +1,46 @@
+package applications
+
+import (
+	"fmt"
+	"strings"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
+)
+
+// dryRunSyncCondition returns the condition reporting what the last operation of app would have
+// synced if it is a completed dry run sync. Dry runs don't change any resource, ArgoCD reports the
+// resources it would have synced in the sync result of the operation.
+func dryRunSyncCondition(app *argocdv1alpha1.Application) (xpv1.Condition, bool) {
+	op := app.Status.OperationState
+	if op == nil || op.Operation.Sync == nil || !op.Operation.Sync.DryRun || !op.Phase.Completed() {
+		return xpv1.Condition{}, false
+	}
+	c := xpv1.Condition{
+		Type:               applications.TypeDryRunSync,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             applications.ReasonDryRunSucceeded,
+	}
+	var msgs []string
+	if op.Phase != synccommon.OperationSucceeded {
+		c.Status, c.Reason = corev1.ConditionFalse, applications.ReasonDryRunFailed
+		if op.Message != "" {
+			msgs = append(msgs, op.Message)
+		}
+	}
+	if r := op.SyncResult; r != nil {
+		msgs = append(msgs, fmt.Sprintf("would sync %d resources at revision %s", len(r.Resources), r.Revision))
+		for _, res := range r.Resources {
+			msgs = append(msgs, fmt.Sprintf("%s %s: %s", res.GroupVersionKind().GroupKind(), strings.TrimPrefix(res.Namespace+"/"+res.Name, "/"), res.Message))
+		}
+	}
+	c.Message = strings.Join(msgs, "; ")
+	return c, true
+}
@@ -0,0 +1,64 @@
+package applications
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+)
+
+const (
+	// notifiedAnnotationKey is the annotation in which the ArgoCD notifications controller records the
+	// delivered notifications of an application
+	notifiedAnnotationKey = "notified.notifications.argoproj.io"
+
+	// maxNotificationDeliveries is the maximum number of notifications reported in the status
+	maxNotificationDeliveries = 10
+)
+
+// summarizeNotifications returns the most recent notifications delivered for app, most recent first.
+// The notifications controller records the unix time of every delivery keyed by
+// [<prefix>:]<trigger>:<condition>:<service>:<recipient>, unparsable records are skipped.
+func summarizeNotifications(app *argocdv1alpha1.Application) []v1alpha1.NotificationDelivery {
+	v, ok := app.Annotations[notifiedAnnotationKey]
+	if !ok {
+		return nil
+	}
+	notified := map[string]int64{}
+	if err := json.Unmarshal([]byte(v), &notified); err != nil {
+		return nil
+	}
+	deliveries := make([]v1alpha1.NotificationDelivery, 0, len(notified))
+	for k, t := range notified {
+		parts := strings.Split(k, ":")
+		if len(parts) < 4 {
+			continue
+		}
+		n := len(parts)
+		deliveries = append(deliveries, v1alpha1.NotificationDelivery{
+			Trigger:     parts[n-4],
+			Service:     parts[n-2],
+			Recipient:   parts[n-1],
+			DeliveredAt: metav1.NewTime(time.Unix(t, 0)),
+		})
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, b := deliveries[i], deliveries[j]
+		if !a.DeliveredAt.Equal(&b.DeliveredAt) {
+			return b.DeliveredAt.Before(&a.DeliveredAt)
+		}
+		return a.Trigger+":"+a.Service+":"+a.Recipient < b.Trigger+":"+b.Service+":"+b.Recipient
+	})
+	if len(deliveries) > maxNotificationDeliveries {
+		deliveries = deliveries[:maxNotificationDeliveries]
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return deliveries
+}
@@ -0,0 +1,48 @@
+package applications
+
+import (
+	"time"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+)
+
+// lastSyncedAt returns the time app last synced successfully according to its operation state and
+// its deployment history, or nil if it never synced
+func lastSyncedAt(app *argocdv1alpha1.Application) *metav1.Time {
+	var last *metav1.Time
+	if op := app.Status.OperationState; op != nil && op.Phase == synccommon.OperationSucceeded && op.FinishedAt != nil {
+		last = op.FinishedAt
+	}
+	for i := range app.Status.History {
+		if t := &app.Status.History[i].DeployedAt; !t.IsZero() && (last == nil || last.Before(t)) {
+			last = t
+		}
+	}
+	return last
+}
+
+// observeOutOfSync reports since when and for how long app has been out of sync according to clk. An
+// application which never synced is out of sync since it was created.
+func observeOutOfSync(status *v1alpha1.ArgoApplicationStatus, app *argocdv1alpha1.Application, clk clock.PassiveClock) {
+	if app.Status.Sync.Status != argocdv1alpha1.SyncStatusCodeOutOfSync {
+		return
+	}
+	since := lastSyncedAt(app)
+	if since == nil {
+		if app.CreationTimestamp.IsZero() {
+			return
+		}
+		since = &app.CreationTimestamp
+	}
+	d := clk.Since(since.Time).Truncate(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	status.OutOfSyncSince = since.DeepCopy()
+	status.OutOfSyncDuration = &metav1.Duration{Duration: d}
+}
@@ -0,0 +1,113 @@
+package applications
+
+import (
+	"encoding/json"
+	"slices"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	jsonpatch "github.com/evanphx/json-patch"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
+)
+
+// applicationFields returns the JSON values of the top-level fields of forProvider which are set in app
+func applicationFields(app *argocdv1alpha1.Application) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(app)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Metadata map[string]json.RawMessage `json:"metadata"`
+		Spec     map[string]json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	values := doc.Spec
+	if values == nil {
+		values = map[string]json.RawMessage{}
+	}
+	for _, f := range applications.MetadataFields {
+		if v, ok := doc.Metadata[f]; ok {
+			values[f] = v
+		}
+	}
+	return values, nil
+}
+
+// applicationDocument returns an application document holding the values of fields
+func applicationDocument(values map[string]json.RawMessage, fields []string) ([]byte, error) {
+	doc := map[string]map[string]json.RawMessage{"metadata": {}, "spec": {}}
+	for _, f := range fields {
+		v, ok := values[f]
+		if !ok {
+			continue
+		}
+		section := "spec"
+		if slices.Contains(applications.MetadataFields, f) {
+			section = "metadata"
+		}
+		doc[section][f] = v
+	}
+	return json.Marshal(doc)
+}
+
+// withUnownedFields returns a copy of observed whose fields not in owned are taken from desired,
+// so that only the owned fields are compared. observed is returned if all fields are owned.
+func withUnownedFields(observed, desired *argocdv1alpha1.Application, owned []string) (*argocdv1alpha1.Application, error) {
+	if len(owned) == 0 {
+		return observed, nil
+	}
+	o, err := applicationFields(observed)
+	if err != nil {
+		return nil, err
+	}
+	d, err := applicationFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	fields := applications.ParameterFields()
+	for _, f := range fields {
+		if slices.Contains(owned, f) {
+			continue
+		}
+		if v, ok := d[f]; ok {
+			o[f] = v
+		} else {
+			delete(o, f)
+		}
+	}
+	doc, err := applicationDocument(o, fields)
+	if err != nil {
+		return nil, err
+	}
+	app := observed.DeepCopy()
+	app.Spec = argocdv1alpha1.ApplicationSpec{}
+	app.Labels, app.Annotations, app.Finalizers = nil, nil, nil
+	return app, json.Unmarshal(doc, app)
+}
+
+// ownedFieldsPatch returns a JSON merge patch which changes the owned fields of observed to the
+// values of desired and leaves all other fields untouched
+func ownedFieldsPatch(observed, desired *argocdv1alpha1.Application, owned []string) ([]byte, error) {
+	if observed == nil {
+		observed = &argocdv1alpha1.Application{}
+	}
+	o, err := applicationFields(observed)
+	if err != nil {
+		return nil, err
+	}
+	d, err := applicationFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	original, err := applicationDocument(o, owned)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := applicationDocument(d, owned)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.CreateMergePatch(original, modified)
+}
@@ -0,0 +1,158 @@
+package applications
+
+import (
+	"slices"
+	"strings"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
+)
+
+// validateApplicationParameters checks the options of all sources that ArgoCD would only reject during manifest generation
+func validateApplicationParameters(p *v1alpha1.ApplicationParameters) error {
+	sources := p.Sources
+	if p.Source != nil {
+		sources = append(v1alpha1.ApplicationSources{*p.Source}, sources...)
+	}
+	for _, s := range sources {
+		if err := validateDirectory(s.Directory); err != nil {
+			return errors.Wrapf(err, "source %s", s.RepoURL)
+		}
+		if err := validatePlugin(s.Plugin); err != nil {
+			return errors.Wrapf(err, "source %s", s.RepoURL)
+		}
+		if err := validateHelm(s.Helm); err != nil {
+			return errors.Wrapf(err, "source %s", s.RepoURL)
+		}
+	}
+	return nil
+}
+
+// validateIgnoreDifferences checks that every managed fields manager is named
+func validateIgnoreDifferences(d []v1alpha1.ResourceIgnoreDifferences) error {
+	for _, r := range d {
+		for i, m := range r.ManagedFieldsManagers {
+			if m == "" {
+				return errors.Errorf("managed fields manager %d of ignored differences of kind %s has no name", i, r.Kind)
+			}
+		}
+	}
+	return nil
+}
+
+// boolSyncOption are the values of the sync options which are switched on or off
+var boolSyncOption = []string{"true", "false"}
+
+// knownSyncOptions are the sync options of an application supported by ArgoCD and their values
+var knownSyncOptions = map[string][]string{
+	"Validate":                 boolSyncOption,
+	"CreateNamespace":          boolSyncOption,
+	"PruneLast":                boolSyncOption,
+	"Replace":                  boolSyncOption,
+	"ServerSideApply":          boolSyncOption,
+	"ApplyOutOfSyncOnly":       boolSyncOption,
+	"RespectIgnoreDifferences": boolSyncOption,
+	"FailOnSharedResource":     boolSyncOption,
+	"PrunePropagationPolicy":   {"foreground", "background", "orphan"},
+}
+
+// validateSyncOptions checks that every sync option is a known option in the format <name>=<value>.
+// ArgoCD matches the options literally, so misspelled options would otherwise be ignored silently.
+func validateSyncOptions(p *v1alpha1.SyncPolicy) error {
+	if p == nil {
+		return nil
+	}
+	for _, o := range p.SyncOptions {
+		name, value, ok := strings.Cut(strings.TrimSpace(o), "=")
+		if !ok {
+			return errors.Errorf("sync option %q must be in the format <name>=<value>", o)
+		}
+		values, known := knownSyncOptions[name]
+		if !known {
+			return errors.Errorf("unknown sync option %q", name)
+		}
+		if !slices.Contains(values, value) {
+			return errors.Errorf("invalid value %q of sync option %s, must be one of %s", value, name, strings.Join(values, ", "))
+		}
+	}
+	return nil
+}
+
+// validateDirectory checks that include and exclude are valid glob patterns
+func validateDirectory(d *v1alpha1.ApplicationSourceDirectory) error {
+	if d == nil {
+		return nil
+	}
+	if d.Include != nil {
+		if _, err := glob.Compile(*d.Include); err != nil {
+			return errors.Wrapf(err, "invalid directory include pattern %q", *d.Include)
+		}
+	}
+	if d.Exclude != nil {
+		if _, err := glob.Compile(*d.Exclude); err != nil {
+			return errors.Wrapf(err, "invalid directory exclude pattern %q", *d.Exclude)
+		}
+	}
+	return nil
+}
+
+// validatePlugin checks that every plugin parameter is named and holds exactly one of string, array or map
+func validatePlugin(p *v1alpha1.ApplicationSourcePlugin) error {
+	if p == nil {
+		return nil
+	}
+	for i, param := range p.Parameters {
+		if param.Name == nil || *param.Name == "" {
+			return errors.Errorf("plugin parameter %d has no name", i)
+		}
+		kinds := 0
+		if param.String_ != nil {
+			kinds++
+		}
+		if param.OptionalArray != nil {
+			kinds++
+		}
+		if param.OptionalMap != nil {
+			kinds++
+		}
+		if kinds != 1 {
+			return errors.Errorf("plugin parameter %s must set exactly one of string, array or map", *param.Name)
+		}
+	}
+	return nil
+}
+
+// validateHelm checks that the values of helm are either set inline or referenced and that the
+// values object is a map of values
+func validateHelm(h *v1alpha1.ApplicationSourceHelm) error {
+	if h == nil {
+		return nil
+	}
+	if _, err := parseValuesObject(applications.ExtV1JSONToRuntimeRawExtension(h.ValuesObject)); err != nil {
+		return errors.Wrap(err, "helm valuesObject must be a map of values")
+	}
+	if h.ValuesRef == nil {
+		return nil
+	}
+	if h.Values != nil {
+		return errors.New("helm values and valuesRef are mutually exclusive")
+	}
+	if len(h.ValuesObject.Raw) != 0 {
+		return errors.New("helm valuesObject and valuesRef are mutually exclusive")
+	}
+	return nil
+}
+
+// validateSourceRepos checks that every source repository of spec is permitted by the sourceRepos of proj
+func validateSourceRepos(proj *argocdv1alpha1.AppProject, spec *argocdv1alpha1.ApplicationSpec) error {
+	for _, s := range spec.GetSources() {
+		if !proj.IsSourcePermitted(s) {
+			return errors.Errorf("source repository %s is not permitted by project %s, add it to the sourceRepos of the project", s.RepoURL, proj.Name)
+		}
+	}
+	return nil
+}
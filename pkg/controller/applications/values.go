@@ -0,0 +1,156 @@
+package applications
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+const (
+	errGetHelmValues        = "cannot get Helm values of Argocd application"
+	errFmtHelmValuesKind    = "unsupported kind %q of Helm values reference"
+	errFmtHelmValuesMissing = "%s %s/%s has no key %q with Helm values"
+
+	// sourceIndex is the key of the helm values of the single source of an application
+	sourceIndex = -1
+
+	defaultHelmValuesKey = "values.yaml"
+)
+
+// resolveHelmValues returns the content of the values referenced by the helm options of the sources of
+// p, keyed by the index of the source in sources, or sourceIndex for source.
+func (e *external) resolveHelmValues(ctx context.Context, p *v1alpha1.ApplicationParameters) (map[int]string, error) {
+	values := map[int]string{}
+	resolve := func(i int, s *v1alpha1.ApplicationSource) error {
+		if s == nil || s.Helm == nil || s.Helm.ValuesRef == nil {
+			return nil
+		}
+		v, err := e.getHelmValues(ctx, s.Helm.ValuesRef)
+		if err != nil {
+			return errors.Wrap(err, errGetHelmValues)
+		}
+		values[i] = v
+		return nil
+	}
+	if err := resolve(sourceIndex, p.Source); err != nil {
+		return nil, err
+	}
+	for i := range p.Sources {
+		if err := resolve(i, &p.Sources[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// getHelmValues reads the key of the ConfigMap or Secret referenced by ref
+func (e *external) getHelmValues(ctx context.Context, ref *v1alpha1.HelmValuesReference) (string, error) {
+	key := ref.Key
+	if key == "" {
+		key = defaultHelmValuesKey
+	}
+	nn := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	switch ref.Kind {
+	case "ConfigMap":
+		cm := &corev1.ConfigMap{}
+		if err := e.kube.Get(ctx, nn, cm); err != nil {
+			return "", err
+		}
+		if v, ok := cm.Data[key]; ok {
+			return v, nil
+		}
+		if v, ok := cm.BinaryData[key]; ok {
+			return string(v), nil
+		}
+	case "Secret":
+		s := &corev1.Secret{}
+		if err := e.kube.Get(ctx, nn, s); err != nil {
+			return "", err
+		}
+		if v, ok := s.Data[key]; ok {
+			return string(v), nil
+		}
+	default:
+		return "", errors.Errorf(errFmtHelmValuesKind, ref.Kind)
+	}
+	return "", errors.Errorf(errFmtHelmValuesMissing, ref.Kind, ref.Namespace, ref.Name, key)
+}
+
+// sourceHelm returns the helm options of the source of spec with index i
+func sourceHelm(spec *argocdv1alpha1.ApplicationSpec, i int) *argocdv1alpha1.ApplicationSourceHelm {
+	switch {
+	case i == sourceIndex && spec.Source != nil:
+		return spec.Source.Helm
+	case i >= 0 && i < len(spec.Sources):
+		return spec.Sources[i].Helm
+	}
+	return nil
+}
+
+// injectHelmValues sets the resolved values as the values of the helm options of the sources of spec
+func injectHelmValues(spec *argocdv1alpha1.ApplicationSpec, values map[int]string) {
+	for i, v := range values {
+		if h := sourceHelm(spec, i); h != nil {
+			h.Values = v
+		}
+	}
+}
+
+// withoutHelmValues returns a copy of app without the values of the sources whose values are resolved
+// from a reference, so that they are only compared by isHelmValuesUpToDate.
+func withoutHelmValues(app *argocdv1alpha1.Application, values map[int]string) *argocdv1alpha1.Application {
+	if len(values) == 0 {
+		return app
+	}
+	app = app.DeepCopy()
+	for i := range values {
+		if h := sourceHelm(&app.Spec, i); h != nil {
+			h.Values = ""
+		}
+	}
+	return app
+}
+
+// isHelmValuesUpToDate compares the hash of the resolved values with the hash of the values of the
+// sources of app.
+func isHelmValuesUpToDate(app *argocdv1alpha1.Application, values map[int]string) bool {
+	for i, v := range values {
+		var remote string
+		if h := sourceHelm(&app.Spec, i); h != nil {
+			remote = h.Values
+		}
+		if clients.Hash([]byte(remote)) != clients.Hash([]byte(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// hashHelmValues returns a hash of all resolved values, or nil if no values are resolved
+func hashHelmValues(values map[int]string) *string {
+	if len(values) == 0 {
+		return nil
+	}
+	keys := make([]int, 0, len(values))
+	for i := range values {
+		keys = append(keys, i)
+	}
+	sort.Ints(keys)
+	var b []byte
+	for _, i := range keys {
+		b = append(b, strconv.Itoa(i)...)
+		b = append(b, 0)
+		b = append(b, values[i]...)
+		b = append(b, 0)
+	}
+	return ptr.To(clients.Hash(b))
+}
@@ -0,0 +1,69 @@
+package applications
+
+import (
+	"sort"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+)
+
+// resourceKey identifies a resource of an application
+type resourceKey struct {
+	group, kind, namespace, name string
+}
+
+// summarizeSyncWaves returns the resources of app summarized by sync wave, lowest wave first,
+// and the lowest wave which is not done yet while an operation is running. Nothing is returned
+// if all resources are in the same wave, as there is no progress through waves to report.
+func summarizeSyncWaves(app *argocdv1alpha1.Application) ([]v1alpha1.SyncWaveSummary, *int64) {
+	waves := map[int64]*v1alpha1.SyncWaveSummary{}
+	for _, r := range app.Status.Resources {
+		if waves[r.SyncWave] == nil {
+			waves[r.SyncWave] = &v1alpha1.SyncWaveSummary{Wave: r.SyncWave}
+		}
+	}
+	if len(waves) < 2 {
+		return nil, nil
+	}
+
+	results := map[resourceKey]synccommon.ResultCode{}
+	op := app.Status.OperationState
+	if op != nil && op.SyncResult != nil {
+		for _, r := range op.SyncResult.Resources {
+			results[resourceKey{r.Group, r.Kind, r.Namespace, r.Name}] = r.Status
+		}
+	}
+	for _, r := range app.Status.Resources {
+		w := waves[r.SyncWave]
+		w.Resources++
+		switch results[resourceKey{r.Group, r.Kind, r.Namespace, r.Name}] {
+		case synccommon.ResultCodeSynced, synccommon.ResultCodePruned:
+			w.Synced++
+		case synccommon.ResultCodeSyncFailed:
+			w.Failed++
+		}
+		if r.Health != nil && r.Health.Status == health.HealthStatusHealthy {
+			w.Healthy++
+		}
+	}
+
+	summary := make([]v1alpha1.SyncWaveSummary, 0, len(waves))
+	for _, w := range waves {
+		summary = append(summary, *w)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Wave < summary[j].Wave })
+
+	if op == nil || op.Phase != synccommon.OperationRunning {
+		return summary, nil
+	}
+	for _, w := range summary {
+		if w.Synced < w.Resources || w.Healthy < w.Resources {
+			return summary, ptr.To(w.Wave)
+		}
+	}
+	return summary, nil
+}
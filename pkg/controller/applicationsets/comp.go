@@ -9,7 +9,9 @@ import (
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applicationsets"
 )
 
-// IsApplicationSetUpToDate converts ApplicationParameters to its ArgoCD Counterpart and returns if they equal
+// IsApplicationSetUpToDate converts ApplicationParameters to its ArgoCD Counterpart and returns if they equal.
+// The template is compared verbatim with the template stored in ArgoCD, never with the applications
+// rendered from it, so generator placeholders like {{.namespace}} don't cause drift.
 func IsApplicationSetUpToDate(cr *v1alpha1.ApplicationSetParameters, remote *argocdv1alpha1.ApplicationSet) bool { // nolint:gocyclo
 	converter := applicationsets.ConverterImpl{}
 	cluster := converter.ToArgoApplicationSetSpec(cr)
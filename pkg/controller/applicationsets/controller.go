@@ -33,10 +33,12 @@ import (
 
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applicationsets/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	appsets "github.com/crossplane-contrib/provider-argocd/pkg/clients/applicationsets"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
@@ -45,6 +47,9 @@ import (
 const (
 	errNotApplicationSet = "managed resource is not a ApplicationSet custom resource"
 	errGetApplicationSet = "failed to GET ApplicationSet with ArgoCD instance"
+	errSyncPolicy        = "invalid sync policy of ApplicationSet"
+
+	errFmtApplicationsSync = "unknown applicationsSync policy %q, must be one of create-only, create-update, create-delete or sync"
 )
 
 // SetupApplicationSet adds a controller that reconciles ApplicationSet managed resources.
@@ -53,10 +58,11 @@ func SetupApplicationSet(mgr ctrl.Manager, o xpcontroller.Options) error {
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: appsets.NewApplicationSetServiceClient}),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: appsets.NewApplicationSetServiceClient}),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 	}
@@ -66,16 +72,16 @@ func SetupApplicationSet(mgr ctrl.Manager, o xpcontroller.Options) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.ApplicationSet{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ApplicationSetGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube              client.Client
 	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, appsets.ServiceClient)
-	conn              io.Closer
 }
 
 // Connect typically produces an ExternalClient by:
@@ -89,23 +95,21 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.New(errNotApplicationSet)
 	}
 
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	// the names are transformed by the ProviderConfig in use, which may be a fallback
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, spec *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		return &external{kube: c.kube, client: argocdClient, names: clients.NameTransformFor(spec)}, conn
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
-
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+	return clients.WithPauseHandling(clients.WithLastErrorRecording(clients.WithPermissionHandling(clients.WithAlreadyExistsHandling(fc), v1alpha1.ApplicationSetKind))), nil
 }
 
 type external struct {
 	kube   client.Client
 	client appsets.ServiceClient
+	names  clients.NameTransform
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -114,18 +118,18 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotApplicationSet)
 	}
 
-	var name = meta.GetExternalName(cr)
-
-	if name == "" {
+	if meta.GetExternalName(cr) == "" {
 		return managed.ExternalObservation{}, nil
 	}
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	query := applicationset.ApplicationSetGetQuery{
 		Name: name,
 	}
 
-	var appset *argov1alpha1.ApplicationSet
-
 	appset, err := e.client.Get(ctx, &query)
 
 	if err != nil && appsets.IsNotFound(err) {
@@ -157,26 +161,52 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotApplicationSet)
 	}
 
-	req := e.generateCreateApplicationSetRequest(cr)
+	req, err := e.generateCreateApplicationSetRequest(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	_, err := e.client.Create(ctx, req)
+	_, err = e.client.Create(ctx, req)
 
 	return managed.ExternalCreation{}, err
 }
 
-func (e *external) generateCreateApplicationSetRequest(cr *v1alpha1.ApplicationSet) *applicationset.ApplicationSetCreateRequest {
+func (e *external) generateCreateApplicationSetRequest(cr *v1alpha1.ApplicationSet) (*applicationset.ApplicationSetCreateRequest, error) {
+	if err := validateSyncPolicy(cr.Spec.ForProvider.SyncPolicy); err != nil {
+		return nil, errors.Wrap(err, errSyncPolicy)
+	}
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return nil, err
+	}
 	converter := &appsets.ConverterImpl{}
 	targetSpec := converter.ToArgoApplicationSetSpec(&cr.Spec.ForProvider)
 
 	req := &applicationset.ApplicationSetCreateRequest{
 		Applicationset: &argov1alpha1.ApplicationSet{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: meta.GetExternalName(cr),
+				Name: name,
 			},
 			Spec: *targetSpec,
 		},
 	}
-	return req
+	return req, nil
+}
+
+// validateSyncPolicy checks the policy applied on the generated applications, which ArgoCD
+// would otherwise treat like sync.
+func validateSyncPolicy(p *v1alpha1.ApplicationSetSyncPolicy) error {
+	if p == nil || p.ApplicationsSync == nil {
+		return nil
+	}
+	switch *p.ApplicationsSync {
+	case v1alpha1.ApplicationsSyncPolicyCreateOnly,
+		v1alpha1.ApplicationsSyncPolicyCreateUpdate,
+		v1alpha1.ApplicationsSyncPolicyCreateDelete,
+		v1alpha1.ApplicationsSyncPolicySync:
+		return nil
+	}
+	return errors.Errorf(errFmtApplicationsSync, *p.ApplicationsSync)
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -185,10 +215,13 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotApplicationSet)
 	}
 
-	req := e.generateCreateApplicationSetRequest(cr)
+	req, err := e.generateCreateApplicationSetRequest(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 	req.Upsert = true
 
-	_, err := e.client.Create(ctx, req)
+	_, err = e.client.Create(ctx, req)
 
 	return managed.ExternalUpdate{}, err
 }
@@ -199,8 +232,12 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotApplicationSet)
 	}
 
-	_, err := e.client.Delete(ctx, &applicationset.ApplicationSetDeleteRequest{
-		Name: meta.GetExternalName(cr),
+	name, err := e.names.Name(meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	_, err = e.client.Delete(ctx, &applicationset.ApplicationSetDeleteRequest{
+		Name: name,
 	})
 	if err != nil {
 		return err
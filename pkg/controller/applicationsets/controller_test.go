@@ -30,6 +30,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -305,6 +306,133 @@ func simpleApplicationSetParameters() v1alpha1.ApplicationSetParameters {
 	}
 }
 
+func TestIsApplicationSetUpToDateTemplatedDestination(t *testing.T) {
+	templated := func(name, namespace string) argocdv1alpha1.ApplicationSetTemplate {
+		return argocdv1alpha1.ApplicationSetTemplate{
+			ApplicationSetTemplateMeta: argocdv1alpha1.ApplicationSetTemplateMeta{Name: name},
+			Spec: argocdv1alpha1.ApplicationSpec{
+				Project:     testProjectName,
+				Destination: argocdv1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: namespace},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		remote argocdv1alpha1.ApplicationSetTemplate
+		want   bool
+	}{
+		"TemplateUnchanged": {
+			remote: templated("{{.cluster}}-guestbook", "{{.namespace}}"),
+			want:   true,
+		},
+		"TemplateRendered": {
+			remote: templated("in-cluster-guestbook", "guestbook"),
+			want:   false,
+		},
+		"PlaceholderChanged": {
+			remote: templated("{{.cluster}}-guestbook", "{{.metadata.labels.namespace}}"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationSetParameters{
+				GoTemplate: true,
+				Template: v1alpha1.ApplicationSetTemplate{
+					ApplicationSetTemplateMeta: v1alpha1.ApplicationSetTemplateMeta{Name: "{{.cluster}}-guestbook"},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Server:    ptr.To("https://kubernetes.default.svc"),
+							Namespace: ptr.To("{{.namespace}}"),
+						},
+					},
+				},
+			}
+			remote := &argocdv1alpha1.ApplicationSet{Spec: argocdv1alpha1.ApplicationSetSpec{GoTemplate: true, Template: tc.remote}}
+			if diff := cmp.Diff(tc.want, IsApplicationSetUpToDate(p, remote)); diff != "" {
+				t.Errorf("IsApplicationSetUpToDate(...): -want, +got:\n%s", diff)
+			}
+
+			// the template is sent to ArgoCD verbatim, ArgoCD renders it per generated application
+			spec := (&applicationsets.ConverterImpl{}).ToArgoApplicationSetSpec(p)
+			if diff := cmp.Diff("{{.namespace}}", spec.Template.Spec.Destination.Namespace); diff != "" {
+				t.Errorf("ToArgoApplicationSetSpec(...): -want namespace, +got namespace:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncPolicy(t *testing.T) {
+	policy := func(preserve bool, sync v1alpha1.ApplicationsSyncPolicy) *v1alpha1.ApplicationSetSyncPolicy {
+		return &v1alpha1.ApplicationSetSyncPolicy{PreserveResourcesOnDeletion: preserve, ApplicationsSync: &sync}
+	}
+
+	type want struct {
+		policy   *argocdv1alpha1.ApplicationSetSyncPolicy
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		policy *v1alpha1.ApplicationSetSyncPolicy
+		remote *argocdv1alpha1.ApplicationSetSyncPolicy
+		want
+	}{
+		"CreateOnly": {
+			policy: policy(false, v1alpha1.ApplicationsSyncPolicyCreateOnly),
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateOnly)}},
+		},
+		"CreateUpdate": {
+			policy: policy(false, v1alpha1.ApplicationsSyncPolicyCreateUpdate),
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateUpdate)}},
+		},
+		"CreateDelete": {
+			policy: policy(false, v1alpha1.ApplicationsSyncPolicyCreateDelete),
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateDelete)}},
+		},
+		"Sync": {
+			policy: policy(false, v1alpha1.ApplicationsSyncPolicySync),
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicySync)}},
+		},
+		"PreserveResourcesOnDeletion": {
+			policy: policy(true, v1alpha1.ApplicationsSyncPolicyCreateUpdate),
+			remote: &argocdv1alpha1.ApplicationSetSyncPolicy{ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateUpdate)},
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{PreserveResourcesOnDeletion: true, ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateUpdate)}},
+		},
+		"Unchanged": {
+			policy: policy(true, v1alpha1.ApplicationsSyncPolicyCreateOnly),
+			remote: &argocdv1alpha1.ApplicationSetSyncPolicy{PreserveResourcesOnDeletion: true, ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateOnly)},
+			want:   want{policy: &argocdv1alpha1.ApplicationSetSyncPolicy{PreserveResourcesOnDeletion: true, ApplicationsSync: ptr.To(argocdv1alpha1.ApplicationsSyncPolicyCreateOnly)}, upToDate: true},
+		},
+		"UnknownPolicy": {
+			policy: policy(false, "create-sync"),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtApplicationsSync, "create-sync"), errSyncPolicy)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := ApplicationSet(withExternalName(testApplicationSetExternalName), withSpec(v1alpha1.ApplicationSetParameters{SyncPolicy: tc.policy}))
+			req, err := (&external{}).generateCreateApplicationSetRequest(cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("generateCreateApplicationSetRequest(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.policy, req.Applicationset.Spec.SyncPolicy); diff != "" {
+				t.Errorf("generateCreateApplicationSetRequest(...): -want policy, +got policy:\n%s", diff)
+			}
+			remote := &argocdv1alpha1.ApplicationSet{Spec: *ArgoAppSpec(func(s *argocdv1alpha1.ApplicationSetSpec) { s.SyncPolicy = tc.remote })}
+			if diff := cmp.Diff(tc.want.upToDate, IsApplicationSetUpToDate(&cr.Spec.ForProvider, remote)); diff != "" {
+				t.Errorf("IsApplicationSetUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.ApplicationSet
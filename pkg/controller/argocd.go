@@ -17,33 +17,131 @@ limitations under the License.
 package controller
 
 import (
+	"strconv"
+	"time"
+
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	applicationsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	applicationsetsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/applicationsets/v1alpha1"
+	clusterv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	projectsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+	settingsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/applications"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/applicationsets"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/cluster"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/cmdparamsconfigs"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/config"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/globalprojects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/projects"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/rbacconfigs"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/repositories"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/resourcefilters"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/resourcehealthchecks"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/resourceignoredifferences"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/tokens"
 )
 
+// setups holds the setup functions of all controllers by the kind of managed
+// resource they reconcile. The ProviderConfig controller has no kind.
+var setups = []struct {
+	kind  string
+	setup func(ctrl.Manager, xpcontroller.Options) error
+}{
+	{"", config.Setup},
+	{repositoriesv1alpha1.RepositoryKind, repositories.SetupRepository},
+	{projectsv1alpha1.ProjectKind, projects.SetupProject},
+	{clusterv1alpha1.ClusterKind, cluster.SetupCluster},
+	{applicationsv1alpha1.ApplicationKind, applications.SetupApplication},
+	{applicationsetsv1alpha1.ApplicationSetKind, applicationsets.SetupApplicationSet},
+	{projectsv1alpha1.TokenKind, tokens.SetupToken},
+	{settingsv1alpha1.GlobalProjectKind, globalprojects.SetupGlobalProject},
+	{settingsv1alpha1.RBACConfigKind, rbacconfigs.SetupRBACConfig},
+	{settingsv1alpha1.ResourceHealthCheckKind, resourcehealthchecks.SetupResourceHealthCheck},
+	{settingsv1alpha1.ResourceFilterKind, resourcefilters.SetupResourceFilter},
+	{settingsv1alpha1.ResourceIgnoreDifferenceKind, resourceignoredifferences.SetupResourceIgnoreDifference},
+	{settingsv1alpha1.CmdParamsConfigKind, cmdparamsconfigs.SetupCmdParamsConfig},
+}
+
 // Setup creates all argocd API controllers with the supplied logger and adds
-// them to the supplied manager.
-func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
-	for _, setup := range []func(ctrl.Manager, xpcontroller.Options) error{
-		config.Setup,
-		repositories.SetupRepository,
-		projects.SetupProject,
-		cluster.SetupCluster,
-		applications.SetupApplication,
-		applicationsets.SetupApplicationSet,
-		tokens.SetupToken,
-	} {
-		if err := setup(mgr, o); err != nil {
+// them to the supplied manager. Resources of the kinds in pollIntervals are
+// polled at that interval once they are up to date instead of o.PollInterval.
+// The controllers of the kinds in maxConcurrentReconciles run that many
+// reconciles concurrently instead of o.MaxConcurrentReconciles. All controllers
+// share the global rate limiter of o.
+func Setup(mgr ctrl.Manager, o xpcontroller.Options, pollIntervals map[string]time.Duration, maxConcurrentReconciles map[string]int) error {
+	for _, s := range setups {
+		if err := s.setup(mgr, optionsFor(o, pollIntervals, maxConcurrentReconciles, s.kind)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// optionsFor returns o with the poll interval and the maximum of concurrent
+// reconciles configured for kind, if any.
+func optionsFor(o xpcontroller.Options, pollIntervals map[string]time.Duration, maxConcurrentReconciles map[string]int, kind string) xpcontroller.Options {
+	if kind == "" {
+		return o
+	}
+	if d, ok := pollIntervals[kind]; ok {
+		o.PollInterval = d
+	}
+	if n, ok := maxConcurrentReconciles[kind]; ok {
+		o.MaxConcurrentReconciles = n
+	}
+	return o
+}
+
+// ParsePollIntervals parses the poll intervals of managed resource kinds, e.g.
+// Project=10m, and checks that every kind is reconciled by a controller.
+func ParsePollIntervals(in map[string]string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration, len(in))
+	for kind, v := range in {
+		if !isManagedKind(kind) {
+			return nil, errors.Errorf("unknown kind %q", kind)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid poll interval of %s", kind)
+		}
+		if d <= 0 {
+			return nil, errors.Errorf("poll interval of %s must be positive", kind)
+		}
+		out[kind] = d
+	}
+	return out, nil
+}
+
+// ParseMaxConcurrentReconciles parses the maximum of concurrent reconciles of
+// managed resource kinds, e.g. Application=20, and checks that every kind is
+// reconciled by a controller.
+func ParseMaxConcurrentReconciles(in map[string]string) (map[string]int, error) {
+	out := make(map[string]int, len(in))
+	for kind, v := range in {
+		if !isManagedKind(kind) {
+			return nil, errors.Errorf("unknown kind %q", kind)
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maximum of concurrent reconciles of %s", kind)
+		}
+		if n <= 0 {
+			return nil, errors.Errorf("maximum of concurrent reconciles of %s must be positive", kind)
+		}
+		out[kind] = n
+	}
+	return out, nil
+}
+
+func isManagedKind(kind string) bool {
+	for _, s := range setups {
+		if s.kind != "" && s.kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParsePollIntervals(t *testing.T) {
+	cases := map[string]struct {
+		in      map[string]string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		"Empty": {
+			want: map[string]time.Duration{},
+		},
+		"PerKind": {
+			in:   map[string]string{"Project": "10m", "Application": "1m"},
+			want: map[string]time.Duration{"Project": 10 * time.Minute, "Application": time.Minute},
+		},
+		"UnknownKind": {
+			in:      map[string]string{"AppProject": "10m"},
+			wantErr: true,
+		},
+		"InvalidDuration": {
+			in:      map[string]string{"Project": "often"},
+			wantErr: true,
+		},
+		"NotPositive": {
+			in:      map[string]string{"Project": "0s"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePollIntervals(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParsePollIntervals(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ParsePollIntervals(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseMaxConcurrentReconciles(t *testing.T) {
+	cases := map[string]struct {
+		in      map[string]string
+		want    map[string]int
+		wantErr bool
+	}{
+		"Empty": {
+			want: map[string]int{},
+		},
+		"PerKind": {
+			in:   map[string]string{"Application": "20", "Project": "5"},
+			want: map[string]int{"Application": 20, "Project": 5},
+		},
+		"UnknownKind": {
+			in:      map[string]string{"AppProject": "5"},
+			wantErr: true,
+		},
+		"InvalidNumber": {
+			in:      map[string]string{"Application": "many"},
+			wantErr: true,
+		},
+		"NotPositive": {
+			in:      map[string]string{"Application": "0"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseMaxConcurrentReconciles(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseMaxConcurrentReconciles(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ParseMaxConcurrentReconciles(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOptionsFor(t *testing.T) {
+	intervals := map[string]time.Duration{"Project": 10 * time.Minute}
+	reconciles := map[string]int{"Application": 20}
+	o := xpcontroller.Options{PollInterval: time.Minute, MaxConcurrentReconciles: 10}
+
+	type want struct {
+		pollInterval            time.Duration
+		maxConcurrentReconciles int
+	}
+
+	cases := map[string]struct {
+		kind string
+		want want
+	}{
+		"PollIntervalConfigured": {kind: "Project", want: want{pollInterval: 10 * time.Minute, maxConcurrentReconciles: 10}},
+		"ConcurrencyConfigured":  {kind: "Application", want: want{pollInterval: time.Minute, maxConcurrentReconciles: 20}},
+		"Default":                {kind: "Repository", want: want{pollInterval: time.Minute, maxConcurrentReconciles: 10}},
+		"ProviderConfig":         {kind: "", want: want{pollInterval: time.Minute, maxConcurrentReconciles: 10}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := optionsFor(o, intervals, reconciles, tc.kind)
+			if diff := cmp.Diff(tc.want.pollInterval, got.PollInterval); diff != "" {
+				t.Errorf("optionsFor(...).PollInterval: -want, +got:\n%s", diff)
+			}
+			// the controller of kind is built with the controller-runtime options of got
+			if diff := cmp.Diff(tc.want.maxConcurrentReconciles, got.ForControllerRuntime().MaxConcurrentReconciles); diff != "" {
+				t.Errorf("optionsFor(...).ForControllerRuntime().MaxConcurrentReconciles: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
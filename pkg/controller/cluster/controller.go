@@ -19,6 +19,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	argocdcluster "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
@@ -38,10 +39,12 @@ import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/cluster"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
@@ -53,6 +56,7 @@ const (
 	errCreateFailed    = "cannot create Argocd Cluster"
 	errUpdateFailed    = "cannot update Argocd Cluster"
 	errDeleteFailed    = "cannot delete Argocd Cluster"
+	errDeleteInCluster = "refusing to delete the in-cluster destination of Argocd, set the deletionPolicy to Orphan to delete the managed resource only"
 	errGetSecretFailed = "cannot get Kubernetes secret"
 	errFmtKeyNotFound  = "key %s is not found in referenced Kubernetes secret"
 	errParseKubeconfig = "unable to parse kubeconfig"
@@ -62,8 +66,9 @@ const (
 func SetupCluster(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.ClusterKind)
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: cluster.NewClusterServiceClient}),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: cluster.NewClusterServiceClient}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 	}
 	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
@@ -71,16 +76,16 @@ func SetupCluster(mgr ctrl.Manager, o xpcontroller.Options) error {
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Cluster{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ClusterGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, argocdcluster.ClusterServiceClient)
-	conn              io.Closer
+	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, cluster.ServiceClient)
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -88,17 +93,14 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotCluster)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, _ *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		return &external{kube: c.kube, client: argocdClient}, conn
+	})
 	if err != nil {
 		return nil, err
 	}
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
-
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+	return clients.WithPauseHandling(clients.WithLastErrorRecording(clients.WithPermissionHandling(clients.WithAlreadyExistsHandling(fc), v1alpha1.ClusterKind))), nil
 }
 
 type external struct {
@@ -160,8 +162,11 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, err
 	}
 	currentStatusAtProvider := cr.Status.AtProvider.DeepCopy()
-	cr.Status.AtProvider = generateClusterObservation(observedCluster, kubeconfigSecretResourceVersion)
-	cr.Status.SetConditions(xpv1.Available())
+	cr.Status.AtProvider = generateClusterObservation(observedCluster, kubeconfigSecretResourceVersion, currentStatusAtProvider.TLSClientConfig)
+	if cluster.IsInCluster(observedCluster.Server) {
+		cr.Status.AtProvider.InCluster = ptr.To(true)
+	}
+	cr.Status.SetConditions(clusterAvailability(observedCluster))
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
@@ -187,6 +192,7 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	meta.SetExternalName(cr, resp.Name)
+	cr.Status.AtProvider.TLSClientConfig = generateTLSClientConfigObservation(&clusterCreateRequest.Cluster.Config.TLSClientConfig)
 
 	return managed.ExternalCreation{}, nil
 }
@@ -202,9 +208,12 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 
-	_, err = e.client.Update(ctx, clusterUpdateRequest)
+	if _, err := e.client.Update(ctx, clusterUpdateRequest); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	cr.Status.AtProvider.TLSClientConfig = generateTLSClientConfigObservation(&clusterUpdateRequest.Cluster.Config.TLSClientConfig)
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -212,6 +221,10 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotCluster)
 	}
+	// the in-cluster destination can't be removed from ArgoCD, deleting it resets it instead
+	if cluster.IsInCluster(ptr.Deref(cr.Spec.ForProvider.Server, "")) || ptr.Deref(cr.Status.AtProvider.InCluster, false) {
+		return errors.New(errDeleteInCluster)
+	}
 
 	clusterQuery := argocdcluster.ClusterQuery{
 		Server: *cr.Spec.ForProvider.Server,
@@ -236,6 +249,10 @@ func lateInitializeCluster(p *v1alpha1.ClusterParameters, r *argocdv1alpha1.Clus
 		p.Shard = r.Shard
 	}
 
+	if p.ClusterResources == nil && r.ClusterResources {
+		p.ClusterResources = &r.ClusterResources
+	}
+
 	if p.Server == nil {
 		p.Server = &r.Server
 	}
@@ -246,7 +263,7 @@ func lateInitializeCluster(p *v1alpha1.ClusterParameters, r *argocdv1alpha1.Clus
 
 }
 
-func generateClusterObservation(r *argocdv1alpha1.Cluster, kubeconfigSecretResourceVersion string) v1alpha1.ClusterObservation {
+func generateClusterObservation(r *argocdv1alpha1.Cluster, kubeconfigSecretResourceVersion string, tlsClientConfig *v1alpha1.TLSClientConfigObservation) v1alpha1.ClusterObservation {
 	if r == nil {
 		return v1alpha1.ClusterObservation{}
 	}
@@ -262,6 +279,7 @@ func generateClusterObservation(r *argocdv1alpha1.Cluster, kubeconfigSecretResou
 			},
 			ApplicationsCount: r.Info.ApplicationsCount,
 		},
+		TLSClientConfig: tlsClientConfig,
 	}
 
 	if kubeconfigSecretResourceVersion != "" {
@@ -273,6 +291,27 @@ func generateClusterObservation(r *argocdv1alpha1.Cluster, kubeconfigSecretResou
 	return o
 }
 
+// clusterAvailability returns the Ready condition of a cluster, which is unavailable
+// while ArgoCD fails to connect to it.
+func clusterAvailability(r *argocdv1alpha1.Cluster) xpv1.Condition {
+	if s := r.Info.ConnectionState; s.Status == argocdv1alpha1.ConnectionStatusFailed {
+		return xpv1.Unavailable().WithMessage(s.Message)
+	}
+	return xpv1.Available()
+}
+
+// generateTLSClientConfigObservation records the hashes of the TLS data applied with c
+func generateTLSClientConfigObservation(c *argocdv1alpha1.TLSClientConfig) *v1alpha1.TLSClientConfigObservation {
+	o := &v1alpha1.TLSClientConfigObservation{
+		CADataHash:   clients.HashTLSData(c.CAData),
+		CertDataHash: clients.HashTLSData(c.CertData),
+	}
+	if o.CADataHash == "" && o.CertDataHash == "" {
+		return nil
+	}
+	return o
+}
+
 func (e *external) generateCreateClusterOptions(ctx context.Context, p *v1alpha1.Cluster) (*argocdcluster.ClusterCreateRequest, error) {
 	argoCluster, err := e.convertClusterTypes(ctx, &p.Spec.ForProvider)
 	clusterCreateRequest := &argocdcluster.ClusterCreateRequest{
@@ -351,6 +390,10 @@ func (e *external) convertClusterTypes(ctx context.Context, p *v1alpha1.ClusterP
 		argoCluster.Shard = p.Shard
 	}
 
+	if p.ClusterResources != nil {
+		argoCluster.ClusterResources = *p.ClusterResources
+	}
+
 	if p.Project != nil {
 		argoCluster.Project = *p.Project
 	}
@@ -384,11 +427,13 @@ func isClusterUpToDate(cr *v1alpha1.Cluster, o *v1alpha1.ClusterObservation, r *
 	}
 	switch {
 	case !isEqualConfig(&p.Config, &r.Config),
-		!cmp.Equal(p.Namespaces, r.Namespaces),
+		!isEqualNamespaces(p.Namespaces, r.Namespaces),
 		!cmp.Equal(p.Shard, r.Shard),
+		clients.BoolValue(p.ClusterResources) != r.ClusterResources,
 		!cmp.Equal(p.Labels, r.Labels),
 		!cmp.Equal(p.Annotations, r.Annotations),
-		!cmp.Equal(cr.Status.AtProvider.Kubeconfig, o.Kubeconfig):
+		!cmp.Equal(cr.Status.AtProvider.Kubeconfig, o.Kubeconfig),
+		!isEqualTLSData(o.TLSClientConfig, &r.Config.TLSClientConfig):
 		return false
 	}
 
@@ -412,6 +457,36 @@ func isEqualConfig(p *v1alpha1.ClusterConfig, r *argocdv1alpha1.ClusterConfig) b
 	return true
 }
 
+// isEqualNamespaces compares the namespaces of a cluster as a set. An empty list means
+// all namespaces, regardless of whether ArgoCD returns it as nil or empty.
+func isEqualNamespaces(p, r []string) bool {
+	return cmp.Equal(normalizeNamespaces(p), normalizeNamespaces(r))
+}
+
+// normalizeNamespaces returns the sorted and deduplicated namespaces ns or nil if there are none
+func normalizeNamespaces(ns []string) []string {
+	if len(ns) == 0 {
+		return nil
+	}
+	out := slices.Clone(ns)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// isEqualTLSData compares the TLS data returned by ArgoCD with the applied TLS data by their normalized hashes,
+// as ArgoCD may return it re-encoded. KeyData is never returned and can't be compared.
+func isEqualTLSData(applied *v1alpha1.TLSClientConfigObservation, r *argocdv1alpha1.TLSClientConfig) bool {
+	if applied == nil {
+		return true
+	}
+	switch {
+	case len(r.CAData) > 0 && clients.HashTLSData(r.CAData) != applied.CADataHash,
+		len(r.CertData) > 0 && clients.HashTLSData(r.CertData) != applied.CertDataHash:
+		return false
+	}
+	return true
+}
+
 func isEqualTLSConfig(p *v1alpha1.TLSClientConfig, r *argocdv1alpha1.TLSClientConfig) bool {
 	if p == nil && r == nil {
 		return true
@@ -423,7 +498,8 @@ func isEqualTLSConfig(p *v1alpha1.TLSClientConfig, r *argocdv1alpha1.TLSClientCo
 	if p != nil && r != nil {
 		switch {
 		case p.Insecure != r.Insecure,
-			p.ServerName != nil && *p.ServerName != r.ServerName:
+			// an empty server name clears the one set in ArgoCD
+			ptr.Deref(p.ServerName, "") != r.ServerName:
 			return false
 		}
 	}
@@ -18,11 +18,16 @@ package cluster
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	argocdCluster "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
@@ -34,6 +39,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/cluster"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/cluster"
 )
@@ -45,6 +51,10 @@ var (
 	testClusterServer       = "https://example.com/"
 	testNamespaces          = [1]string{"default"}
 	testUsername            = "testuser"
+	testCAData              = "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIUY2FkYXRh\n-----END CERTIFICATE-----\n"
+	testCertData            = "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIUY2VydA==\n-----END CERTIFICATE-----\n"
+	testServerVersion       = "1.29"
+	testModifiedAt          = metav1.NewTime(time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC))
 )
 
 type args struct {
@@ -166,6 +176,162 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"TLSDataReencodedUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Config: argocdv1alpha1.ClusterConfig{
+								TLSClientConfig: argocdv1alpha1.TLSClientConfig{
+									Insecure: true,
+									CAData:   []byte(base64.StdEncoding.EncodeToString([]byte(testCAData))),
+									CertData: []byte(strings.ReplaceAll(testCertData, "\n", "\r\n")),
+								},
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+					withObservation(v1alpha1.ClusterObservation{
+						TLSClientConfig: &v1alpha1.TLSClientConfigObservation{
+							CADataHash:   clients.HashTLSData([]byte(testCAData)),
+							CertDataHash: clients.HashTLSData([]byte(testCertData)),
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{},
+							ServerVersion:   new(string),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+							ApplicationsCount: 0,
+						},
+						TLSClientConfig: &v1alpha1.TLSClientConfigObservation{
+							CADataHash:   clients.HashTLSData([]byte(testCAData)),
+							CertDataHash: clients.HashTLSData([]byte(testCertData)),
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"TLSDataChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Config: argocdv1alpha1.ClusterConfig{
+								TLSClientConfig: argocdv1alpha1.TLSClientConfig{
+									Insecure: true,
+									CAData:   []byte(testCertData),
+									CertData: []byte(testCertData),
+								},
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+					withObservation(v1alpha1.ClusterObservation{
+						TLSClientConfig: &v1alpha1.TLSClientConfigObservation{
+							CADataHash:   clients.HashTLSData([]byte(testCAData)),
+							CertDataHash: clients.HashTLSData([]byte(testCertData)),
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{},
+							ServerVersion:   new(string),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+							ApplicationsCount: 0,
+						},
+						TLSClientConfig: &v1alpha1.TLSClientConfigObservation{
+							CADataHash:   clients.HashTLSData([]byte(testCAData)),
+							CertDataHash: clients.HashTLSData([]byte(testCertData)),
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
 		"SuccessfulLateInitialize": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -347,6 +513,126 @@ func TestObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"ConnectionSuccessful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Info: argocdv1alpha1.ClusterInfo{
+								ConnectionState: argocdv1alpha1.ConnectionState{
+									Status:     "Successful",
+									Message:    "",
+									ModifiedAt: &testModifiedAt,
+								},
+								ServerVersion: testServerVersion,
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{
+								Status:     "Successful",
+								Message:    "",
+								ModifiedAt: &testModifiedAt,
+							},
+							ServerVersion: ptr.To(testServerVersion),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ConnectionFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Info: argocdv1alpha1.ClusterInfo{
+								ConnectionState: argocdv1alpha1.ConnectionState{
+									Status:     "Failed",
+									Message:    "dial tcp: i/o timeout",
+									ModifiedAt: &testModifiedAt,
+								},
+								ServerVersion: testServerVersion,
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+					}),
+					withConditions(xpv1.Unavailable().WithMessage("dial tcp: i/o timeout")),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{
+								Status:     "Failed",
+								Message:    "dial tcp: i/o timeout",
+								ModifiedAt: &testModifiedAt,
+							},
+							ServerVersion: ptr.To(testServerVersion),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -434,6 +720,54 @@ func TestCreate(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessfulClusterResourcesAndProject": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdCluster.ClusterCreateRequest{
+							Cluster: &argocdv1alpha1.Cluster{
+								Server:           testClusterServer,
+								Name:             testClusterExternalName,
+								Namespaces:       testNamespaces[:],
+								ClusterResources: true,
+								Project:          "team-a",
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server:           testClusterServer,
+							Name:             testClusterExternalName,
+							Namespaces:       testNamespaces[:],
+							ClusterResources: true,
+							Project:          "team-a",
+						}, nil)
+				}),
+				cr: Cluster(
+					withSpec(v1alpha1.ClusterParameters{
+						Server:           ptr.To(testClusterServer),
+						Name:             ptr.To(testClusterExternalName),
+						Namespaces:       testNamespaces[:],
+						ClusterResources: ptr.To(true),
+						Project:          ptr.To("team-a"),
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server:           ptr.To(testClusterServer),
+						Name:             ptr.To(testClusterExternalName),
+						Namespaces:       testNamespaces[:],
+						ClusterResources: ptr.To(true),
+						Project:          ptr.To("team-a"),
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
 		"CreateSystemFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -501,6 +835,163 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestIsClusterUpToDateScoping(t *testing.T) {
+	cases := map[string]struct {
+		clusterResources *bool
+		project          *string
+		remote           argocdv1alpha1.Cluster
+		want             bool
+	}{
+		"Unset":                    {want: true},
+		"ClusterResourcesEnabled":  {clusterResources: ptr.To(true), want: false},
+		"ClusterResourcesApplied":  {clusterResources: ptr.To(true), remote: argocdv1alpha1.Cluster{ClusterResources: true}, want: true},
+		"ClusterResourcesDisabled": {clusterResources: ptr.To(false), remote: argocdv1alpha1.Cluster{ClusterResources: true}, want: false},
+		"ScopedToProject":          {project: ptr.To("team-a"), want: false},
+		"ProjectApplied":           {project: ptr.To("team-a"), remote: argocdv1alpha1.Cluster{Project: "team-a"}, want: true},
+		"ProjectChanged":           {project: ptr.To("team-b"), remote: argocdv1alpha1.Cluster{Project: "team-a"}, want: false},
+		"ProjectUnscoped":          {remote: argocdv1alpha1.Cluster{Project: "team-a"}, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Cluster(withSpec(v1alpha1.ClusterParameters{
+				Server:           ptr.To(testClusterServer),
+				ClusterResources: tc.clusterResources,
+				Project:          tc.project,
+			}))
+			tc.remote.Server = testClusterServer
+			got := isClusterUpToDate(cr, &cr.Status.AtProvider, &tc.remote)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("isClusterUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServerName(t *testing.T) {
+	cases := map[string]struct {
+		serverName *string
+		remote     string
+		upToDate   bool
+		want       string
+	}{
+		"Set":           {serverName: ptr.To("cluster.internal"), upToDate: false, want: "cluster.internal"},
+		"Applied":       {serverName: ptr.To("cluster.internal"), remote: "cluster.internal", upToDate: true, want: "cluster.internal"},
+		"Changed":       {serverName: ptr.To("cluster.internal"), remote: "proxy.internal", upToDate: false, want: "cluster.internal"},
+		"Cleared":       {serverName: ptr.To(""), remote: "cluster.internal", upToDate: false, want: ""},
+		"Unset":         {remote: "cluster.internal", upToDate: false, want: ""},
+		"NeverSet":      {upToDate: true, want: ""},
+		"ClearedBefore": {serverName: ptr.To(""), upToDate: true, want: ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Cluster(
+				withSpec(v1alpha1.ClusterParameters{
+					Server: ptr.To(testClusterServer),
+					Name:   ptr.To(testClusterExternalName),
+					Config: v1alpha1.ClusterConfig{TLSClientConfig: &v1alpha1.TLSClientConfig{ServerName: tc.serverName}},
+				}),
+				withExternalName(testClusterExternalName),
+			)
+			remote := &argocdv1alpha1.Cluster{Server: testClusterServer, Config: argocdv1alpha1.ClusterConfig{TLSClientConfig: argocdv1alpha1.TLSClientConfig{ServerName: tc.remote}}}
+			if diff := cmp.Diff(tc.upToDate, isClusterUpToDate(cr, &cr.Status.AtProvider, remote)); diff != "" {
+				t.Errorf("isClusterUpToDate(...): -want, +got:\n%s", diff)
+			}
+
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *argocdCluster.ClusterUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.Cluster, error) {
+						if diff := cmp.Diff(tc.want, req.Cluster.Config.TLSClientConfig.ServerName); diff != "" {
+							t.Errorf("Update(...): -want server name, +got server name:\n%s", diff)
+						}
+						return req.Cluster, nil
+					}).Times(1)
+			})
+			e := &external{client: client}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Errorf("Update(...): unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestIsClusterUpToDateNamespaces(t *testing.T) {
+	cases := map[string]struct {
+		namespaces []string
+		remote     []string
+		want       bool
+	}{
+		"AllNamespaces":     {want: true},
+		"EmptyIsAll":        {namespaces: []string{}, want: true},
+		"Equal":             {namespaces: []string{"team-a", "team-b"}, remote: []string{"team-a", "team-b"}, want: true},
+		"OrderChanged":      {namespaces: []string{"team-b", "team-a"}, remote: []string{"team-a", "team-b"}, want: true},
+		"DuplicateIgnored":  {namespaces: []string{"team-a", "team-a"}, remote: []string{"team-a"}, want: true},
+		"Restricted":        {namespaces: []string{"team-a"}, want: false},
+		"NamespaceAdded":    {namespaces: []string{"team-a", "team-b"}, remote: []string{"team-a"}, want: false},
+		"NamespaceRemoved":  {namespaces: []string{"team-a"}, remote: []string{"team-a", "team-b"}, want: false},
+		"WidenedToAll":      {namespaces: []string{}, remote: []string{"team-a"}, want: false},
+		"NamespaceReplaced": {namespaces: []string{"team-b"}, remote: []string{"team-a"}, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Cluster(withSpec(v1alpha1.ClusterParameters{
+				Server:     ptr.To(testClusterServer),
+				Namespaces: tc.namespaces,
+			}))
+			remote := &argocdv1alpha1.Cluster{Server: testClusterServer, Namespaces: tc.remote}
+			got := isClusterUpToDate(cr, &cr.Status.AtProvider, remote)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("isClusterUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateNamespaces(t *testing.T) {
+	cases := map[string]struct {
+		namespaces []string
+		want       []string
+	}{
+		"Restrict": {
+			namespaces: []string{"team-a", "team-b"},
+			want:       []string{"team-a", "team-b"},
+		},
+		"Widen": {
+			namespaces: []string{},
+			want:       []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// the cluster is updated in place, it's neither deleted nor created again
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *argocdCluster.ClusterUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.Cluster, error) {
+						if diff := cmp.Diff(tc.want, req.Cluster.Namespaces); diff != "" {
+							t.Errorf("Update(...): -want namespaces, +got namespaces:\n%s", diff)
+						}
+						return req.Cluster, nil
+					}).Times(1)
+			})
+			cr := Cluster(
+				withSpec(v1alpha1.ClusterParameters{
+					Server:     ptr.To(testClusterServer),
+					Name:       ptr.To(testClusterExternalName),
+					Namespaces: tc.namespaces,
+				}),
+				withExternalName(testClusterExternalName),
+			)
+			e := &external{client: client}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Errorf("Update(...): unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.Cluster
@@ -673,6 +1164,54 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errBoom, errDeleteFailed),
 			},
 		},
+		"InClusterRefused": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Cluster(
+					withExternalName("in-cluster"),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To("https://kubernetes.default.svc/"),
+						Name:   ptr.To("in-cluster"),
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName("in-cluster"),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To("https://kubernetes.default.svc/"),
+						Name:   ptr.To("in-cluster"),
+					}),
+				),
+				err: errors.New(errDeleteInCluster),
+			},
+		},
+		"ObservedInClusterRefused": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Cluster(
+					withExternalName("in-cluster"),
+					withSpec(v1alpha1.ClusterParameters{
+						Name: ptr.To("in-cluster"),
+					}),
+					withObservation(v1alpha1.ClusterObservation{
+						InCluster: ptr.To(true),
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName("in-cluster"),
+					withSpec(v1alpha1.ClusterParameters{
+						Name: ptr.To("in-cluster"),
+					}),
+					withObservation(v1alpha1.ClusterObservation{
+						InCluster: ptr.To(true),
+					}),
+				),
+				err: errors.New(errDeleteInCluster),
+			},
+		},
 	}
 
 	for name, tc := range cases {
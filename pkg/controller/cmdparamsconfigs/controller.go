@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdparamsconfigs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/features"
+)
+
+const (
+	errNotCmdParamsConfig = "managed resource is not a Argocd command parameters config custom resource"
+	errUpdateConfigMap    = "cannot update ArgoCD command parameters ConfigMap"
+)
+
+// tunables is the allowlist of the keys of argocd-cmd-params-cm which are managed,
+// by the field of the parameters holding their value. Other keys such as the TLS
+// or authentication settings of the components are never touched.
+var tunables = []struct {
+	key   string
+	value func(p v1alpha1.CmdParamsConfigParameters) *int32
+}{
+	{settings.ControllerStatusProcessorsKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ControllerStatusProcessors }},
+	{settings.ControllerOperationProcessorsKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ControllerOperationProcessors }},
+	{settings.ControllerRepoServerTimeoutSecondsKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ControllerRepoServerTimeoutSeconds }},
+	{settings.ControllerSelfHealTimeoutSecondsKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ControllerSelfHealTimeoutSeconds }},
+	{settings.ControllerKubectlParallelismLimitKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ControllerKubectlParallelismLimit }},
+	{settings.ServerRepoServerTimeoutSecondsKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.ServerRepoServerTimeoutSeconds }},
+	{settings.RepoServerParallelismLimitKey, func(p v1alpha1.CmdParamsConfigParameters) *int32 { return p.RepoServerParallelismLimit }},
+}
+
+// SetupCmdParamsConfig adds a controller that reconciles command parameters configs.
+func SetupCmdParamsConfig(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.CmdParamsConfigKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), reader: mgr.GetAPIReader()}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(5 * time.Minute),
+	}
+
+	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CmdParamsConfig{}).
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CmdParamsConfigGroupVersionKind),
+			opts...), o.GlobalRateLimiter))
+}
+
+// The command parameters are stored in the argocd-cmd-params-cm ConfigMap, see package settings.
+type connector struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.CmdParamsConfig); !ok {
+		return nil, errors.New(errNotCmdParamsConfig)
+	}
+	if err := clients.TrackProviderConfigUsage(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	if err := settings.CheckInCluster(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	return clients.WithPauseHandling(&external{kube: c.kube, reader: c.reader}), nil
+}
+
+type external struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CmdParamsConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCmdParamsConfig)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.CmdParamsConfigMapName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	params := managedParams(cr.Spec.ForProvider)
+	if len(params) > 0 && !hasManagedKeys(cm, params) {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isCmdParamsUpToDate(params, cm),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CmdParamsConfig)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCmdParamsConfig)
+	}
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CmdParamsConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCmdParamsConfig)
+	}
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CmdParamsConfig)
+	if !ok {
+		return errors.New(errNotCmdParamsConfig)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.CmdParamsConfigMapName)
+	if err != nil {
+		return err
+	}
+	params := managedParams(cr.Spec.ForProvider)
+	if !hasManagedKeys(cm, params) {
+		return nil
+	}
+	for k := range params {
+		delete(cm.Data, k)
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func (e *external) apply(ctx context.Context, cr *v1alpha1.CmdParamsConfig) error {
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.CmdParamsConfigMapName)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for k, v := range managedParams(cr.Spec.ForProvider) {
+		cm.Data[k] = v
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+// managedParams renders the tunables set in p into the values of their keys.
+// Tunables which aren't set are not managed.
+func managedParams(p v1alpha1.CmdParamsConfigParameters) map[string]string {
+	params := map[string]string{}
+	for _, t := range tunables {
+		if v := t.value(p); v != nil {
+			params[t.key] = strconv.FormatInt(int64(*v), 10)
+		}
+	}
+	return params
+}
+
+// hasManagedKeys reports whether cm holds any of the managed params.
+func hasManagedKeys(cm *corev1.ConfigMap, params map[string]string) bool {
+	for k := range params {
+		if _, ok := cm.Data[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isCmdParamsUpToDate compares the managed params with the values of cm. All
+// other keys of cm are ignored. Values are compared as numbers, so that e.g.
+// quoted or padded values in the ConfigMap aren't reported as a difference.
+func isCmdParamsUpToDate(params map[string]string, cm *corev1.ConfigMap) bool {
+	for k, v := range params {
+		if normalizeParam(v) != normalizeParam(cm.Data[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeParam(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), `"'`)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return strconv.FormatInt(i, 10)
+	}
+	return s
+}
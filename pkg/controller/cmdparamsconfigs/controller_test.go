@@ -0,0 +1,378 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdparamsconfigs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom    = errors.New("boom")
+	testParams = v1alpha1.CmdParamsConfigParameters{
+		ControllerStatusProcessors:         ptr.To[int32](50),
+		ControllerRepoServerTimeoutSeconds: ptr.To[int32](180),
+		RepoServerParallelismLimit:         ptr.To[int32](10),
+	}
+	testData = map[string]string{
+		settings.ControllerStatusProcessorsKey:         "50",
+		settings.ControllerRepoServerTimeoutSecondsKey: "180",
+		settings.RepoServerParallelismLimitKey:         "10",
+	}
+	// unmanagedData holds keys of argocd-cmd-params-cm which are never managed
+	unmanagedData = map[string]string{
+		"server.insecure":               "true",
+		"controller.log.level":          "debug",
+		"reposerver.log.format":         "json",
+		"controller.sharding.algorithm": "round-robin",
+	}
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.CmdParamsConfig
+}
+
+func CmdParamsConfig(m ...CmdParamsConfigModifier) *v1alpha1.CmdParamsConfig {
+	cr := &v1alpha1.CmdParamsConfig{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type CmdParamsConfigModifier func(*v1alpha1.CmdParamsConfig)
+
+func withSpec(p v1alpha1.CmdParamsConfigParameters) CmdParamsConfigModifier {
+	return func(r *v1alpha1.CmdParamsConfig) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) CmdParamsConfigModifier {
+	return func(r *v1alpha1.CmdParamsConfig) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+// merge returns a copy of the union of data, later maps taking precedence.
+func merge(data ...map[string]string) map[string]string {
+	m := map[string]string{}
+	for _, d := range data {
+		for k, v := range d {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.CmdParamsConfig
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, testData)},
+				cr:   CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: CmdParamsConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"UnmanagedKeysIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(testData, unmanagedData, map[string]string{
+					settings.ControllerOperationProcessorsKey: "5",
+				}))},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: CmdParamsConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"QuotedValueIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(testData, map[string]string{
+					settings.RepoServerParallelismLimitKey: `"10"`,
+				}))},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: CmdParamsConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"TunableChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(testData, map[string]string{
+					settings.ControllerStatusProcessorsKey: "20",
+				}))},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: CmdParamsConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"TunableMissing": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, map[string]string{
+					settings.ControllerStatusProcessorsKey: "50",
+				})},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: CmdParamsConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, unmanagedData)},
+				cr:   CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr:     CmdParamsConfig(withSpec(testParams)),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NothingManaged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, unmanagedData)},
+				cr:   CmdParamsConfig(),
+			},
+			want: want{
+				cr: CmdParamsConfig(withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"GetConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr:   CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr:  CmdParamsConfig(withSpec(testParams)),
+				err: errors.Wrap(errBoom, "cannot get ArgoCD settings ConfigMap"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SetsTunables": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(testData),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"KeepsUnmanagedKeys": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(unmanagedData)),
+					MockUpdate: settingstest.ExpectConfigMapData(merge(unmanagedData, testData)),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	raised := testParams
+	raised.ControllerStatusProcessors = ptr.To[int32](100)
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SetsTunable": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(testData, unmanagedData)),
+					MockUpdate: settingstest.ExpectConfigMapData(merge(testData, unmanagedData, map[string]string{
+						settings.ControllerStatusProcessorsKey: "100",
+					})),
+				},
+				cr: CmdParamsConfig(withSpec(raised)),
+			},
+			want: want{},
+		},
+		"LeavesUnsetTunables": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, map[string]string{settings.ControllerOperationProcessorsKey: "5"}),
+					MockUpdate: settingstest.ExpectConfigMapData(merge(testData, map[string]string{
+						settings.ControllerOperationProcessorsKey: "5",
+					})),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesTunables": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(testData, unmanagedData)),
+					MockUpdate: settingstest.ExpectConfigMapData(unmanagedData),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.CmdParamsConfigMapName, merge(unmanagedData)),
+				},
+				cr: CmdParamsConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
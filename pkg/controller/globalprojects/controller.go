@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globalprojects
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/features"
+)
+
+const (
+	errNotGlobalProject = "managed resource is not a Argocd global project custom resource"
+	errUpdateConfigMap  = "cannot update ArgoCD settings ConfigMap"
+)
+
+// SetupGlobalProject adds a controller that reconciles global projects.
+func SetupGlobalProject(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.GlobalProjectKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), reader: mgr.GetAPIReader()}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(5 * time.Minute),
+	}
+
+	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.GlobalProject{}).
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.GlobalProjectGroupVersionKind),
+			opts...), o.GlobalRateLimiter))
+}
+
+// The global projects are stored in the argocd-cm ConfigMap, see package settings.
+type connector struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.GlobalProject); !ok {
+		return nil, errors.New(errNotGlobalProject)
+	}
+	if err := clients.TrackProviderConfigUsage(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	if err := settings.CheckInCluster(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	return clients.WithPauseHandling(&external{kube: c.kube, reader: c.reader}), nil
+}
+
+type external struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalProject)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGlobalProject)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	gps, err := settings.GetGlobalProjects(cm)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	i := indexOf(gps, cr.Spec.ForProvider.ProjectName)
+	if i < 0 {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: cmp.Equal(cr.Spec.ForProvider.LabelSelector, gps[i].LabelSelector, cmpopts.EquateEmpty()),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalProject)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGlobalProject)
+	}
+	return managed.ExternalCreation{}, e.upsert(ctx, cr)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.GlobalProject)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGlobalProject)
+	}
+	return managed.ExternalUpdate{}, e.upsert(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.GlobalProject)
+	if !ok {
+		return errors.New(errNotGlobalProject)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	gps, err := settings.GetGlobalProjects(cm)
+	if err != nil {
+		return err
+	}
+	i := indexOf(gps, cr.Spec.ForProvider.ProjectName)
+	if i < 0 {
+		return nil
+	}
+	if err := settings.SetGlobalProjects(cm, append(gps[:i], gps[i+1:]...)); err != nil {
+		return err
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func (e *external) upsert(ctx context.Context, cr *v1alpha1.GlobalProject) error {
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	gps, err := settings.GetGlobalProjects(cm)
+	if err != nil {
+		return err
+	}
+	gp := settings.GlobalProject{
+		ProjectName:   cr.Spec.ForProvider.ProjectName,
+		LabelSelector: cr.Spec.ForProvider.LabelSelector,
+	}
+	if i := indexOf(gps, gp.ProjectName); i >= 0 {
+		gps[i] = gp
+	} else {
+		gps = append(gps, gp)
+	}
+	if err := settings.SetGlobalProjects(cm, gps); err != nil {
+		return err
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func indexOf(gps []settings.GlobalProject, projectName string) int {
+	for i := range gps {
+		if gps[i].ProjectName == projectName {
+			return i
+		}
+	}
+	return -1
+}
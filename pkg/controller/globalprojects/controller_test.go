@@ -0,0 +1,347 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globalprojects
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom            = errors.New("boom")
+	testProjectName    = "global-project"
+	testOtherProject   = "other-global-project"
+	testLabelSelector  = metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+	testGlobalProjects = `- labelSelector:
+    matchLabels:
+      tier: frontend
+  projectName: global-project
+`
+	testOtherGlobalProjects = `- labelSelector:
+    matchLabels:
+      team: payments
+  projectName: other-global-project
+`
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.GlobalProject
+}
+
+func GlobalProject(m ...GlobalProjectModifier) *v1alpha1.GlobalProject {
+	cr := &v1alpha1.GlobalProject{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type GlobalProjectModifier func(*v1alpha1.GlobalProject)
+
+func withSpec(p v1alpha1.GlobalProjectParameters) GlobalProjectModifier {
+	return func(r *v1alpha1.GlobalProject) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) GlobalProjectModifier {
+	return func(r *v1alpha1.GlobalProject) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.GlobalProject
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testGlobalProjects})},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{
+				cr: GlobalProject(
+					withSpec(v1alpha1.GlobalProjectParameters{
+						ProjectName:   testProjectName,
+						LabelSelector: testLabelSelector,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"LabelSelectorChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testGlobalProjects})},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}},
+				})),
+			},
+			want: want{
+				cr: GlobalProject(
+					withSpec(v1alpha1.GlobalProjectParameters{
+						ProjectName:   testProjectName,
+						LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects})},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName: testProjectName,
+				})),
+			},
+			want: want{
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName: testProjectName,
+				})),
+				err: errors.Wrap(errBoom, "cannot get ArgoCD settings ConfigMap"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"AppendsGlobalProject": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects + testGlobalProjects}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{},
+		},
+		"InitializesSetting": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{settings.GlobalProjectsKey: testGlobalProjects}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"ReplacesLabelSelector": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: `- labelSelector:
+    matchLabels:
+      tier: backend
+  projectName: global-project
+`}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{settings.GlobalProjectsKey: testGlobalProjects}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesGlobalProject": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects + testGlobalProjects}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName:   testProjectName,
+					LabelSelector: testLabelSelector,
+				})),
+			},
+			want: want{},
+		},
+		"RemovesSetting": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testGlobalProjects}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName: testProjectName,
+				})),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.GlobalProjectsKey: testOtherGlobalProjects}),
+				},
+				cr: GlobalProject(withSpec(v1alpha1.GlobalProjectParameters{
+					ProjectName: testProjectName,
+				})),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
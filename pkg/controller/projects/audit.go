@@ -0,0 +1,67 @@
+package projects
+
+import (
+	"sort"
+	"time"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+)
+
+// maxTokenAuditRecords bounds the token audit in the status of a project
+const maxTokenAuditRecords = 50
+
+// generateTokenAudit records the tokens of all roles, most recently issued first.
+// Only the maxTokenAuditRecords most recently issued tokens are kept.
+func generateTokenAudit(roles []argocdv1alpha1.ProjectRole) []v1alpha1.TokenAuditRecord {
+	var records []v1alpha1.TokenAuditRecord
+	for _, r := range roles {
+		for _, t := range r.JWTTokens {
+			rec := v1alpha1.TokenAuditRecord{
+				Role:     r.Name,
+				IssuedAt: t.IssuedAt,
+			}
+			if t.ID != "" {
+				rec.ID = ptr.To(t.ID)
+			}
+			if t.ExpiresAt != 0 {
+				rec.ExpiresAt = ptr.To(t.ExpiresAt)
+			}
+			records = append(records, rec)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].IssuedAt != records[j].IssuedAt {
+			return records[i].IssuedAt > records[j].IssuedAt
+		}
+		return records[i].Role < records[j].Role
+	})
+	if len(records) > maxTokenAuditRecords {
+		records = records[:maxTokenAuditRecords]
+	}
+	return records
+}
+
+// tokenExpiry formats the time from now until the token of roles expiring soonest, e.g. "in 13h",
+// or since it expired, e.g. "expired 2d ago". It returns nil if no token expires.
+func tokenExpiry(roles []argocdv1alpha1.ProjectRole, now time.Time) *string {
+	var soonest int64
+	for _, r := range roles {
+		for _, t := range r.JWTTokens {
+			if t.ExpiresAt != 0 && (soonest == 0 || t.ExpiresAt < soonest) {
+				soonest = t.ExpiresAt
+			}
+		}
+	}
+	if soonest == 0 {
+		return nil
+	}
+	d := time.Unix(soonest, 0).Sub(now)
+	if d < 0 {
+		return ptr.To("expired " + duration.HumanDuration(-d) + " ago")
+	}
+	return ptr.To("in " + duration.HumanDuration(d))
+}
@@ -18,6 +18,7 @@ package projects
 
 import (
 	"context"
+	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
@@ -26,6 +27,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,22 +37,34 @@ import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
 )
 
 const (
-	errNotProject       = "managed resource is not a Argocd Project custom resource"
-	errGetFailed        = "cannot get Argocd Project"
-	errKubeUpdateFailed = "cannot update Argocd Project custom resource"
-	errCreateFailed     = "cannot create Argocd Project"
-	errUpdateFailed     = "cannot update Argocd Project"
-	errDeleteFailed     = "cannot delete Argocd Project"
+	errNotProject        = "managed resource is not a Argocd Project custom resource"
+	errGetFailed         = "cannot get Argocd Project"
+	errKubeUpdateFailed  = "cannot update Argocd Project custom resource"
+	errCreateFailed      = "cannot create Argocd Project"
+	errUpdateFailed      = "cannot update Argocd Project"
+	errDeleteFailed      = "cannot delete Argocd Project"
+	errIgnoreFields      = "invalid ignore fields annotation"
+	errInvalidProject    = "invalid Argocd Project"
+	errExportSpec        = "cannot export desired AppProject spec"
+	errSnapshotSpec      = "cannot snapshot initial AppProject spec"
+	errPartialCreate     = "created Argocd Project, but not all of its tokens"
+	errPartialUpdate     = "updated Argocd Project, but not all of its tokens"
+	errIndexExternalName = "cannot index Argocd Projects by external name"
+
+	errFmtEmptySpec = "Argocd returned an empty spec for Project %s, retrying instead of updating it, set annotation %s to \"true\" if the project is empty on purpose"
 )
 
 // SetupProject adds a controller that reconciles projects.
@@ -57,8 +72,9 @@ func SetupProject(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.ProjectKind)
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: projects.NewProjectServiceClient}),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: projects.NewProjectServiceClient, newApplicationClientFn: applications.NewApplicationServiceClient, cache: projects.NewListCache(projects.ListCacheTTL, clock.RealClock{})}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 	}
 
@@ -66,18 +82,24 @@ func SetupProject(mgr ctrl.Manager, o xpcontroller.Options) error {
 		opts = append(opts, managed.WithManagementPolicies())
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.Project{}, clients.ExternalNameIndexKey, clients.IndexExternalName); err != nil {
+		return errors.Wrap(err, errIndexExternalName)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Project{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ProjectGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
-	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, project.ProjectServiceClient)
-	conn              io.Closer
+	kube                   client.Client
+	newArgocdClientFn      func(clientOpts *apiclient.ClientOptions) (io.Closer, projects.ProjectServiceClient)
+	newApplicationClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, applications.ServiceClient)
+	cache                  *projects.ListCache
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -85,22 +107,41 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotProject)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	secretKeys, err := clients.GetTokenSecretKeys(ctx, c.kube, cr)
 	if err != nil {
 		return nil, err
 	}
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, _ *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		ext := &external{kube: c.kube, client: argocdClient, clock: clock.RealClock{}, secretKeys: secretKeys, cache: c.cache, cacheKey: projects.ListCacheKey(cfg), tokenBackoff: projects.TokenCreateBackoff(cr, projects.DefaultTokenCreateBackoff)}
 
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+		// the application client is only needed to count the applications of the project
+		// or to handle them on delete
+		if !projects.IsApplicationCountEnabled(cr) && cr.GetAnnotations()[projects.AnnotationKeyDeletionPropagation] == "" {
+			return ext, conn
+		}
+		appConn, appClient := c.newApplicationClientFn(cfg)
+		ext.appClient = appClient
+		return ext, clients.Closers{conn, appConn}
+	})
+	if err != nil {
+		return nil, err
+	}
+	// two Projects with the same external name would fight over the same ArgoCD project
+	conflicts := clients.WithConflictHandling(fc, c.kube, v1alpha1.ProjectKind, func() resource.ManagedList { return &v1alpha1.ProjectList{} })
+	return clients.WithPauseHandling(clients.WithPermissionHandling(clients.WithAlreadyExistsHandling(conflicts), v1alpha1.ProjectKind)), nil
 }
 
 type external struct {
-	kube   client.Client
-	client projects.ProjectServiceClient
+	kube       client.Client
+	client     projects.ProjectServiceClient
+	appClient  applications.ServiceClient
+	clock      clock.PassiveClock
+	secretKeys clients.TokenSecretKeys
+	cache      *projects.ListCache
+	cacheKey   string
+	// tokenBackoff is the backoff between the attempts to create a token
+	tokenBackoff wait.Backoff
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -115,28 +156,58 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
-	projectQuery := project.ProjectQuery{
-		Name: meta.GetExternalName(cr),
+	ignored, err := clients.GetIgnoredFields(cr, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errIgnoreFields)
 	}
 
-	project, err := e.client.Get(ctx, &projectQuery)
+	project, err := e.getProject(ctx, cr)
 	if projects.IsErrorProjectNotFound(err) {
 		return managed.ExternalObservation{}, nil
 	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
 	}
+	if isSuspiciouslyEmpty(cr, project) {
+		return managed.ExternalObservation{}, errors.Errorf(errFmtEmptySpec, project.Name, projects.AnnotationKeyAllowEmptySpec)
+	}
+
+	snapshot, err := snapshotInitialSpec(cr, project.Spec)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	current := cr.Spec.ForProvider.DeepCopy()
 	lateInitializeProject(&cr.Spec.ForProvider, &project.Spec)
 
+	desired := withIgnoredFields(&cr.Spec.ForProvider, project, ignored)
+	upToDate := isProjectUpToDate(desired, project)
+	exported, err := exportDesiredSpec(cr, generateProjectSpec(desired))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	lastError, lastErrorTime, failedTokens := cr.Status.AtProvider.LastError, cr.Status.AtProvider.LastErrorTime, cr.Status.AtProvider.FailedTokens
 	cr.Status.AtProvider = generateProjectObservation(project)
+	active, manualSyncAllowed, frozen := observeSyncWindows(project.Spec.SyncWindows, e.clock.Now())
+	cr.Status.AtProvider.SyncWindowActive, cr.Status.AtProvider.ManualSyncAllowed, cr.Status.AtProvider.SyncFrozen = active, manualSyncAllowed, frozen
+	observeOrphanedTokens(cr, findOrphanedTokens(project.Status.JWTTokensByRole, desired.Roles, project.Spec.Roles))
+	cr.Status.AtProvider.TokenExpiry = tokenExpiry(project.Spec.Roles, e.clock.Now())
+	observeTokensReady(cr, countTokenStates(cr.Spec.ForProvider.Roles, project.Spec.Roles, failedTokens, e.clock.Now()))
+	if cr.Status.AtProvider.ApplicationCount, err = e.countApplications(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !upToDate {
+		cr.Status.AtProvider.LastError, cr.Status.AtProvider.LastErrorTime = lastError, lastErrorTime
+		cr.Status.AtProvider.FailedTokens = failedTokens
+		cr.Status.AtProvider.Diff = clients.StringToPtr(summarizeProjectDiff(desired, project))
+	}
 	cr.Status.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isProjectUpToDate(&cr.Spec.ForProvider, project),
-		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: exported || snapshot || !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
 
@@ -145,17 +216,28 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotProject)
 	}
+	if err := validateProject(cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidProject)
+	}
 
 	projCreateRequest := generateCreateProjectOptions(cr)
 
 	resp, err := e.client.Create(ctx, projCreateRequest)
-	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	if err := e.recordError(cr, errors.Wrap(err, errCreateFailed)); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
 	meta.SetExternalName(cr, resp.Name)
+	e.invalidate(resp.Name)
 
-	return managed.ExternalCreation{}, errors.Wrap(nil, errKubeUpdateFailed)
+	// the project exists even if a token can't be created, so the created tokens are
+	// published and the failed ones are retried by the next update
+	details, err := e.mintPendingTokens(ctx, cr, resp.Spec.Roles)
+	if err != nil {
+		_ = e.recordError(cr, errors.Wrap(err, errPartialCreate))
+	}
+
+	return managed.ExternalCreation{ConnectionDetails: details}, errors.Wrap(nil, errKubeUpdateFailed)
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -163,20 +245,38 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotProject)
 	}
+	if err := validateProject(cr); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidProject)
+	}
+	ignored, err := clients.GetIgnoredFields(cr, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errIgnoreFields)
+	}
 	projQuery := project.ProjectQuery{
 		Name: meta.GetExternalName(cr),
 	}
 
 	proj, err := e.client.Get(ctx, &projQuery)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+		return managed.ExternalUpdate{}, e.recordError(cr, errors.Wrap(err, errUpdateFailed))
 	}
 
-	projUpdateRequest := generateUpdateProjectOptions(cr, proj)
+	desired := cr.DeepCopy()
+	desired.Spec.ForProvider = *withIgnoredFields(&cr.Spec.ForProvider, proj, ignored)
+	projUpdateRequest := generateUpdateProjectOptions(desired, proj)
 
 	_, err = e.client.Update(ctx, projUpdateRequest)
+	if err != nil {
+		return managed.ExternalUpdate{}, e.recordError(cr, errors.Wrap(err, errUpdateFailed))
+	}
+	e.invalidate(projQuery.Name)
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	details, err := e.mintPendingTokens(ctx, cr, proj.Spec.Roles)
+	if err != nil {
+		_ = e.recordError(cr, errors.Wrap(err, errPartialUpdate))
+		return managed.ExternalUpdate{ConnectionDetails: details}, nil
+	}
+	return managed.ExternalUpdate{ConnectionDetails: details}, e.recordError(cr, nil)
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -184,13 +284,74 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotProject)
 	}
+	pending, err := e.deleteApplications(ctx, cr)
+	if err != nil || pending {
+		// the project is deleted by a later reconcile once its applications are gone
+		return e.recordError(cr, err)
+	}
+
 	projQuery := project.ProjectQuery{
 		Name: meta.GetExternalName(cr),
 	}
 
-	_, err := e.client.Delete(ctx, &projQuery)
+	_, err = e.client.Delete(ctx, &projQuery)
+	e.invalidate(projQuery.Name)
+	if projects.IsErrorProjectNotFound(err) {
+		// the project is already gone, so the managed resource can be finalized
+		return e.recordError(cr, nil)
+	}
 
-	return errors.Wrap(err, errDeleteFailed)
+	return e.recordError(cr, errors.Wrap(err, errDeleteFailed))
+}
+
+// getProject fetches the project of cr from ArgoCD, or from the list cache if it is enabled for cr.
+func (e *external) getProject(ctx context.Context, cr *v1alpha1.Project) (*argocdv1alpha1.AppProject, error) {
+	if e.cache != nil && projects.IsListCacheEnabled(cr) {
+		return e.cache.Get(ctx, e.client, e.cacheKey, meta.GetExternalName(cr))
+	}
+	return e.client.Get(ctx, &project.ProjectQuery{Name: meta.GetExternalName(cr)})
+}
+
+// invalidate removes the project name from the list cache after it was changed in ArgoCD.
+func (e *external) invalidate(name string) {
+	if e.cache != nil {
+		e.cache.Invalidate(e.cacheKey, name)
+	}
+}
+
+// recordError records err as the last error of cr, or clears the last error if err is nil.
+// It returns err. Projects are not wrapped by clients.WithLastErrorRecording, as the tokens
+// which couldn't be minted by a successful create or update are recorded as well.
+func (e *external) recordError(cr *v1alpha1.Project, err error) error {
+	return clients.RecordLastError(cr, e.clock, err)
+}
+
+// withIgnoredFields returns a copy of p in which the ignored fields hold the values observed in r
+func withIgnoredFields(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProject, ignored []string) *v1alpha1.ProjectParameters {
+	if len(ignored) == 0 {
+		return p
+	}
+	observed := &v1alpha1.ProjectParameters{
+		SourceNamespaces: r.Spec.SourceNamespaces,
+		ProjectLabels:    r.Labels,
+	}
+	lateInitializeProject(observed, &r.Spec)
+
+	desired := p.DeepCopy()
+	clients.ApplyIgnoredFields(desired, observed, ignored)
+	return desired
+}
+
+// isSuspiciouslyEmpty reports whether the observed spec of project is completely empty while the
+// desired spec of cr is not. Converging such a response, e.g. a partial response of ArgoCD, would
+// wipe the project, so it is treated as transient unless the allow-empty-spec annotation is set.
+func isSuspiciouslyEmpty(cr *v1alpha1.Project, project *argocdv1alpha1.AppProject) bool {
+	if projects.IsEmptySpecAllowed(cr) {
+		return false
+	}
+	// the protobuf encoding of a spec without any field set is empty
+	desired := generateProjectSpec(&cr.Spec.ForProvider)
+	return project.Spec.Size() == 0 && desired.Size() > 0
 }
 
 func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProjectSpec) { // nolint:gocyclo // checking all parameters can't be reduced
@@ -241,6 +402,7 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 			}
 		}
 	}
+	lateInitializePendingTokens(p.Roles, r.Roles)
 
 	if p.ClusterResourceWhitelist == nil {
 		p.ClusterResourceWhitelist = r.ClusterResourceWhitelist
@@ -298,6 +460,9 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 	if p.ClusterResourceBlacklist == nil {
 		p.ClusterResourceBlacklist = r.ClusterResourceBlacklist
 	}
+	if p.PermitOnlyProjectScopedClusters == nil && r.PermitOnlyProjectScopedClusters {
+		p.PermitOnlyProjectScopedClusters = &r.PermitOnlyProjectScopedClusters
+	}
 }
 
 func generateProjectObservation(r *argocdv1alpha1.AppProject) v1alpha1.ProjectObservation {
@@ -323,6 +488,10 @@ func generateProjectObservation(r *argocdv1alpha1.AppProject) v1alpha1.ProjectOb
 	}
 	o := v1alpha1.ProjectObservation{
 		JWTTokensByRole: jwtTokensByRole,
+		TokenAudit:      generateTokenAudit(r.Spec.Roles),
+	}
+	if !r.CreationTimestamp.IsZero() {
+		o.CreatedAt = r.CreationTimestamp.DeepCopy()
 	}
 
 	return o
@@ -330,11 +499,12 @@ func generateProjectObservation(r *argocdv1alpha1.AppProject) v1alpha1.ProjectOb
 
 func generateCreateProjectOptions(p *v1alpha1.Project) *project.ProjectCreateRequest {
 	projSpec := generateProjectSpec(&p.Spec.ForProvider)
+	withoutPendingTokens(&projSpec)
 
 	projectCreateRequest := &project.ProjectCreateRequest{
 		Project: &argocdv1alpha1.AppProject{
 			Spec:       projSpec,
-			ObjectMeta: metav1.ObjectMeta{Name: p.Name, Labels: p.Spec.ForProvider.ProjectLabels},
+			ObjectMeta: metav1.ObjectMeta{Name: meta.GetExternalName(p), Labels: p.Spec.ForProvider.ProjectLabels},
 		},
 		Upsert: false,
 	}
@@ -434,6 +604,10 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 		projSpec.ClusterResourceBlacklist = p.ClusterResourceBlacklist
 	}
 
+	if p.PermitOnlyProjectScopedClusters != nil {
+		projSpec.PermitOnlyProjectScopedClusters = *p.PermitOnlyProjectScopedClusters
+	}
+
 	if p.SourceNamespaces != nil {
 		projSpec.SourceNamespaces = p.SourceNamespaces
 	}
@@ -443,16 +617,19 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 
 func generateUpdateProjectOptions(p *v1alpha1.Project, current *argocdv1alpha1.AppProject) *project.ProjectUpdateRequest {
 	projSpec := generateProjectSpec(&p.Spec.ForProvider)
+	withoutPendingTokens(&projSpec)
+
+	// ArgoCD replaces the whole project on update, so the desired spec and labels are merged into
+	// the current project to write them at once and keep the fields managed by the server
+	merged := current.DeepCopy()
+	merged.TypeMeta = p.TypeMeta
+	merged.Spec = projSpec
+	if p.Spec.ForProvider.ProjectLabels != nil {
+		merged.Labels = p.Spec.ForProvider.ProjectLabels
+	}
 
 	o := &project.ProjectUpdateRequest{
-		Project: &argocdv1alpha1.AppProject{
-			TypeMeta: p.TypeMeta,
-			ObjectMeta: metav1.ObjectMeta{
-				Name:            current.ObjectMeta.Name,
-				ResourceVersion: current.ObjectMeta.ResourceVersion,
-			},
-			Spec: projSpec,
-		},
+		Project: merged,
 	}
 	return o
 }
@@ -462,20 +639,31 @@ func isProjectUpToDate(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProje
 	case !cmp.Equal(p.SourceRepos, r.Spec.SourceRepos),
 		!isEqualDestinations(p.Destinations, r.Spec.Destinations),
 		clients.StringValue(p.Description) != r.Spec.Description,
-		!isEqualRoles(p.Roles, r.Spec.Roles),
+		!isEqualLabels(p.ProjectLabels, r.Labels),
+		!isEqualRoles(p.Roles, r.Spec.Roles, clients.BoolValue(p.CaseInsensitiveRoleGroups)),
 		!cmp.Equal(p.ClusterResourceWhitelist, r.Spec.ClusterResourceWhitelist),
 		!cmp.Equal(p.NamespaceResourceBlacklist, r.Spec.NamespaceResourceBlacklist),
 		!isEqualOrphanedResources(p.OrphanedResources, r.Spec.OrphanedResources),
 		!isEqualSyncWindows(p.SyncWindows, r.Spec.SyncWindows),
 		!cmp.Equal(p.NamespaceResourceWhitelist, r.Spec.NamespaceResourceWhitelist),
 		!isEqualSignatureKeys(p.SignatureKeys, r.Spec.SignatureKeys),
-		!cmp.Equal(p.ClusterResourceBlacklist, r.Spec.ClusterResourceBlacklist):
+		!cmp.Equal(p.ClusterResourceBlacklist, r.Spec.ClusterResourceBlacklist),
+		clients.BoolValue(p.PermitOnlyProjectScopedClusters) != r.Spec.PermitOnlyProjectScopedClusters:
 		return false
 	}
 	return true
 }
 
-func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole) bool { // nolint:gocyclo // checking all parameters can't be reduced
+// isEqualLabels returns whether the labels of the project are up to date. Labels are left as
+// they are in ArgoCD if p is nil.
+func isEqualLabels(p, r map[string]string) bool {
+	if p == nil {
+		return true
+	}
+	return len(p) == len(r) && (len(p) == 0 || cmp.Equal(p, r))
+}
+
+func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole, caseInsensitiveGroups bool) bool { // nolint:gocyclo // checking all parameters can't be reduced
 	if p == nil && r == nil {
 		return true
 	}
@@ -487,7 +675,7 @@ func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole) bool
 		case role.Name != r[i].Name,
 			role.Description != nil && *role.Description != r[i].Description,
 			!cmp.Equal(role.Policies, r[i].Policies),
-			!cmp.Equal(role.Groups, r[i].Groups),
+			!isEqualGroups(role.Groups, r[i].Groups, caseInsensitiveGroups),
 			!isEqualJWTTokens(role.JWTTokens, r[i].JWTTokens):
 			return false
 		}
@@ -495,6 +683,29 @@ func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole) bool
 	return true
 }
 
+// isEqualGroups compares the OIDC groups of a role after normalizing them with normalizeGroup.
+func isEqualGroups(p, r []string, caseInsensitive bool) bool {
+	if len(p) != len(r) {
+		return false
+	}
+	for i := range p {
+		if normalizeGroup(p[i], caseInsensitive) != normalizeGroup(r[i], caseInsensitive) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeGroup trims leading and trailing whitespace of an OIDC group and
+// folds it to lower case if the groups of the project are case-insensitive.
+func normalizeGroup(g string, caseInsensitive bool) string {
+	g = strings.TrimSpace(g)
+	if caseInsensitive {
+		return strings.ToLower(g)
+	}
+	return g
+}
+
 func isEqualJWTTokens(p []v1alpha1.JWTToken, r []argocdv1alpha1.JWTToken) bool {
 	if p == nil && r == nil {
 		return true
@@ -524,7 +735,7 @@ func isEqualDestinations(p []v1alpha1.ApplicationDestination, r []argocdv1alpha1
 		switch {
 		case destination.Name != nil && *destination.Name != r[i].Name,
 			destination.Namespace != nil && *destination.Namespace != r[i].Namespace,
-			destination.Server != nil && *destination.Server != r[i].Server:
+			destination.Server != nil && clients.CanonicalServerURL(*destination.Server) != clients.CanonicalServerURL(r[i].Server):
 			return false
 		}
 	}
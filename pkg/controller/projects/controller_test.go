@@ -18,13 +18,26 @@ package projects
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
+	argocdApplication "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
@@ -34,6 +47,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	mockapplications "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/applications"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/projects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 )
@@ -45,6 +61,7 @@ var (
 	testDescription         = "This is a Test"
 	testDescription2        = "This description changed"
 	testLabels              = map[string]string{"label1": "value1"}
+	testNow                 = time.Date(2024, time.January, 15, 12, 30, 0, 0, time.UTC)
 )
 
 type args struct {
@@ -77,6 +94,12 @@ func withExternalName(v string) ProjectModifier {
 	}
 }
 
+func withIgnoreFieldsAnnotation(v string) ProjectModifier {
+	return func(s *v1alpha1.Project) {
+		meta.AddAnnotations(s, map[string]string{clients.AnnotationKeyIgnoreFields: v})
+	}
+}
+
 func withSpec(p v1alpha1.ProjectParameters) ProjectModifier {
 	return func(r *v1alpha1.Project) { r.Spec.ForProvider = p }
 }
@@ -89,6 +112,18 @@ func withObservation(p v1alpha1.ProjectObservation) ProjectModifier {
 	return func(r *v1alpha1.Project) { r.Status.AtProvider = p }
 }
 
+func withLastError(msg string) ProjectModifier {
+	return func(r *v1alpha1.Project) {
+		t := metav1.NewTime(testNow)
+		r.Status.AtProvider.LastError = &msg
+		r.Status.AtProvider.LastErrorTime = &t
+	}
+}
+
+func withFailedTokens(f ...v1alpha1.TokenFailure) ProjectModifier {
+	return func(r *v1alpha1.Project) { r.Status.AtProvider.FailedTokens = f }
+}
+
 func withConditions(c ...xpv1.Condition) ProjectModifier {
 	return func(r *v1alpha1.Project) { r.Status.ConditionedStatus.Conditions = c }
 }
@@ -233,7 +268,107 @@ func TestObserve(t *testing.T) {
 					withConditions(xpv1.Available()),
 					withObservation(v1alpha1.ProjectObservation{
 						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+						Diff:            ptr.To("description changed"),
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"LastErrorClearedWhenUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testProjectExternalName,
+								Labels: testLabels,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"LastErrorKeptWhileNotUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+						Diff:            ptr.To("description changed"),
 					}),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
 				),
 				result: managed.ExternalObservation{
 					ResourceExists:          true,
@@ -243,6 +378,67 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"IgnoredDescriptionUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("description"),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("description"),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"UnknownIgnoredField": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("descriptoin"),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("descriptoin"),
+				),
+				err: errors.Wrap(errors.Errorf("unknown field %q in annotation %s", "descriptoin", clients.AnnotationKeyIgnoreFields), errIgnoreFields),
+			},
+		},
 		"GetProjectFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
@@ -292,7 +488,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, clock: clocktesting.NewFakePassiveClock(testNow)}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -308,90 +504,1549 @@ func TestObserve(t *testing.T) {
 	}
 }
 
-func TestCreate(t *testing.T) {
+func TestObserveSyncWindows(t *testing.T) {
+	allowAtNoon := &argocdv1alpha1.SyncWindow{Kind: "allow", Schedule: "0 12 * * *", Duration: "1h"}
+	denyAtNight := &argocdv1alpha1.SyncWindow{Kind: "deny", Schedule: "0 22 * * *", Duration: "2h"}
+	denyAtNightManual := &argocdv1alpha1.SyncWindow{Kind: "deny", Schedule: "0 22 * * *", Duration: "2h", ManualSync: true}
+	allowAtNoonManual := &argocdv1alpha1.SyncWindow{Kind: "allow", Schedule: "0 12 * * *", Duration: "1h", ManualSync: true}
+
 	type want struct {
-		cr     *v1alpha1.Project
-		result managed.ExternalCreation
-		err    error
+		active            *bool
+		manualSyncAllowed *bool
+		frozen            *bool
 	}
 
 	cases := map[string]struct {
-		args
+		windows argocdv1alpha1.SyncWindows
+		now     time.Time
 		want
 	}{
-		"Successful": {
-			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Create(
-						context.Background(),
-						&project.ProjectCreateRequest{
-							Project: &argocdv1alpha1.AppProject{
-								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, Labels: testLabels},
-								Spec: argocdv1alpha1.AppProjectSpec{
-									Description: testDescription,
-								},
-							},
-						},
-					).Return(
-						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
-							ObjectMeta: metav1.ObjectMeta{
-								Name:   testProjectExternalName,
-								Labels: testLabels,
-							},
-							Spec: argocdv1alpha1.AppProjectSpec{
-								Description: testDescription,
-							},
-							Status: argocdv1alpha1.AppProjectStatus{},
-						}, nil)
-				}),
-				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+		"NoWindows": {
+			now:  testNow,
+			want: want{},
+		},
+		"InsideAllowWindow": {
+			windows: argocdv1alpha1.SyncWindows{allowAtNoon},
+			now:     testNow,
+			want:    want{active: ptr.To(true), manualSyncAllowed: ptr.To(true), frozen: ptr.To(false)},
+		},
+		"OutsideAllowWindow": {
+			windows: argocdv1alpha1.SyncWindows{allowAtNoon},
+			now:     testNow.Add(2 * time.Hour),
+			want:    want{active: ptr.To(false), manualSyncAllowed: ptr.To(false), frozen: ptr.To(true)},
+		},
+		"OutsideAllowWindowWithManualSync": {
+			windows: argocdv1alpha1.SyncWindows{allowAtNoonManual},
+			now:     testNow.Add(2 * time.Hour),
+			want:    want{active: ptr.To(false), manualSyncAllowed: ptr.To(true), frozen: ptr.To(true)},
+		},
+		"InsideDenyWindow": {
+			windows: argocdv1alpha1.SyncWindows{denyAtNight},
+			now:     testNow.Add(10 * time.Hour),
+			want:    want{active: ptr.To(true), manualSyncAllowed: ptr.To(false), frozen: ptr.To(true)},
+		},
+		"InsideDenyWindowWithManualSync": {
+			windows: argocdv1alpha1.SyncWindows{denyAtNightManual},
+			now:     testNow.Add(10 * time.Hour),
+			want:    want{active: ptr.To(true), manualSyncAllowed: ptr.To(true), frozen: ptr.To(true)},
+		},
+		"OutsideDenyWindow": {
+			windows: argocdv1alpha1.SyncWindows{denyAtNight},
+			now:     testNow,
+			want:    want{active: ptr.To(false), manualSyncAllowed: ptr.To(true), frozen: ptr.To(false)},
+		},
+		"DenyWinsOverAllow": {
+			windows: argocdv1alpha1.SyncWindows{allowAtNoon, &argocdv1alpha1.SyncWindow{Kind: "deny", Schedule: "0 12 * * *", Duration: "1h"}},
+			now:     testNow,
+			want:    want{active: ptr.To(true), manualSyncAllowed: ptr.To(false), frozen: ptr.To(true)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			active, manual, frozen := observeSyncWindows(tc.windows, tc.now)
+			if diff := cmp.Diff(tc.want.active, active); diff != "" {
+				t.Errorf("active: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.manualSyncAllowed, manual); diff != "" {
+				t.Errorf("manualSyncAllowed: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.frozen, frozen); diff != "" {
+				t.Errorf("frozen: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObservePrinterColumns(t *testing.T) {
+	created := metav1.NewTime(testNow.Add(-24 * time.Hour))
+
+	type want struct {
+		createdAt *metav1.Time
+		frozen    *bool
+	}
+
+	cases := map[string]struct {
+		windows argocdv1alpha1.SyncWindows
+		want
+	}{
+		"NoSyncWindows": {
+			want: want{createdAt: &created},
+		},
+		"Frozen": {
+			windows: argocdv1alpha1.SyncWindows{{Kind: "deny", Schedule: "0 12 * * *", Duration: "1h"}},
+			want:    want{createdAt: &created, frozen: ptr.To(true)},
+		},
+		"NotFrozen": {
+			windows: argocdv1alpha1.SyncWindows{{Kind: "allow", Schedule: "0 12 * * *", Duration: "1h"}},
+			want:    want{createdAt: &created, frozen: ptr.To(false)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+					ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, CreationTimestamp: created},
+					Spec:       argocdv1alpha1.AppProjectSpec{SyncWindows: tc.windows},
+				}, nil)
+			})
+			e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+			cr := Project(withExternalName(testProjectExternalName))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("Observe(...): unexpected error: %v", err)
+			}
+			got := want{createdAt: cr.Status.AtProvider.CreatedAt, frozen: cr.Status.AtProvider.SyncFrozen}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveOrphanedTokens(t *testing.T) {
+	tokensByRole := map[string]argocdv1alpha1.JWTTokens{
+		"ci":  {Items: []argocdv1alpha1.JWTToken{{IssuedAt: 200, ID: "deploy"}}},
+		"old": {Items: []argocdv1alpha1.JWTToken{{IssuedAt: 100, ID: "stale", ExpiresAt: 300}, {IssuedAt: 150}}},
+	}
+	staleTokens := []v1alpha1.TokenAuditRecord{
+		{Role: "old", ID: ptr.To("stale"), IssuedAt: 100, ExpiresAt: ptr.To(int64(300))},
+		{Role: "old", IssuedAt: 150},
+	}
+
+	type want struct {
+		orphaned   []v1alpha1.TokenAuditRecord
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		tokensByRole map[string]argocdv1alpha1.JWTTokens
+		desired      []v1alpha1.ProjectRole
+		remote       []argocdv1alpha1.ProjectRole
+		conditions   []xpv1.Condition
+		want
+	}{
+		"NoTokens": {
+			desired: []v1alpha1.ProjectRole{{Name: "ci"}},
+			remote:  []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+			want:    want{},
+		},
+		"RolesExist": {
+			tokensByRole: tokensByRole,
+			desired:      []v1alpha1.ProjectRole{{Name: "ci"}, {Name: "old"}},
+			remote:       []argocdv1alpha1.ProjectRole{{Name: "ci"}, {Name: "old"}},
+			want:         want{},
+		},
+		"RoleDeletedFromProject": {
+			tokensByRole: tokensByRole,
+			desired:      []v1alpha1.ProjectRole{{Name: "ci"}, {Name: "old"}},
+			remote:       []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+			want: want{
+				orphaned:   staleTokens,
+				conditions: []xpv1.Condition{OrphanedTokensFound([]string{"old"}, 2)},
+			},
+		},
+		"RoleRemovedFromSpec": {
+			tokensByRole: tokensByRole,
+			desired:      []v1alpha1.ProjectRole{{Name: "ci"}},
+			remote:       []argocdv1alpha1.ProjectRole{{Name: "ci"}, {Name: "old"}},
+			want: want{
+				orphaned:   staleTokens,
+				conditions: []xpv1.Condition{OrphanedTokensFound([]string{"old"}, 2)},
+			},
+		},
+		"OrphanedTokensCleanedUp": {
+			tokensByRole: map[string]argocdv1alpha1.JWTTokens{"ci": tokensByRole["ci"]},
+			desired:      []v1alpha1.ProjectRole{{Name: "ci"}},
+			remote:       []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+			conditions:   []xpv1.Condition{OrphanedTokensFound([]string{"old"}, 2)},
+			want: want{
+				conditions: []xpv1.Condition{NoOrphanedTokens()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withConditions(tc.conditions...))
+			observeOrphanedTokens(cr, findOrphanedTokens(tc.tokensByRole, tc.desired, tc.remote))
+			if diff := cmp.Diff(tc.want.orphaned, cr.Status.AtProvider.OrphanedTokens); diff != "" {
+				t.Errorf("orphaned: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, cr.Status.Conditions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("conditions: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveTokensReady(t *testing.T) {
+	now := testNow.Unix()
+	desired := []v1alpha1.ProjectRole{
+		{Name: "ci", JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}, {ID: ptr.To("audit")}}},
+		{Name: "ops", JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("admin")}, {IssuedAt: 100}}},
+	}
+	issued := func(expiresAt ...int64) []argocdv1alpha1.ProjectRole {
+		exp := func(i int) int64 {
+			if i < len(expiresAt) {
+				return expiresAt[i]
+			}
+			return 0
+		}
+		return []argocdv1alpha1.ProjectRole{
+			{Name: "ci", JWTTokens: []argocdv1alpha1.JWTToken{{ID: "deploy", IssuedAt: 100, ExpiresAt: exp(0)}, {ID: "audit", IssuedAt: 100, ExpiresAt: exp(1)}}},
+			{Name: "ops", JWTTokens: []argocdv1alpha1.JWTToken{{ID: "admin", IssuedAt: 100, ExpiresAt: exp(2)}, {IssuedAt: 100}}},
+		}
+	}
+
+	cases := map[string]struct {
+		desired    []v1alpha1.ProjectRole
+		remote     []argocdv1alpha1.ProjectRole
+		failed     []v1alpha1.TokenFailure
+		conditions []xpv1.Condition
+		want       []xpv1.Condition
+	}{
+		"NoTokensDeclared": {
+			desired: []v1alpha1.ProjectRole{{Name: "ci"}},
+			remote:  []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+		},
+		"AllValid": {
+			desired: desired,
+			remote:  issued(now+3600, 0, now+60),
+			want:    []xpv1.Condition{TokensReady(3)},
+		},
+		"SomeMissing": {
+			desired: desired,
+			remote: []argocdv1alpha1.ProjectRole{
+				{Name: "ci", JWTTokens: []argocdv1alpha1.JWTToken{{ID: "deploy", IssuedAt: 100}}},
+				{Name: "ops"},
+			},
+			failed: []v1alpha1.TokenFailure{{Role: "ops", ID: "admin", Error: "boom"}},
+			want:   []xpv1.Condition{TokensNotReady(tokenStates{declared: 3, pending: 1, failed: 1})},
+		},
+		"SomeExpired": {
+			desired: desired,
+			remote:  issued(now, now-60, now+60),
+			want:    []xpv1.Condition{TokensNotReady(tokenStates{declared: 3, expired: 2})},
+		},
+		"ReadyAgain": {
+			desired:    desired,
+			remote:     issued(),
+			conditions: []xpv1.Condition{TokensNotReady(tokenStates{declared: 3, pending: 3})},
+			want:       []xpv1.Condition{TokensReady(3)},
+		},
+		"TokensNoLongerDeclared": {
+			desired:    []v1alpha1.ProjectRole{{Name: "ci"}},
+			remote:     []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+			conditions: []xpv1.Condition{TokensNotReady(tokenStates{declared: 1, pending: 1})},
+			want:       []xpv1.Condition{TokensReady(0)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withConditions(tc.conditions...))
+			observeTokensReady(cr, countTokenStates(tc.desired, tc.remote, tc.failed, testNow))
+			if diff := cmp.Diff(tc.want, cr.Status.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("conditions: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveExportDesiredSpec(t *testing.T) {
+	spec := argocdv1alpha1.AppProjectSpec{Description: testDescription, SourceRepos: []string{"*"}}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desiredSpec := string(b)
+
+	type want struct {
+		annotation *string
+		lateInit   bool
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        want
+	}{
+		"Disabled": {
+			want: want{},
+		},
+		"Exported": {
+			annotations: map[string]string{projects.AnnotationKeyExportDesiredSpec: "true"},
+			want:        want{annotation: &desiredSpec, lateInit: true},
+		},
+		"AlreadyExported": {
+			annotations: map[string]string{projects.AnnotationKeyExportDesiredSpec: "true", projects.AnnotationKeyDesiredSpec: desiredSpec},
+			want:        want{annotation: &desiredSpec},
+		},
+		"OutdatedExport": {
+			annotations: map[string]string{projects.AnnotationKeyExportDesiredSpec: "true", projects.AnnotationKeyDesiredSpec: "{}"},
+			want:        want{annotation: &desiredSpec, lateInit: true},
+		},
+		"ExportRemovedWhenDisabled": {
+			annotations: map[string]string{projects.AnnotationKeyDesiredSpec: desiredSpec},
+			want:        want{lateInit: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(
+				withExternalName(testProjectExternalName),
+				withSpec(v1alpha1.ProjectParameters{Description: &testDescription, SourceRepos: []string{"*"}}),
+			)
+			meta.AddAnnotations(cr, tc.annotations)
+			client := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+					ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+					Spec:       spec,
+				}, nil)
+			})
+			e := &external{client: client, clock: clocktesting.NewFakePassiveClock(testNow)}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %v", err)
+			}
+
+			var got want
+			if v, ok := cr.GetAnnotations()[projects.AnnotationKeyDesiredSpec]; ok {
+				got.annotation = &v
+			}
+			got.lateInit = o.ResourceLateInitialized
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveEmptySpec(t *testing.T) {
+	params := v1alpha1.ProjectParameters{Description: &testDescription, SourceRepos: []string{"*"}}
+	allow := map[string]string{projects.AnnotationKeyAllowEmptySpec: "true"}
+
+	type want struct {
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		params      v1alpha1.ProjectParameters
+		annotations map[string]string
+		spec        argocdv1alpha1.AppProjectSpec
+		want
+	}{
+		"EmptySpecIsTransient": {
+			params: params,
+			want:   want{err: errors.Errorf(errFmtEmptySpec, testProjectExternalName, projects.AnnotationKeyAllowEmptySpec)},
+		},
+		"EmptySpecAllowed": {
+			params:      params,
+			annotations: allow,
+			want:        want{upToDate: false},
+		},
+		"EmptySpecDesired": {
+			want: want{upToDate: true},
+		},
+		"PartiallyEmptySpec": {
+			params: params,
+			spec:   argocdv1alpha1.AppProjectSpec{SourceRepos: []string{"*"}},
+			want:   want{upToDate: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withExternalName(testProjectExternalName), withSpec(tc.params))
+			meta.AddAnnotations(cr, tc.annotations)
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+					ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+					Spec:       tc.spec,
+				}, nil)
+			})
+			e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+			o, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want up to date, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveSnapshotInitialSpec(t *testing.T) {
+	initial := argocdv1alpha1.AppProjectSpec{Description: "managed by hand", SourceRepos: []string{"https://github.com/argoproj/argocd-example-apps"}}
+	b, err := json.Marshal(initial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := string(b)
+	changed := argocdv1alpha1.AppProjectSpec{Description: testDescription, SourceRepos: []string{"*"}}
+
+	observe := func(cr *v1alpha1.Project, spec argocdv1alpha1.AppProjectSpec) managed.ExternalObservation {
+		mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+			mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+				ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+				Spec:       spec,
+			}, nil)
+		})
+		e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+		o, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): unexpected error: %v", err)
+		}
+		return o
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{Description: &testDescription, SourceRepos: []string{"*"}}))
+		observe(cr, initial)
+		if v, ok := cr.GetAnnotations()[projects.AnnotationKeyInitialSpec]; ok {
+			t.Errorf("Observe(...): unexpected initial spec snapshot %s", v)
+		}
+	})
+
+	t.Run("SnapshotTakenOnce", func(t *testing.T) {
+		cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{Description: &testDescription, SourceRepos: []string{"*"}}))
+		meta.AddAnnotations(cr, map[string]string{projects.AnnotationKeySnapshotInitialSpec: "true"})
+
+		o := observe(cr, initial)
+		if diff := cmp.Diff(snapshot, cr.GetAnnotations()[projects.AnnotationKeyInitialSpec]); diff != "" {
+			t.Errorf("Observe(...): -want snapshot, +got snapshot:\n%s", diff)
+		}
+		if !o.ResourceLateInitialized {
+			t.Errorf("Observe(...): want the snapshot to be persisted by late initialization")
+		}
+
+		o = observe(cr, changed)
+		if diff := cmp.Diff(snapshot, cr.GetAnnotations()[projects.AnnotationKeyInitialSpec]); diff != "" {
+			t.Errorf("Observe(...): -want snapshot not overwritten, +got snapshot:\n%s", diff)
+		}
+		if o.ResourceLateInitialized {
+			t.Errorf("Observe(...): want no late initialization once the snapshot was taken")
+		}
+	})
+}
+
+func TestMintPendingTokensSecretKeys(t *testing.T) {
+	roles := func(tokens map[string][]string) ProjectModifier {
+		var rs []v1alpha1.ProjectRole
+		for _, role := range []string{"ci", "ci-deploy"} {
+			if _, ok := tokens[role]; !ok {
+				continue
+			}
+			r := v1alpha1.ProjectRole{Name: role}
+			for _, id := range tokens[role] {
+				r.JWTTokens = append(r.JWTTokens, v1alpha1.JWTToken{ID: ptr.To(id)})
+			}
+			rs = append(rs, r)
+		}
+		return withSpec(v1alpha1.ProjectParameters{Roles: rs})
+	}
+	keyTemplate := func(tmpl string) clients.TokenSecretKeys {
+		t.Helper()
+		keys, err := clients.TokenSecretKeysFor(&apisv1alpha1.ProviderConfigSpec{TokenSecretKeyTemplate: ptr.To(tmpl)})
+		if err != nil {
+			t.Fatalf("TokenSecretKeysFor(%q): %s", tmpl, err)
+		}
+		return keys
+	}
+
+	type want struct {
+		details managed.ConnectionDetails
+		err     error
+	}
+
+	cases := map[string]struct {
+		cr     *v1alpha1.Project
+		keys   clients.TokenSecretKeys
+		minted []string
+		want
+	}{
+		"DefaultKeys": {
+			cr:     Project(withExternalName(testProjectExternalName), roles(map[string][]string{"ci": {"deploy"}})),
+			minted: []string{"ci/deploy"},
+			want:   want{details: managed.ConnectionDetails{"ci.deploy": []byte("jwt")}},
+		},
+		"CustomTemplate": {
+			cr:     Project(withExternalName(testProjectExternalName), roles(map[string][]string{"ci": {"deploy"}})),
+			keys:   keyTemplate("{{ .ID }}_{{ .Role }}_token"),
+			minted: []string{"ci/deploy"},
+			want:   want{details: managed.ConnectionDetails{"deploy_ci_token": []byte("jwt")}},
+		},
+		"Collision": {
+			cr:   Project(withExternalName(testProjectExternalName), roles(map[string][]string{"ci": {"deploy-prod"}, "ci-deploy": {"prod"}})),
+			keys: keyTemplate("{{ .Role }}-{{ .ID }}"),
+			want: want{err: errors.Errorf(errFmtTokenKeyCollision, "ci-deploy-prod", "deploy-prod", "ci", "prod", "ci-deploy")},
+		},
+		"InvalidKey": {
+			cr:   Project(withExternalName(testProjectExternalName), roles(map[string][]string{"ci": {"deploy"}})),
+			keys: keyTemplate("{{ .Role }}/{{ .ID }}"),
+			want: want{err: errors.New(`invalid secret key "ci/deploy" of token deploy of role ci: a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')`)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				for _, m := range tc.minted {
+					role, id, _ := strings.Cut(m, "/")
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: role, Id: id},
+					).Return(&project.ProjectTokenResponse{Token: "jwt"}, nil)
+				}
+			})
+			e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow), secretKeys: tc.keys}
+			details, err := e.mintPendingTokens(context.Background(), tc.cr, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("mintPendingTokens(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.details, details); diff != "" {
+				t.Errorf("mintPendingTokens(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateManyRolesAndTokens(t *testing.T) {
+	const roles, tokensPerRole = 50, 20
+
+	var desired []v1alpha1.ProjectRole
+	var existing []argocdv1alpha1.ProjectRole
+	for i := 0; i < roles; i++ {
+		name := fmt.Sprintf("role-%d", i)
+		r := v1alpha1.ProjectRole{Name: name}
+		er := argocdv1alpha1.ProjectRole{Name: name}
+		for j := 0; j < tokensPerRole; j++ {
+			id := fmt.Sprintf("token-%d", j)
+			r.JWTTokens = append(r.JWTTokens, v1alpha1.JWTToken{ID: ptr.To(id)})
+			// every other token was already issued, e.g. by an update whose status was not persisted
+			if j%2 == 0 {
+				er.JWTTokens = append(er.JWTTokens, argocdv1alpha1.JWTToken{ID: id, IssuedAt: testNow.Unix()})
+			}
+		}
+		desired = append(desired, r)
+		existing = append(existing, er)
+	}
+	pending := roles * tokensPerRole / 2
+
+	mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+			Spec:       argocdv1alpha1.AppProjectSpec{Roles: existing},
+		}, nil).Times(1)
+		mcs.EXPECT().Update(context.Background(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil).Times(1)
+		mcs.EXPECT().CreateToken(context.Background(), gomock.Any()).Return(&project.ProjectTokenResponse{Token: "jwt"}, nil).Times(pending)
+	})
+	e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+	cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{Roles: desired}))
+
+	u, err := e.Update(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+	if len(u.ConnectionDetails) != pending {
+		t.Errorf("Update(...): want %d connection details, got %d", pending, len(u.ConnectionDetails))
+	}
+}
+
+func TestMintPendingTokensConcurrently(t *testing.T) {
+	const tokens = 3 * maxConcurrentTokenCreates
+
+	var desired []v1alpha1.JWTToken
+	for i := 0; i < tokens; i++ {
+		desired = append(desired, v1alpha1.JWTToken{ID: ptr.To(fmt.Sprintf("token-%d", i))})
+	}
+	var inFlight, maxInFlight atomic.Int32
+	mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		mcs.EXPECT().CreateToken(context.Background(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *project.ProjectTokenCreateRequest, _ ...grpc.CallOption) (*project.ProjectTokenResponse, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					if m := maxInFlight.Load(); n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				if req.Id == "token-0" {
+					return nil, errBoom
+				}
+				return &project.ProjectTokenResponse{Token: "jwt-" + req.Id}, nil
+			}).Times(tokens)
+	})
+	e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+	cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{
+		Roles: []v1alpha1.ProjectRole{{Name: "ci", JWTTokens: desired}},
+	}))
+
+	details, err := e.mintPendingTokens(context.Background(), cr, nil)
+	wantErr := utilerrors.NewAggregate([]error{errors.Wrapf(errBoom, errFmtCreateToken, "token-0", "ci")})
+	if diff := cmp.Diff(wantErr, err, test.EquateErrors()); diff != "" {
+		t.Errorf("mintPendingTokens(...): -want error, +got error:\n%s", diff)
+	}
+	if got := maxInFlight.Load(); got > maxConcurrentTokenCreates || got < 2 {
+		t.Errorf("mintPendingTokens(...): want at most %d tokens created at once, got %d", maxConcurrentTokenCreates, got)
+	}
+	if len(details) != tokens-1 {
+		t.Errorf("mintPendingTokens(...): want %d connection details, got %d", tokens-1, len(details))
+	}
+	for i := 1; i < tokens; i++ {
+		id := fmt.Sprintf("token-%d", i)
+		if got := string(details["ci."+id]); got != "jwt-"+id {
+			t.Errorf("mintPendingTokens(...): want token %s, got %q", id, got)
+		}
+	}
+	wantFailed := []v1alpha1.TokenFailure{{Role: "ci", ID: "token-0", Error: errors.Wrapf(errBoom, errFmtCreateToken, "token-0", "ci").Error()}}
+	if diff := cmp.Diff(wantFailed, cr.Status.AtProvider.FailedTokens); diff != "" {
+		t.Errorf("mintPendingTokens(...): -want failed tokens, +got failed tokens:\n%s", diff)
+	}
+}
+
+func TestUpdateDescriptionAndLabels(t *testing.T) {
+	labels := map[string]string{"team": "platform", "env": "prod"}
+	current := &argocdv1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testProjectExternalName,
+			Namespace:       "argocd",
+			UID:             "uid",
+			ResourceVersion: "42",
+			Generation:      3,
+			Labels:          map[string]string{"env": "dev"},
+			Annotations:     map[string]string{"note": "kept"},
+			Finalizers:      []string{"resources-finalizer.argocd.argoproj.io"},
+		},
+		Spec: argocdv1alpha1.AppProjectSpec{Description: testDescription},
+		Status: argocdv1alpha1.AppProjectStatus{
+			JWTTokensByRole: map[string]argocdv1alpha1.JWTTokens{"ci": {}},
+		},
+	}
+
+	var got *project.ProjectUpdateRequest
+	mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(current.DeepCopy(), nil).Times(1)
+		mcs.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *project.ProjectUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+				got = req
+				return req.Project, nil
+			}).Times(1)
+	})
+	e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+	cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{
+		Description:   &testDescription2,
+		ProjectLabels: labels,
+	}))
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+
+	want := current.DeepCopy()
+	want.Labels = labels
+	want.Spec = argocdv1alpha1.AppProjectSpec{Description: testDescription2}
+	if diff := cmp.Diff(want, got.Project, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestObserveListCache(t *testing.T) {
+	withListCache := func(name string) *v1alpha1.Project {
+		return Project(withObjectMeta(metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: name, projects.AnnotationKeyListCache: "true"},
+		}))
+	}
+	remote := func(name string) argocdv1alpha1.AppProject {
+		return argocdv1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	clock := clocktesting.NewFakeClock(testNow)
+	mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		// listed once for the first observes and again once the list expired
+		mcs.EXPECT().List(context.Background(), &project.ProjectQuery{}).Return(&argocdv1alpha1.AppProjectList{
+			Items: []argocdv1alpha1.AppProject{remote("alpha"), remote("beta")},
+		}, nil).Times(2)
+		// fetched by the update and by the observe after the update invalidated it
+		mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: "alpha"}).DoAndReturn(
+			func(context.Context, *project.ProjectQuery, ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+				p := remote("alpha")
+				return &p, nil
+			}).Times(2)
+		mcs.EXPECT().Update(context.Background(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil).Times(1)
+	})
+	e := &external{client: mc, clock: clock, cache: projects.NewListCache(projects.ListCacheTTL, clock), cacheKey: "argocd"}
+
+	observe := func(name string) {
+		t.Helper()
+		o, err := e.Observe(context.Background(), withListCache(name))
+		if err != nil {
+			t.Fatalf("Observe(%s): unexpected error: %v", name, err)
+		}
+		if !o.ResourceExists {
+			t.Errorf("Observe(%s): want resource to exist", name)
+		}
+	}
+
+	observe("alpha")
+	observe("beta")
+	if _, err := e.Update(context.Background(), withListCache("alpha")); err != nil {
+		t.Fatalf("Update(alpha): unexpected error: %v", err)
+	}
+	observe("alpha")
+	observe("beta")
+	clock.Step(projects.ListCacheTTL)
+	observe("beta")
+}
+
+func TestObserveApplicationCount(t *testing.T) {
+	type want struct {
+		count *int32
+		err   error
+	}
+
+	cases := map[string]struct {
+		enabled bool
+		apps    *argocdv1alpha1.ApplicationList
+		listErr error
+		want
+	}{
+		"Disabled": {
+			want: want{},
+		},
+		"NoApplications": {
+			enabled: true,
+			apps:    &argocdv1alpha1.ApplicationList{},
+			want:    want{count: ptr.To[int32](0)},
+		},
+		"Applications": {
+			enabled: true,
+			apps: &argocdv1alpha1.ApplicationList{Items: []argocdv1alpha1.Application{
+				{ObjectMeta: metav1.ObjectMeta{Name: "guestbook"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "helm-guestbook"}},
+			}},
+			want: want{count: ptr.To[int32](2)},
+		},
+		"ListFailed": {
+			enabled: true,
+			apps:    &argocdv1alpha1.ApplicationList{},
+			listErr: errBoom,
+			want:    want{err: errors.Wrap(errBoom, errListApplications)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{Description: &testDescription}))
+			if tc.enabled {
+				meta.AddAnnotations(cr, map[string]string{projects.AnnotationKeyObserveApplicationCount: "true"})
+			}
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+					ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+					Spec:       argocdv1alpha1.AppProjectSpec{Description: testDescription},
+				}, nil)
+			})
+			appClient := mockapplications.NewMockServiceClient(gomock.NewController(t))
+			if tc.apps != nil {
+				appClient.EXPECT().List(context.Background(), &argocdApplication.ApplicationQuery{Projects: []string{testProjectExternalName}}).Return(tc.apps, tc.listErr)
+			}
+			e := &external{client: mc, appClient: appClient, clock: clocktesting.NewFakePassiveClock(testNow)}
+			_, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.count, cr.Status.AtProvider.ApplicationCount); diff != "" {
+				t.Errorf("Observe(...): -want count, +got count:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeProjectDiff(t *testing.T) {
+	cases := map[string]struct {
+		p    v1alpha1.ProjectParameters
+		r    argocdv1alpha1.AppProject
+		want string
+	}{
+		"UpToDate": {
+			p:    v1alpha1.ProjectParameters{Description: &testDescription, ProjectLabels: testLabels},
+			r:    argocdv1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Labels: testLabels}, Spec: argocdv1alpha1.AppProjectSpec{Description: testDescription}},
+			want: "",
+		},
+		"DescriptionAndLabels": {
+			p: v1alpha1.ProjectParameters{
+				Description:   &testDescription2,
+				ProjectLabels: map[string]string{"team": "platform", "tier": "backend", "env": "prod"},
+			},
+			r: argocdv1alpha1.AppProject{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"env": "dev", "owner": "ops"}},
+				Spec:       argocdv1alpha1.AppProjectSpec{Description: testDescription},
+			},
+			want: "description changed, 2 labels added, 1 label removed, 1 label changed",
+		},
+		"SourceRepos": {
+			p:    v1alpha1.ProjectParameters{Description: &testDescription, SourceRepos: []string{"*"}},
+			r:    argocdv1alpha1.AppProject{Spec: argocdv1alpha1.AppProjectSpec{Description: testDescription}},
+			want: "sourceRepos changed",
+		},
+		"PermitOnlyProjectScopedClusters": {
+			p:    v1alpha1.ProjectParameters{Description: &testDescription, PermitOnlyProjectScopedClusters: ptr.To(true)},
+			r:    argocdv1alpha1.AppProject{Spec: argocdv1alpha1.AppProjectSpec{Description: testDescription}},
+			want: "permitOnlyProjectScopedClusters changed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := summarizeProjectDiff(&tc.p, &tc.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("summarizeProjectDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializePendingTokens(t *testing.T) {
+	roles := []v1alpha1.ProjectRole{{
+		Name: "ci",
+		JWTTokens: []v1alpha1.JWTToken{
+			{ID: ptr.To("deploy")},
+			{ID: ptr.To("pending")},
+			{IssuedAt: 50, ID: ptr.To("issued")},
+		},
+	}}
+	remote := []argocdv1alpha1.ProjectRole{{
+		Name: "ci",
+		JWTTokens: []argocdv1alpha1.JWTToken{
+			{IssuedAt: 100, ExpiresAt: 200, ID: "deploy"},
+			{IssuedAt: 60, ID: "issued"},
+		},
+	}}
+	want := []v1alpha1.ProjectRole{{
+		Name: "ci",
+		JWTTokens: []v1alpha1.JWTToken{
+			{IssuedAt: 100, ExpiresAt: ptr.To[int64](200), ID: ptr.To("deploy")},
+			{ID: ptr.To("pending")},
+			{IssuedAt: 50, ID: ptr.To("issued")},
+		},
+	}}
+
+	lateInitializePendingTokens(roles, remote)
+	if diff := cmp.Diff(want, roles); diff != "" {
+		t.Errorf("lateInitializePendingTokens(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsEqualDestinations(t *testing.T) {
+	cases := map[string]struct {
+		server string
+		remote string
+		want   bool
+	}{
+		"Identical": {
+			server: "https://kubernetes.default.svc",
+			remote: "https://kubernetes.default.svc",
+			want:   true,
+		},
+		"TrailingSlash": {
+			server: "https://kubernetes.default.svc/",
+			remote: "https://kubernetes.default.svc",
+			want:   true,
+		},
+		"DefaultPort": {
+			server: "https://kubernetes.default.svc:443",
+			remote: "https://kubernetes.default.svc",
+			want:   true,
+		},
+		"UppercaseSchemeAndHost": {
+			server: "HTTPS://Kubernetes.Default.svc",
+			remote: "https://kubernetes.default.svc",
+			want:   true,
+		},
+		"IPv6DefaultPort": {
+			server: "https://[2001:db8::1]:443/",
+			remote: "https://[2001:db8::1]",
+			want:   true,
+		},
+		"DifferentPort": {
+			server: "https://kubernetes.default.svc:6443",
+			remote: "https://kubernetes.default.svc",
+			want:   false,
+		},
+		"DifferentScheme": {
+			server: "http://kubernetes.default.svc",
+			remote: "https://kubernetes.default.svc",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isEqualDestinations(
+				[]v1alpha1.ApplicationDestination{{Server: &tc.server}},
+				[]argocdv1alpha1.ApplicationDestination{{Server: tc.remote}},
+			)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSourceRepos(t *testing.T) {
+	cases := map[string]struct {
+		repos []string
+		want  error
+	}{
+		"AllowOnly": {
+			repos: []string{"https://github.com/example/*"},
+		},
+		"MixedAllowAndDeny": {
+			repos: []string{"*", "!https://github.com/example/private.git", "!https://gitlab.com/*"},
+		},
+		"EmptyDeny": {
+			repos: []string{"*", "!"},
+			want:  errors.New(`source repository "!" has no repository`),
+		},
+		"DoubleNegation": {
+			repos: []string{"*", "!!https://github.com/example/private.git"},
+			want:  errors.New(`deny entry "!!https://github.com/example/private.git" must be negated only once`),
+		},
+		"WhitespaceAfterNegation": {
+			repos: []string{"*", "! https://github.com/example/private.git"},
+			want:  errors.New(`source repository "! https://github.com/example/private.git" must not contain surrounding whitespace`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, validateSourceRepos(tc.repos), test.EquateErrors()); diff != "" {
+				t.Errorf("validateSourceRepos(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateProject(t *testing.T) {
+	cases := map[string]struct {
+		spec v1alpha1.ProjectParameters
+		want error
+	}{
+		"Valid": {
+			spec: v1alpha1.ProjectParameters{SourceRepos: []string{"*", "!https://github.com/example/private.git"}},
+		},
+		"MalformedDeny": {
+			spec: v1alpha1.ProjectParameters{SourceRepos: []string{"*", "!!https://github.com/example/private.git"}},
+			want: errors.New(`deny entry "!!https://github.com/example/private.git" must be negated only once`),
+		},
+		"DenyAllSourceRepos": {
+			spec: v1alpha1.ProjectParameters{SourceRepos: []string{"*", "!*"}},
+			want: status.Error(codes.InvalidArgument, "source repository has an invalid format, '!*'"),
+		},
+		"DuplicateSourceRepo": {
+			spec: v1alpha1.ProjectParameters{SourceRepos: []string{"!https://gitlab.com/*", "!https://gitlab.com/*"}},
+			want: status.Error(codes.InvalidArgument, "source repository '!https://gitlab.com/*' already added"),
+		},
+		"InvalidNamespacePattern": {
+			spec: v1alpha1.ProjectParameters{Destinations: []v1alpha1.ApplicationDestination{{Namespace: ptr.To("!team-[a")}}},
+			want: errors.Wrap(errors.New("unexpected end of input"), `invalid destination namespace pattern "!team-[a"`),
+		},
+		"DenyAllNamespaces": {
+			spec: v1alpha1.ProjectParameters{Destinations: []v1alpha1.ApplicationDestination{{Server: ptr.To("*"), Namespace: ptr.To("!*")}}},
+			want: status.Error(codes.InvalidArgument, "namespace has an invalid format, '!*'"),
+		},
+		"DenyAllServers": {
+			spec: v1alpha1.ProjectParameters{Destinations: []v1alpha1.ApplicationDestination{{Server: ptr.To("!*"), Namespace: ptr.To("team-*")}}},
+			want: status.Error(codes.InvalidArgument, "server has an invalid format, '!*'"),
+		},
+		"DenyAllClusterNames": {
+			spec: v1alpha1.ProjectParameters{Destinations: []v1alpha1.ApplicationDestination{{Name: ptr.To("!*"), Namespace: ptr.To("team-*")}}},
+			want: status.Error(codes.InvalidArgument, "name has an invalid format, '!*'"),
+		},
+		"ValidRolePolicies": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"p, proj:testproject:ci, applications, sync, testproject/*, allow",
+				"p, proj:testproject:ci, applications, action/apps/Deployment/restart, testproject/guestbook, allow",
+				"p,proj:testproject:ci,logs,get,testproject/guestbook-ui,deny",
+			}}}},
+		},
+		"GroupingPolicy": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"g, ci-bots, proj:testproject:ci, applications, sync, allow",
+			}}}},
+			want: status.Error(codes.InvalidArgument,
+				"invalid policy rule 'g, ci-bots, proj:testproject:ci, applications, sync, allow': must be of the form: 'p, sub, res, act, obj, eft'"),
+		},
+		"OtherRoleSubject": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"p, proj:testproject:admin, applications, sync, testproject/*, allow",
+			}}}},
+			want: status.Error(codes.InvalidArgument,
+				"invalid policy rule 'p, proj:testproject:admin, applications, sync, testproject/*, allow': policy subject must be: 'proj:testproject:ci', not 'proj:testproject:admin'"),
+		},
+		"UnknownResource": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"p, proj:testproject:ci, projects, get, testproject/*, allow",
+			}}}},
+			want: status.Error(codes.InvalidArgument,
+				"invalid policy rule 'p, proj:testproject:ci, projects, get, testproject/*, allow': project resource must be: 'applications', 'repositories' or 'clusters', not 'projects'"),
+		},
+		"ObjectOfOtherProject": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"p, proj:testproject:ci, applications, get, default/*, allow",
+			}}}},
+			want: status.Error(codes.InvalidArgument,
+				"invalid policy rule 'p, proj:testproject:ci, applications, get, default/*, allow': object must be of form 'testproject/*' or 'testproject/<APPNAME>', not 'default/*'"),
+		},
+		"InvalidEffect": {
+			spec: v1alpha1.ProjectParameters{Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{
+				"p, proj:testproject:ci, applications, get, testproject/*, Allow",
+			}}}},
+			want: status.Error(codes.InvalidArgument,
+				"invalid policy rule 'p, proj:testproject:ci, applications, get, testproject/*, Allow': effect must be: 'allow' or 'deny'"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withExternalName(testProjectExternalName), withSpec(tc.spec))
+			if diff := cmp.Diff(tc.want, validateProject(cr), test.EquateErrors()); diff != "" {
+				t.Errorf("validateProject(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateDestinations(t *testing.T) {
+	cases := map[string]struct {
+		namespaces []string
+		want       error
+	}{
+		"Literal": {
+			namespaces: []string{"team-a"},
+		},
+		"Glob": {
+			namespaces: []string{"team-*", "!team-[!a-z]", "*"},
+		},
+		"InvalidGlob": {
+			namespaces: []string{"team-*", "team-[a"},
+			want:       errors.Wrap(errors.New("unexpected end of input"), `invalid destination namespace pattern "team-[a"`),
+		},
+		"InvalidNegatedGlob": {
+			namespaces: []string{"!team-[a"},
+			want:       errors.Wrap(errors.New("unexpected end of input"), `invalid destination namespace pattern "!team-[a"`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			destinations := make([]v1alpha1.ApplicationDestination, len(tc.namespaces))
+			for i := range tc.namespaces {
+				destinations[i].Namespace = &tc.namespaces[i]
+			}
+			if diff := cmp.Diff(tc.want, validateDestinations(destinations), test.EquateErrors()); diff != "" {
+				t.Errorf("validateDestinations(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEqualDestinationsNamespacePattern(t *testing.T) {
+	cases := map[string]struct {
+		namespace string
+		remote    string
+		want      bool
+	}{
+		"SamePattern": {
+			namespace: "team-*",
+			remote:    "team-*",
+			want:      true,
+		},
+		"PatternIsNotExpanded": {
+			namespace: "team-*",
+			remote:    "team-a",
+			want:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isEqualDestinations(
+				[]v1alpha1.ApplicationDestination{{Namespace: &tc.namespace}},
+				[]argocdv1alpha1.ApplicationDestination{{Namespace: tc.remote}},
+			)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsProjectUpToDateSourceRepos(t *testing.T) {
+	cases := map[string]struct {
+		repos  []string
+		remote []string
+		want   bool
+	}{
+		"MixedAllowAndDeny": {
+			repos:  []string{"*", "!https://github.com/example/private.git"},
+			remote: []string{"*", "!https://github.com/example/private.git"},
+			want:   true,
+		},
+		"DenyDiffersFromAllow": {
+			repos:  []string{"*", "!https://github.com/example/private.git"},
+			remote: []string{"*", "https://github.com/example/private.git"},
+			want:   false,
+		},
+		"OrderChanged": {
+			repos:  []string{"!https://github.com/example/private.git", "*"},
+			remote: []string{"*", "!https://github.com/example/private.git"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ProjectParameters{SourceRepos: tc.repos}
+			r := &argocdv1alpha1.AppProject{Spec: argocdv1alpha1.AppProjectSpec{SourceRepos: tc.remote}}
+			if diff := cmp.Diff(tc.want, isProjectUpToDate(p, r)); diff != "" {
+				t.Errorf("isProjectUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsProjectUpToDateRoleGroups(t *testing.T) {
+	cases := map[string]struct {
+		caseInsensitive *bool
+		groups          []string
+		remote          []string
+		want            bool
+	}{
+		"Equal": {
+			groups: []string{"my-org:team-alpha"},
+			remote: []string{"my-org:team-alpha"},
+			want:   true,
+		},
+		"SurroundingWhitespace": {
+			groups: []string{" my-org:team-alpha", "my-org:team-beta\t"},
+			remote: []string{"my-org:team-alpha ", "my-org:team-beta"},
+			want:   true,
+		},
+		"CaseDiffers": {
+			groups: []string{"My-Org:Team-Alpha"},
+			remote: []string{"my-org:team-alpha"},
+			want:   false,
+		},
+		"CaseInsensitive": {
+			caseInsensitive: ptr.To(true),
+			groups:          []string{"My-Org:Team-Alpha "},
+			remote:          []string{"my-org:team-alpha"},
+			want:            true,
+		},
+		"CaseInsensitiveDifferentGroup": {
+			caseInsensitive: ptr.To(true),
+			groups:          []string{"My-Org:Team-Alpha"},
+			remote:          []string{"my-org:team-beta"},
+			want:            false,
+		},
+		"InnerWhitespaceDiffers": {
+			caseInsensitive: ptr.To(true),
+			groups:          []string{"my-org: team-alpha"},
+			remote:          []string{"my-org:team-alpha"},
+			want:            false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ProjectParameters{
+				CaseInsensitiveRoleGroups: tc.caseInsensitive,
+				Roles:                     []v1alpha1.ProjectRole{{Name: "admin", Groups: tc.groups}},
+			}
+			r := &argocdv1alpha1.AppProject{Spec: argocdv1alpha1.AppProjectSpec{
+				Roles: []argocdv1alpha1.ProjectRole{{Name: "admin", Groups: tc.remote}},
+			}}
+			if diff := cmp.Diff(tc.want, isProjectUpToDate(p, r)); diff != "" {
+				t.Errorf("isProjectUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServerAddedDestination(t *testing.T) {
+	managedDestination := argocdv1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "team-a"}
+	addedDestination := argocdv1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "kube-system"}
+
+	cases := map[string]struct {
+		ignoreFields string
+		upToDate     bool
+		want         []argocdv1alpha1.ApplicationDestination
+	}{
+		"Removed": {
+			upToDate: false,
+			want:     []argocdv1alpha1.ApplicationDestination{managedDestination},
+		},
+		"KeptIfIgnored": {
+			ignoreFields: "destinations",
+			upToDate:     true,
+			want:         []argocdv1alpha1.ApplicationDestination{managedDestination, addedDestination},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			newProject := func() *v1alpha1.Project {
+				cr := Project(withExternalName(testProjectExternalName), withSpec(v1alpha1.ProjectParameters{
+					Destinations: []v1alpha1.ApplicationDestination{{Server: ptr.To(managedDestination.Server), Namespace: ptr.To(managedDestination.Namespace)}},
+				}))
+				if tc.ignoreFields != "" {
+					withIgnoreFieldsAnnotation(tc.ignoreFields)(cr)
+				}
+				return cr
+			}
+
+			var got []argocdv1alpha1.ApplicationDestination
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				mcs.EXPECT().Get(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&argocdv1alpha1.AppProject{
+					ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+					Spec: argocdv1alpha1.AppProjectSpec{
+						Destinations: []argocdv1alpha1.ApplicationDestination{managedDestination, addedDestination},
+					},
+				}, nil).Times(2)
+				mcs.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *project.ProjectUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+						got = req.Project.Spec.Destinations
+						return req.Project, nil
+					})
+			})
+			e := &external{client: mc, clock: clocktesting.NewFakePassiveClock(testNow)}
+
+			o, err := e.Observe(context.Background(), newProject())
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want up to date, +got up to date:\n%s", diff)
+			}
+			if _, err := e.Update(context.Background(), newProject()); err != nil {
+				t.Fatalf("Update(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(argocdv1alpha1.ApplicationDestination{})); diff != "" {
+				t.Errorf("Update(...): -want destinations, +got destinations:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Project
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, Labels: testLabels},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testProjectExternalName,
+								Labels: testLabels,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"ExternalNameDiffersFromName": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: "tenant-a-" + testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: "tenant-a-" + testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"CreateSystemFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+					withLastError(errors.Wrap(errBoom, errCreateFailed).Error()),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+		"SuccessfulWithRolesAndTokens": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Roles: []argocdv1alpha1.ProjectRole{{
+										Name:      "ci",
+										Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+										Groups:    []string{"ci-bots"},
+										JWTTokens: []argocdv1alpha1.JWTToken{},
+									}},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName}}, nil)
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: "ci", Id: "deploy"},
+					).Return(&project.ProjectTokenResponse{Token: "jwt"}, nil)
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+							Groups:    []string{"ci-bots"},
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+							Groups:    []string{"ci-bots"},
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{"ci.deploy": []byte("jwt")}},
+				err:    nil,
+			},
+		},
+		"TokenFailedAfterCreate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Roles: []argocdv1alpha1.ProjectRole{{
+										Name:      "ci",
+										Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+										Groups:    []string{"ci-bots"},
+										JWTTokens: []argocdv1alpha1.JWTToken{},
+									}},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName}}, nil)
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: "ci", Id: "deploy"},
+					).Return(nil, errBoom)
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+							Groups:    []string{"ci-bots"},
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+							Groups:    []string{"ci-bots"},
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
 					}),
+					withExternalName(testProjectExternalName),
+					withLastError(errors.Wrap(errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci"), errPartialCreate).Error()),
+					withFailedTokens(v1alpha1.TokenFailure{Role: "ci", ID: "deploy", Error: errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci").Error()}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"InvalidDenyEntry": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
 					withSpec(v1alpha1.ProjectParameters{
-						Description:   &testDescription,
-						ProjectLabels: testLabels,
+						SourceRepos: []string{"*", "!"},
 					}),
+					withExternalName(testProjectExternalName),
 				),
 			},
 			want: want{
 				cr: Project(
 					withSpec(v1alpha1.ProjectParameters{
-						Description:   &testDescription,
-						ProjectLabels: testLabels,
-					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+						SourceRepos: []string{"*", "!"},
 					}),
 					withExternalName(testProjectExternalName),
 				),
 				result: managed.ExternalCreation{},
-				err:    nil,
+				err:    errors.Wrap(errors.New(`source repository "!" has no repository`), errInvalidProject),
 			},
 		},
-		"CreateSystemFailed": {
+		"InvalidDestinationNamespace": {
 			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Create(
-						context.Background(),
-						&project.ProjectCreateRequest{
-							Project: &argocdv1alpha1.AppProject{
-								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
-								Spec: argocdv1alpha1.AppProjectSpec{
-									Description: testDescription,
-								},
-							},
-						},
-					).Return(
-						nil, errBoom)
-				}),
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
-					}),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription,
+						Destinations: []v1alpha1.ApplicationDestination{{Namespace: ptr.To("team-[a")}},
 					}),
 					withExternalName(testProjectExternalName),
 				),
@@ -399,22 +2054,41 @@ func TestCreate(t *testing.T) {
 			want: want{
 				cr: Project(
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription,
+						Destinations: []v1alpha1.ApplicationDestination{{Namespace: ptr.To("team-[a")}},
 					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.Wrap(errors.New("unexpected end of input"), `invalid destination namespace pattern "team-[a"`), errInvalidProject),
+			},
+		},
+		"InvalidRolePolicy": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{"p, proj:testproject:ci, applications, sync, testproject/*, permit"}}},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{Name: "ci", Policies: []string{"p, proj:testproject:ci, applications, sync, testproject/*, permit"}}},
 					}),
 					withExternalName(testProjectExternalName),
 				),
 				result: managed.ExternalCreation{},
-				err:    errors.Wrap(errBoom, errCreateFailed),
+				err: errors.Wrap(status.Error(codes.InvalidArgument,
+					"invalid policy rule 'p, proj:testproject:ci, applications, sync, testproject/*, permit': effect must be: 'allow' or 'deny'"), errInvalidProject),
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, clock: clocktesting.NewFakePassiveClock(testNow)}
 			o, err := e.Create(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -497,6 +2171,7 @@ func TestUpdate(t *testing.T) {
 						Description: &testDescription2,
 					}),
 					withExternalName(testProjectExternalName),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
 				),
 			},
 			want: want{
@@ -544,6 +2219,7 @@ func TestUpdate(t *testing.T) {
 						Name: testProjectExternalName,
 					}),
 					withExternalName(testProjectExternalName),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
 				),
 				result: managed.ExternalUpdate{},
 				err:    errors.Wrap(errBoom, errUpdateFailed),
@@ -602,16 +2278,172 @@ func TestUpdate(t *testing.T) {
 						Name: testProjectExternalName,
 					}),
 					withExternalName(testProjectExternalName),
+					withLastError(errors.Wrap(errBoom, errUpdateFailed).Error()),
 				),
 				result: managed.ExternalUpdate{},
 				err:    errors.Wrap(errBoom, errUpdateFailed),
 			},
 		},
+		"IgnoredDescriptionNotUpdated": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(func(_ context.Context, req *project.ProjectUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+						if req.Project.Spec.Description != testDescription {
+							t.Errorf("ignored description was updated to %q", req.Project.Spec.Description)
+						}
+						return req.Project, nil
+					})
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("description"),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						SourceRepos: []string{"*"},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withIgnoreFieldsAnnotation("description"),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						SourceRepos: []string{"*"},
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"PartialTokenFailure": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{Name: testProjectExternalName},
+					).Return(&argocdv1alpha1.AppProject{
+						ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+						Spec: argocdv1alpha1.AppProjectSpec{
+							Roles: []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+						},
+					}, nil)
+					mcs.EXPECT().Update(context.Background(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil)
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: "ci", Id: "deploy"},
+					).Return(nil, errBoom)
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: "ci", Id: "audit"},
+					).Return(&project.ProjectTokenResponse{Token: "jwt"}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name: "ci",
+							JWTTokens: []v1alpha1.JWTToken{
+								{ID: ptr.To("deploy")},
+								{ID: ptr.To("audit")},
+								{ID: ptr.To("expired"), ExpiresAt: ptr.To(testNow.Unix())},
+							},
+						}},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name: "ci",
+							JWTTokens: []v1alpha1.JWTToken{
+								{ID: ptr.To("deploy")},
+								{ID: ptr.To("audit")},
+								{ID: ptr.To("expired"), ExpiresAt: ptr.To(testNow.Unix())},
+							},
+						}},
+					}),
+					withLastError(errors.Wrap(utilerrors.NewAggregate([]error{
+						errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci"),
+						errors.Errorf(errFmtTokenExpired, "expired", "ci"),
+					}), errPartialUpdate).Error()),
+					withFailedTokens(
+						v1alpha1.TokenFailure{Role: "ci", ID: "deploy", Error: errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci").Error()},
+						v1alpha1.TokenFailure{Role: "ci", ID: "expired", Error: errors.Errorf(errFmtTokenExpired, "expired", "ci").Error()},
+					),
+				),
+				result: managed.ExternalUpdate{ConnectionDetails: managed.ConnectionDetails{"ci.audit": []byte("jwt")}},
+				err:    nil,
+			},
+		},
+		"FailedTokensCleared": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{Name: testProjectExternalName},
+					).Return(&argocdv1alpha1.AppProject{
+						ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+						Spec: argocdv1alpha1.AppProjectSpec{
+							Roles: []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+						},
+					}, nil)
+					mcs.EXPECT().Update(context.Background(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil)
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{Project: testProjectExternalName, Role: "ci", Id: "deploy"},
+					).Return(&project.ProjectTokenResponse{Token: "jwt"}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
+					}),
+					withLastError(errors.Wrap(errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci"), errPartialUpdate).Error()),
+					withFailedTokens(v1alpha1.TokenFailure{Role: "ci", ID: "deploy", Error: errors.Wrapf(errBoom, errFmtCreateToken, "deploy", "ci").Error()}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:      "ci",
+							JWTTokens: []v1alpha1.JWTToken{{ID: ptr.To("deploy")}},
+						}},
+					}),
+				),
+				result: managed.ExternalUpdate{ConnectionDetails: managed.ConnectionDetails{"ci.deploy": []byte("jwt")}},
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, clock: clocktesting.NewFakePassiveClock(testNow)}
 			u, err := e.Update(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -665,6 +2497,57 @@ func TestDelete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ExternalNameDiffersFromName": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&project.EmptyResponse{}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: "tenant-a-" + testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: "tenant-a-" + testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				err: nil,
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errors.New(`rpc error: code = NotFound desc = appprojects.argoproj.io "`+testProjectExternalName+`" not found`))
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withLastError(errBoom.Error()),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: nil,
+			},
+		},
 		"DeleteFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
@@ -689,6 +2572,7 @@ func TestDelete(t *testing.T) {
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
 					}),
+					withLastError(errors.Wrap(errBoom, errDeleteFailed).Error()),
 				),
 				err: errors.Wrap(errBoom, errDeleteFailed),
 			},
@@ -697,7 +2581,7 @@ func TestDelete(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, clock: clocktesting.NewFakePassiveClock(testNow)}
 			err := e.Delete(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -709,3 +2593,199 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDeletePropagation(t *testing.T) {
+	propagation := func(p projects.DeletionPropagation) ProjectModifier {
+		return func(r *v1alpha1.Project) {
+			meta.AddAnnotations(r, map[string]string{projects.AnnotationKeyDeletionPropagation: string(p)})
+		}
+	}
+	listQuery := &argocdApplication.ApplicationQuery{Projects: []string{testProjectExternalName}}
+	apps := &argocdv1alpha1.ApplicationList{Items: []argocdv1alpha1.Application{
+		{ObjectMeta: metav1.ObjectMeta{Name: "guestbook"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "helm-guestbook", DeletionTimestamp: &metav1.Time{Time: testNow}}},
+	}}
+	deleteRequest := func(cascade bool, policy *string) *argocdApplication.ApplicationDeleteRequest {
+		return &argocdApplication.ApplicationDeleteRequest{
+			Name:              ptr.To("guestbook"),
+			Cascade:           ptr.To(cascade),
+			PropagationPolicy: policy,
+			Project:           ptr.To(testProjectExternalName),
+		}
+	}
+
+	cases := map[string]struct {
+		cr        *v1alpha1.Project
+		apps      *argocdv1alpha1.ApplicationList
+		deleteApp *argocdApplication.ApplicationDeleteRequest
+		deleted   bool
+		want      error
+	}{
+		"NoApplications": {
+			cr:      Project(withExternalName(testProjectExternalName), propagation(projects.DeletionPropagationForeground)),
+			apps:    &argocdv1alpha1.ApplicationList{},
+			deleted: true,
+		},
+		"Refuse": {
+			cr:   Project(withExternalName(testProjectExternalName), propagation(projects.DeletionPropagationRefuse)),
+			apps: apps,
+			want: errors.Errorf(errFmtApplicationsExist, "guestbook, helm-guestbook"),
+		},
+		"Foreground": {
+			cr:        Project(withExternalName(testProjectExternalName), propagation(projects.DeletionPropagationForeground)),
+			apps:      apps,
+			deleteApp: deleteRequest(true, ptr.To("foreground")),
+		},
+		"Background": {
+			cr:        Project(withExternalName(testProjectExternalName), propagation(projects.DeletionPropagationBackground)),
+			apps:      apps,
+			deleteApp: deleteRequest(true, ptr.To("background")),
+		},
+		"Orphan": {
+			cr:        Project(withExternalName(testProjectExternalName), propagation(projects.DeletionPropagationOrphan)),
+			apps:      apps,
+			deleteApp: deleteRequest(false, nil),
+		},
+		"InvalidPropagation": {
+			cr:   Project(withExternalName(testProjectExternalName), propagation("Cascade")),
+			want: errors.Errorf("invalid deletion propagation %q in annotation %s, must be one of Refuse, Foreground, Background or Orphan", "Cascade", projects.AnnotationKeyDeletionPropagation),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				if tc.deleted {
+					mcs.EXPECT().Delete(context.Background(), &project.ProjectQuery{Name: testProjectExternalName}).Return(&project.EmptyResponse{}, nil)
+				}
+			})
+			appClient := mockapplications.NewMockServiceClient(gomock.NewController(t))
+			if tc.apps != nil {
+				appClient.EXPECT().List(context.Background(), listQuery).Return(tc.apps, nil)
+			}
+			if tc.deleteApp != nil {
+				appClient.EXPECT().Delete(context.Background(), tc.deleteApp).Return(&argocdApplication.ApplicationResponse{}, nil)
+			}
+			e := &external{client: mc, appClient: appClient, clock: clocktesting.NewFakePassiveClock(testNow)}
+			err := e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTokenAudit(t *testing.T) {
+	manyTokens := make([]argocdv1alpha1.JWTToken, maxTokenAuditRecords+10)
+	for i := range manyTokens {
+		manyTokens[i] = argocdv1alpha1.JWTToken{IssuedAt: int64(i + 1)}
+	}
+
+	cases := map[string]struct {
+		roles []argocdv1alpha1.ProjectRole
+		want  []v1alpha1.TokenAuditRecord
+	}{
+		"NoTokens": {
+			roles: []argocdv1alpha1.ProjectRole{{Name: "ci"}},
+			want:  nil,
+		},
+		"MostRecentFirst": {
+			roles: []argocdv1alpha1.ProjectRole{
+				{
+					Name: "ci",
+					JWTTokens: []argocdv1alpha1.JWTToken{
+						{IssuedAt: 100, ExpiresAt: 200, ID: "ci-1"},
+						{IssuedAt: 300, ExpiresAt: 400, ID: "ci-2"},
+					},
+				},
+				{
+					Name: "deployer",
+					JWTTokens: []argocdv1alpha1.JWTToken{
+						{IssuedAt: 200, ID: "deployer-1"},
+					},
+				},
+			},
+			want: []v1alpha1.TokenAuditRecord{
+				{Role: "ci", ID: ptr.To("ci-2"), IssuedAt: 300, ExpiresAt: ptr.To[int64](400)},
+				{Role: "deployer", ID: ptr.To("deployer-1"), IssuedAt: 200},
+				{Role: "ci", ID: ptr.To("ci-1"), IssuedAt: 100, ExpiresAt: ptr.To[int64](200)},
+			},
+		},
+		"Bounded": {
+			roles: []argocdv1alpha1.ProjectRole{{Name: "ci", JWTTokens: manyTokens}},
+			want: func() []v1alpha1.TokenAuditRecord {
+				want := make([]v1alpha1.TokenAuditRecord, maxTokenAuditRecords)
+				for i := range want {
+					want[i] = v1alpha1.TokenAuditRecord{Role: "ci", IssuedAt: int64(len(manyTokens) - i)}
+				}
+				return want
+			}(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generateTokenAudit(tc.roles)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("generateTokenAudit(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	expiring := func(d time.Duration) argocdv1alpha1.JWTToken {
+		return argocdv1alpha1.JWTToken{IssuedAt: testNow.Add(-time.Hour).Unix(), ExpiresAt: testNow.Add(d).Unix()}
+	}
+
+	cases := map[string]struct {
+		tokens []argocdv1alpha1.JWTToken
+		want   *string
+	}{
+		"NoTokens": {
+			want: nil,
+		},
+		"NeverExpires": {
+			tokens: []argocdv1alpha1.JWTToken{{IssuedAt: testNow.Unix()}},
+			want:   nil,
+		},
+		"Seconds": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(45 * time.Second)},
+			want:   ptr.To("in 45s"),
+		},
+		"Minutes": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(5*time.Minute + 30*time.Second)},
+			want:   ptr.To("in 5m30s"),
+		},
+		"Hours": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(13*time.Hour + 20*time.Minute)},
+			want:   ptr.To("in 13h"),
+		},
+		"Days": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(3*24*time.Hour + 4*time.Hour)},
+			want:   ptr.To("in 3d4h"),
+		},
+		"Years": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(3 * 365 * 24 * time.Hour)},
+			want:   ptr.To("in 3y"),
+		},
+		"Expired": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(-5 * time.Hour)},
+			want:   ptr.To("expired 5h ago"),
+		},
+		"Soonest": {
+			tokens: []argocdv1alpha1.JWTToken{expiring(48 * time.Hour), {IssuedAt: testNow.Unix()}, expiring(4 * time.Hour)},
+			want:   ptr.To("in 4h"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			roles := []argocdv1alpha1.ProjectRole{{Name: "ci", JWTTokens: tc.tokens}, {Name: "deployer"}}
+			got := tokenExpiry(roles, testNow)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("tokenExpiry(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package projects
+
+import (
+	"context"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
+)
+
+const (
+	errListApplications     = "cannot list applications of Argocd Project"
+	errFmtDeleteApplication = "cannot delete application %s of Argocd Project"
+	errFmtApplicationsExist = "refusing to delete Argocd Project used by applications %s"
+)
+
+// deleteApplications handles the applications of cr according to its deletion propagation
+// before the project is deleted. It returns true while applications are left, i.e. the
+// project can't be deleted yet.
+func (e *external) deleteApplications(ctx context.Context, cr *v1alpha1.Project) (bool, error) {
+	propagation, err := projects.DeletionPropagationOf(cr)
+	if err != nil || propagation == projects.DeletionPropagationNone {
+		return false, err
+	}
+	apps, err := e.appClient.List(ctx, &application.ApplicationQuery{Projects: []string{meta.GetExternalName(cr)}})
+	if err != nil {
+		return false, errors.Wrap(err, errListApplications)
+	}
+	if len(apps.Items) == 0 {
+		return false, nil
+	}
+	if propagation == projects.DeletionPropagationRefuse {
+		names := make([]string, len(apps.Items))
+		for i, app := range apps.Items {
+			names[i] = app.Name
+		}
+		return true, errors.Errorf(errFmtApplicationsExist, strings.Join(names, ", "))
+	}
+	for _, app := range apps.Items {
+		if app.DeletionTimestamp != nil {
+			continue
+		}
+		req := &application.ApplicationDeleteRequest{
+			Name:    ptr.To(app.Name),
+			Cascade: ptr.To(propagation.Cascade()),
+			Project: ptr.To(meta.GetExternalName(cr)),
+		}
+		if propagation.Cascade() {
+			req.PropagationPolicy = ptr.To(propagation.PropagationPolicy())
+		}
+		if app.Namespace != "" {
+			req.AppNamespace = ptr.To(app.Namespace)
+		}
+		if _, err := e.appClient.Delete(ctx, req); err != nil {
+			return true, errors.Wrapf(err, errFmtDeleteApplication, app.Name)
+		}
+	}
+	return true, nil
+}
+
+// countApplications returns the number of applications using cr if counting is enabled
+func (e *external) countApplications(ctx context.Context, cr *v1alpha1.Project) (*int32, error) {
+	if !projects.IsApplicationCountEnabled(cr) {
+		return nil, nil
+	}
+	apps, err := e.appClient.List(ctx, &application.ApplicationQuery{Projects: []string{meta.GetExternalName(cr)}})
+	if err != nil {
+		return nil, errors.Wrap(err, errListApplications)
+	}
+	return ptr.To(int32(len(apps.Items))), nil
+}
@@ -0,0 +1,31 @@
+package projects
+
+import (
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// summarizeProjectDiff describes which fields of p differ from the project r in ArgoCD.
+// It compares the same fields as isProjectUpToDate.
+func summarizeProjectDiff(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProject) string {
+	var d clients.DiffSummary
+	d.ChangedIf("description", clients.StringValue(p.Description) != r.Spec.Description)
+	if p.ProjectLabels != nil {
+		d.Map("label", p.ProjectLabels, r.Labels)
+	}
+	d.ChangedIf("sourceRepos", !cmp.Equal(p.SourceRepos, r.Spec.SourceRepos))
+	d.ChangedIf("destinations", !isEqualDestinations(p.Destinations, r.Spec.Destinations))
+	d.ChangedIf("roles", !isEqualRoles(p.Roles, r.Spec.Roles, clients.BoolValue(p.CaseInsensitiveRoleGroups)))
+	d.ChangedIf("clusterResourceWhitelist", !cmp.Equal(p.ClusterResourceWhitelist, r.Spec.ClusterResourceWhitelist))
+	d.ChangedIf("clusterResourceBlacklist", !cmp.Equal(p.ClusterResourceBlacklist, r.Spec.ClusterResourceBlacklist))
+	d.ChangedIf("namespaceResourceWhitelist", !cmp.Equal(p.NamespaceResourceWhitelist, r.Spec.NamespaceResourceWhitelist))
+	d.ChangedIf("namespaceResourceBlacklist", !cmp.Equal(p.NamespaceResourceBlacklist, r.Spec.NamespaceResourceBlacklist))
+	d.ChangedIf("orphanedResources", !isEqualOrphanedResources(p.OrphanedResources, r.Spec.OrphanedResources))
+	d.ChangedIf("syncWindows", !isEqualSyncWindows(p.SyncWindows, r.Spec.SyncWindows))
+	d.ChangedIf("signatureKeys", !isEqualSignatureKeys(p.SignatureKeys, r.Spec.SignatureKeys))
+	d.ChangedIf("permitOnlyProjectScopedClusters", clients.BoolValue(p.PermitOnlyProjectScopedClusters) != r.Spec.PermitOnlyProjectScopedClusters)
+	return d.String()
+}
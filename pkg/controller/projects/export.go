@@ -0,0 +1,54 @@
+package projects
+
+import (
+	"encoding/json"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
+)
+
+// exportDesiredSpec writes spec to the desired spec annotation of cr if the export is enabled,
+// and removes a previously exported spec otherwise. Project specs hold no secrets, so nothing
+// is redacted. It returns whether the annotations of cr changed.
+func exportDesiredSpec(cr *v1alpha1.Project, spec argocdv1alpha1.AppProjectSpec) (bool, error) {
+	current, exported := cr.GetAnnotations()[projects.AnnotationKeyDesiredSpec]
+	if !projects.IsDesiredSpecExportEnabled(cr) {
+		if exported {
+			meta.RemoveAnnotations(cr, projects.AnnotationKeyDesiredSpec)
+		}
+		return exported, nil
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return false, errors.Wrap(err, errExportSpec)
+	}
+	if exported && current == string(b) {
+		return false, nil
+	}
+	meta.AddAnnotations(cr, map[string]string{projects.AnnotationKeyDesiredSpec: string(b)})
+	return true, nil
+}
+
+// snapshotInitialSpec writes the observed spec to the initial spec annotation of cr if the
+// snapshot is enabled and was not taken yet. An existing snapshot is never overwritten, so
+// that it keeps the spec the project had before it was managed. It returns whether the
+// annotations of cr changed.
+func snapshotInitialSpec(cr *v1alpha1.Project, observed argocdv1alpha1.AppProjectSpec) (bool, error) {
+	if !projects.IsInitialSpecSnapshotEnabled(cr) {
+		return false, nil
+	}
+	if _, taken := cr.GetAnnotations()[projects.AnnotationKeyInitialSpec]; taken {
+		return false, nil
+	}
+	b, err := json.Marshal(observed)
+	if err != nil {
+		return false, errors.Wrap(err, errSnapshotSpec)
+	}
+	meta.AddAnnotations(cr, map[string]string{projects.AnnotationKeyInitialSpec: string(b)})
+	return true, nil
+}
@@ -0,0 +1,77 @@
+package projects
+
+import (
+	"time"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	syncWindowKindAllow = "allow"
+	syncWindowKindDeny  = "deny"
+)
+
+var syncWindowParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// observeSyncWindows reports whether any sync window is active at now, whether a manual sync is allowed at now
+// and whether automated syncs are frozen at now. It follows the rules ArgoCD applies when a sync is requested
+// and returns nil for all of them if there are no windows.
+func observeSyncWindows(windows argocdv1alpha1.SyncWindows, now time.Time) (active, manualSyncAllowed, frozen *bool) {
+	if len(windows) == 0 {
+		return nil, nil, nil
+	}
+
+	var activeAllow, activeDeny, inactiveAllow bool
+	denyManual, inactiveAllowManual := true, true
+	for _, w := range windows {
+		if w == nil {
+			continue
+		}
+		isActive, ok := isSyncWindowActive(w, now)
+		if !ok {
+			continue
+		}
+		switch {
+		case isActive && w.Kind == syncWindowKindDeny:
+			activeDeny = true
+			denyManual = denyManual && w.ManualSync
+		case isActive && w.Kind == syncWindowKindAllow:
+			activeAllow = true
+		case !isActive && w.Kind == syncWindowKindAllow:
+			inactiveAllow = true
+			inactiveAllowManual = inactiveAllowManual && w.ManualSync
+		}
+	}
+
+	allowed := true
+	switch {
+	case activeDeny:
+		allowed = denyManual
+	case activeAllow:
+		allowed = true
+	case inactiveAllow:
+		allowed = inactiveAllowManual
+	}
+	isActive := activeAllow || activeDeny
+	isFrozen := activeDeny || (!activeAllow && inactiveAllow)
+	return &isActive, &allowed, &isFrozen
+}
+
+// isSyncWindowActive returns whether the window is active at now. The second
+// return value is false if the schedule, duration or time zone is invalid.
+func isSyncWindowActive(w *argocdv1alpha1.SyncWindow, now time.Time) (bool, bool) {
+	spec := w.Schedule
+	if w.TimeZone != "" {
+		spec = "CRON_TZ=" + w.TimeZone + " " + spec
+	}
+	schedule, err := syncWindowParser.Parse(spec)
+	if err != nil {
+		return false, false
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return false, false
+	}
+	return schedule.Next(now.Add(-duration)).Before(now), true
+}
@@ -0,0 +1,396 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
+)
+
+const (
+	// TypeOrphanedTokens indicates whether tokens were issued for roles which no longer exist in a project
+	TypeOrphanedTokens xpv1.ConditionType = "OrphanedTokens"
+
+	// ReasonOrphanedTokensFound is set when a project has tokens of roles which no longer exist
+	ReasonOrphanedTokensFound xpv1.ConditionReason = "OrphanedTokensFound"
+	// ReasonNoOrphanedTokens is set when the orphaned tokens of a project were cleaned up
+	ReasonNoOrphanedTokens xpv1.ConditionReason = "NoOrphanedTokens"
+
+	// TypeTokensReady indicates whether every declared token of a project was issued and is not expired
+	TypeTokensReady xpv1.ConditionType = "TokensReady"
+
+	// ReasonAllTokensReady is set when every declared token of a project was issued and is not expired
+	ReasonAllTokensReady xpv1.ConditionReason = "AllTokensReady"
+	// ReasonTokensNotReady is set when declared tokens of a project are pending, failed or expired
+	ReasonTokensNotReady xpv1.ConditionReason = "TokensNotReady"
+
+	// maxConcurrentTokenCreates is the number of tokens of a project created at once
+	maxConcurrentTokenCreates = 4
+
+	errFmtCreateToken       = "cannot create token %s of role %s"
+	errFmtTokenExpired      = "token %s of role %s expires in the past"
+	errFmtTokenKeyCollision = "secret key %q of token %s of role %s collides with token %s of role %s"
+	msgFmtOrphanedTokens    = "%d token(s) of roles which no longer exist: %s"
+	msgFmtTokensReady       = "%d declared token(s) ready"
+	msgFmtTokensNotReady    = "%d of %d declared token(s) not ready: %d pending, %d failed, %d expired"
+)
+
+// OrphanedTokensFound returns a condition that warns about tokens of roles which no longer exist in a project
+func OrphanedTokensFound(roles []string, count int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOrphanedTokens,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOrphanedTokensFound,
+		Message:            fmt.Sprintf(msgFmtOrphanedTokens, count, strings.Join(roles, ", ")),
+	}
+}
+
+// NoOrphanedTokens returns a condition that indicates that a project has no orphaned tokens anymore
+func NoOrphanedTokens() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOrphanedTokens,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoOrphanedTokens,
+	}
+}
+
+// findOrphanedTokens returns the tokens issued by ArgoCD for roles which are missing from
+// either the desired or the remote roles. Tokens of a role removed from the desired roles
+// are orphaned as the next update deletes the role.
+func findOrphanedTokens(tokensByRole map[string]argocdv1alpha1.JWTTokens, desired []v1alpha1.ProjectRole, remote []argocdv1alpha1.ProjectRole) []v1alpha1.TokenAuditRecord {
+	exists := func(role string) bool {
+		inDesired, inRemote := false, false
+		for _, r := range desired {
+			inDesired = inDesired || r.Name == role
+		}
+		for _, r := range remote {
+			inRemote = inRemote || r.Name == role
+		}
+		return inDesired && inRemote
+	}
+	roles := make([]string, 0, len(tokensByRole))
+	for role := range tokensByRole {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	var orphaned []v1alpha1.TokenAuditRecord
+	for _, role := range roles {
+		if exists(role) {
+			continue
+		}
+		for _, t := range tokensByRole[role].Items {
+			rec := v1alpha1.TokenAuditRecord{Role: role, IssuedAt: t.IssuedAt}
+			if t.ID != "" {
+				rec.ID = ptr.To(t.ID)
+			}
+			if t.ExpiresAt != 0 {
+				rec.ExpiresAt = ptr.To(t.ExpiresAt)
+			}
+			orphaned = append(orphaned, rec)
+		}
+	}
+	return orphaned
+}
+
+// observeOrphanedTokens records the orphaned tokens of cr and warns about them with a
+// condition. The condition is only cleared if it was set before, so that projects which
+// never had orphaned tokens don't carry it.
+func observeOrphanedTokens(cr *v1alpha1.Project, orphaned []v1alpha1.TokenAuditRecord) {
+	cr.Status.AtProvider.OrphanedTokens = orphaned
+	if len(orphaned) == 0 {
+		if cr.GetCondition(TypeOrphanedTokens).Status == corev1.ConditionTrue {
+			cr.SetConditions(NoOrphanedTokens())
+		}
+		return
+	}
+	var roles []string
+	for _, t := range orphaned {
+		if len(roles) == 0 || roles[len(roles)-1] != t.Role {
+			roles = append(roles, t.Role)
+		}
+	}
+	cr.SetConditions(OrphanedTokensFound(roles, len(orphaned)))
+}
+
+// TokensReady returns a condition that indicates that all count declared tokens of a project are ready
+func TokensReady(count int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokensReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAllTokensReady,
+		Message:            fmt.Sprintf(msgFmtTokensReady, count),
+	}
+}
+
+// TokensNotReady returns a condition that indicates that declared tokens of a project are pending,
+// failed or expired. Tokens which failed to be created are not counted as pending.
+func TokensNotReady(s tokenStates) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokensReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokensNotReady,
+		Message:            fmt.Sprintf(msgFmtTokensNotReady, s.pending+s.failed+s.expired, s.declared, s.pending, s.failed, s.expired),
+	}
+}
+
+// tokenStates counts the declared tokens of a project by their state
+type tokenStates struct {
+	declared, pending, failed, expired int
+}
+
+// countTokenStates counts the tokens with an ID declared in the desired roles by whether they weren't
+// issued by ArgoCD yet, failed to be created or expired at now.
+func countTokenStates(desired []v1alpha1.ProjectRole, remote []argocdv1alpha1.ProjectRole, failed []v1alpha1.TokenFailure, now time.Time) tokenStates {
+	issued := indexTokens(remote)
+	failures := map[tokenRef]bool{}
+	for _, f := range failed {
+		failures[tokenRef{role: f.Role, id: f.ID}] = true
+	}
+	var s tokenStates
+	for _, r := range desired {
+		for _, t := range r.JWTTokens {
+			if ptr.Deref(t.ID, "") == "" {
+				continue
+			}
+			s.declared++
+			ref := tokenRef{role: r.Name, id: *t.ID}
+			it, ok := issued[ref]
+			switch {
+			case !ok && failures[ref]:
+				s.failed++
+			case !ok:
+				s.pending++
+			case it.ExpiresAt != 0 && it.ExpiresAt <= now.Unix():
+				s.expired++
+			}
+		}
+	}
+	return s
+}
+
+// observeTokensReady reports whether the declared tokens of cr are ready with a condition,
+// which is re-evaluated on each observation. Projects which never declared tokens don't
+// carry the condition.
+func observeTokensReady(cr *v1alpha1.Project, s tokenStates) {
+	switch {
+	case s.pending+s.failed+s.expired > 0:
+		cr.SetConditions(TokensNotReady(s))
+	case s.declared > 0 || cr.GetCondition(TypeTokensReady).Reason != "":
+		cr.SetConditions(TokensReady(s.declared))
+	}
+}
+
+// isPendingToken returns whether t is declared with an ID, but was not issued by ArgoCD yet
+func isPendingToken(t v1alpha1.JWTToken) bool {
+	return t.IssuedAt == 0 && ptr.Deref(t.ID, "") != ""
+}
+
+// withoutPendingTokens removes the tokens which were not issued yet from the roles of spec.
+// They are minted once the roles exist, as a token record alone can't be used.
+func withoutPendingTokens(spec *argocdv1alpha1.AppProjectSpec) {
+	for i, r := range spec.Roles {
+		if r.JWTTokens == nil {
+			continue
+		}
+		tokens := make([]argocdv1alpha1.JWTToken, 0, len(r.JWTTokens))
+		for _, t := range r.JWTTokens {
+			if t.IssuedAt == 0 && t.ID != "" {
+				continue
+			}
+			tokens = append(tokens, t)
+		}
+		spec.Roles[i].JWTTokens = tokens
+	}
+}
+
+// lateInitializePendingTokens sets the issue and expiry time of pending tokens which were
+// issued by ArgoCD in the meantime.
+func lateInitializePendingTokens(roles []v1alpha1.ProjectRole, remote []argocdv1alpha1.ProjectRole) {
+	issued := indexTokens(remote)
+	for i := range roles {
+		for j, t := range roles[i].JWTTokens {
+			if !isPendingToken(t) {
+				continue
+			}
+			rt, ok := issued[tokenRef{role: roles[i].Name, id: *t.ID}]
+			if !ok {
+				continue
+			}
+			roles[i].JWTTokens[j].IssuedAt = rt.IssuedAt
+			if rt.ExpiresAt != 0 {
+				roles[i].JWTTokens[j].ExpiresAt = ptr.To(rt.ExpiresAt)
+			}
+		}
+	}
+}
+
+// tokenRef identifies a declared token by role and ID
+type tokenRef struct {
+	role, id string
+}
+
+// tokenRequest is a pending token which has to be created
+type tokenRequest struct {
+	tokenRef
+	token v1alpha1.JWTToken
+}
+
+// indexTokens indexes the tokens of roles with an ID by role and ID, so that
+// declared tokens can be matched without scanning all roles for each of them.
+func indexTokens(roles []argocdv1alpha1.ProjectRole) map[tokenRef]argocdv1alpha1.JWTToken {
+	tokens := map[tokenRef]argocdv1alpha1.JWTToken{}
+	for _, r := range roles {
+		for _, t := range r.JWTTokens {
+			if t.ID == "" {
+				continue
+			}
+			tokens[tokenRef{role: r.Name, id: t.ID}] = t
+		}
+	}
+	return tokens
+}
+
+// pendingTokens returns the pending tokens of all roles of cr which don't exist in the
+// existing roles of the project yet, in the order they are declared.
+func pendingTokens(cr *v1alpha1.Project, existing []argocdv1alpha1.ProjectRole) []tokenRequest {
+	issued := indexTokens(existing)
+	var pending []tokenRequest
+	for _, r := range cr.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			if !isPendingToken(t) {
+				continue
+			}
+			ref := tokenRef{role: r.Name, id: *t.ID}
+			if _, ok := issued[ref]; ok {
+				continue
+			}
+			pending = append(pending, tokenRequest{tokenRef: ref, token: t})
+		}
+	}
+	return pending
+}
+
+// tokenSecretKeys returns the connection secret keys of all declared tokens of cr. It fails if
+// two tokens share a key, as one token would overwrite the other in the connection secret.
+func (e *external) tokenSecretKeys(cr *v1alpha1.Project) (map[tokenRef]string, error) {
+	keys := map[tokenRef]string{}
+	owners := map[string]tokenRef{}
+	for _, r := range cr.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			if ptr.Deref(t.ID, "") == "" {
+				continue
+			}
+			ref := tokenRef{role: r.Name, id: *t.ID}
+			key, err := e.secretKeys.Key(ref.role, ref.id)
+			if err != nil {
+				return nil, err
+			}
+			if other, ok := owners[key]; ok && other != ref {
+				return nil, errors.Errorf(errFmtTokenKeyCollision, key, other.id, other.role, ref.id, ref.role)
+			}
+			owners[key] = ref
+			keys[ref] = key
+		}
+	}
+	return keys, nil
+}
+
+// mintPendingTokens creates the pending tokens of all roles of cr which don't exist in the
+// existing roles of the project, as read from ArgoCD by the caller. The tokens are returned as
+// connection details keyed by the token secret key template of the ProviderConfig, e.g.
+// ci.deploy for token deploy of role ci by default, or nil if no token was created.
+// All tokens to create are collected before the first request, so that the project is not
+// read again per role or token.
+// A token which can't be created doesn't stop the others from being created. The failed tokens
+// are recorded in the status of cr and their errors are returned aggregated. No token is created
+// if the secret keys of the tokens collide.
+func (e *external) mintPendingTokens(ctx context.Context, cr *v1alpha1.Project, existing []argocdv1alpha1.ProjectRole) (managed.ConnectionDetails, error) {
+	keys, err := e.tokenSecretKeys(cr)
+	if err != nil {
+		return nil, err
+	}
+	pending := pendingTokens(cr, existing)
+	tokens, mintErrs := e.mintTokens(ctx, cr, pending)
+
+	var details managed.ConnectionDetails
+	var failed []v1alpha1.TokenFailure
+	var errs []error
+	for i, req := range pending {
+		if err := mintErrs[i]; err != nil {
+			failed = append(failed, v1alpha1.TokenFailure{Role: req.role, ID: req.id, Error: err.Error()})
+			errs = append(errs, err)
+			continue
+		}
+		if details == nil {
+			details = managed.ConnectionDetails{}
+		}
+		details[keys[req.tokenRef]] = []byte(tokens[i])
+	}
+	cr.Status.AtProvider.FailedTokens = failed
+	return details, utilerrors.NewAggregate(errs)
+}
+
+// mintTokens creates the pending tokens, at most maxConcurrentTokenCreates at once. The JWT or the
+// error of every token is returned at the index of the token, so that a failed token doesn't abort
+// the others and the results keep the order of the pending tokens.
+func (e *external) mintTokens(ctx context.Context, cr *v1alpha1.Project, pending []tokenRequest) ([]string, []error) {
+	tokens := make([]string, len(pending))
+	errs := make([]error, len(pending))
+	sem := make(chan struct{}, maxConcurrentTokenCreates)
+	var wg sync.WaitGroup
+	for i := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			tokens[i], errs[i] = e.mintToken(ctx, cr, pending[i].role, pending[i].token)
+		}(i)
+	}
+	wg.Wait()
+	return tokens, errs
+}
+
+// mintToken creates the pending token t of role and returns the JWT
+func (e *external) mintToken(ctx context.Context, cr *v1alpha1.Project, role string, t v1alpha1.JWTToken) (string, error) {
+	req := &project.ProjectTokenCreateRequest{
+		Project: meta.GetExternalName(cr),
+		Role:    role,
+		Id:      *t.ID,
+	}
+	if t.ExpiresAt != nil {
+		req.ExpiresIn = *t.ExpiresAt - e.clock.Now().Unix()
+		if req.ExpiresIn <= 0 {
+			return "", errors.Errorf(errFmtTokenExpired, *t.ID, role)
+		}
+	}
+	resp, err := projects.CreateToken(ctx, e.client, req, e.tokenBackoff)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtCreateToken, *t.ID, role)
+	}
+	return resp.Token, nil
+}
@@ -0,0 +1,55 @@
+package projects
+
+import (
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+)
+
+// validateProject checks the project with the rules ArgoCD applies before it stores a project, e.g.
+// the grammar of the policies of its roles, so that an invalid project fails early instead of being
+// rejected by the server. ArgoCD doesn't check that the source repositories are well-formed nor that
+// the destination namespaces are valid patterns, this is done on top.
+func validateProject(cr *v1alpha1.Project) error {
+	if err := validateSourceRepos(cr.Spec.ForProvider.SourceRepos); err != nil {
+		return err
+	}
+	if err := validateDestinations(cr.Spec.ForProvider.Destinations); err != nil {
+		return err
+	}
+	return generateCreateProjectOptions(cr).Project.ValidateProject()
+}
+
+// validateSourceRepos checks that every source repository, and every repository negated by a deny entry, is set
+func validateSourceRepos(repos []string) error {
+	for _, r := range repos {
+		repo, deny := strings.CutPrefix(r, "!")
+		switch {
+		case strings.TrimSpace(repo) == "":
+			return errors.Errorf("source repository %q has no repository", r)
+		case deny && strings.HasPrefix(repo, "!"):
+			return errors.Errorf("deny entry %q must be negated only once", r)
+		case strings.TrimSpace(repo) != repo:
+			return errors.Errorf("source repository %q must not contain surrounding whitespace", r)
+		}
+	}
+	return nil
+}
+
+// validateDestinations checks that the namespace of every destination, and every namespace negated
+// by a deny entry, is a valid glob pattern
+func validateDestinations(destinations []v1alpha1.ApplicationDestination) error {
+	for _, d := range destinations {
+		if d.Namespace == nil {
+			continue
+		}
+		pattern := strings.TrimPrefix(*d.Namespace, "!")
+		if _, err := glob.Compile(pattern); err != nil {
+			return errors.Wrapf(err, "invalid destination namespace pattern %q", *d.Namespace)
+		}
+	}
+	return nil
+}
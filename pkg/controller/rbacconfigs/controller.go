@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacconfigs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/features"
+)
+
+const (
+	errNotRBACConfig   = "managed resource is not a Argocd RBAC config custom resource"
+	errUpdateConfigMap = "cannot update ArgoCD RBAC ConfigMap"
+)
+
+// SetupRBACConfig adds a controller that reconciles RBAC configs.
+func SetupRBACConfig(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.RBACConfigKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), reader: mgr.GetAPIReader()}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(5 * time.Minute),
+	}
+
+	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RBACConfig{}).
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RBACConfigGroupVersionKind),
+			opts...), o.GlobalRateLimiter))
+}
+
+// The RBAC settings are stored in the argocd-rbac-cm ConfigMap, see package settings.
+type connector struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RBACConfig); !ok {
+		return nil, errors.New(errNotRBACConfig)
+	}
+	if err := clients.TrackProviderConfigUsage(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	if err := settings.CheckInCluster(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	return clients.WithPauseHandling(&external{kube: c.kube, reader: c.reader}), nil
+}
+
+type external struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RBACConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRBACConfig)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.RBACConfigMapName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !hasManagedKeys(cm, cr.Spec.ForProvider) {
+		return managed.ExternalObservation{}, nil
+	}
+	csv, err := renderPolicyCSV(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isRBACConfigUpToDate(cr.Spec.ForProvider, csv, cm),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RBACConfig)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRBACConfig)
+	}
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RBACConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRBACConfig)
+	}
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RBACConfig)
+	if !ok {
+		return errors.New(errNotRBACConfig)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.RBACConfigMapName)
+	if err != nil {
+		return err
+	}
+	if !hasManagedKeys(cm, cr.Spec.ForProvider) {
+		return nil
+	}
+	delete(cm.Data, settings.PolicyCSVKey)
+	if cr.Spec.ForProvider.PolicyDefault != nil {
+		delete(cm.Data, settings.PolicyDefaultKey)
+	}
+	if cr.Spec.ForProvider.Scopes != nil {
+		delete(cm.Data, settings.ScopesKey)
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func (e *external) apply(ctx context.Context, cr *v1alpha1.RBACConfig) error {
+	csv, err := renderPolicyCSV(cr.Spec.ForProvider)
+	if err != nil {
+		return err
+	}
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.RBACConfigMapName)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[settings.PolicyCSVKey] = csv
+	if cr.Spec.ForProvider.PolicyDefault != nil {
+		cm.Data[settings.PolicyDefaultKey] = *cr.Spec.ForProvider.PolicyDefault
+	}
+	if cr.Spec.ForProvider.Scopes != nil {
+		cm.Data[settings.ScopesKey] = renderScopes(cr.Spec.ForProvider.Scopes)
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+// hasManagedKeys reports whether cm holds any of the settings managed for p.
+func hasManagedKeys(cm *corev1.ConfigMap, p v1alpha1.RBACConfigParameters) bool {
+	if _, ok := cm.Data[settings.PolicyCSVKey]; ok {
+		return true
+	}
+	if _, ok := cm.Data[settings.PolicyDefaultKey]; ok && p.PolicyDefault != nil {
+		return true
+	}
+	_, ok := cm.Data[settings.ScopesKey]
+	return ok && p.Scopes != nil
+}
+
+// isRBACConfigUpToDate compares the settings of cm with the rendered csv and
+// the optional settings of p. Edits to the policy.csv setting made outside the
+// provider are reported as a difference.
+func isRBACConfigUpToDate(p v1alpha1.RBACConfigParameters, csv string, cm *corev1.ConfigMap) bool {
+	if !cmp.Equal(normalizePolicyCSV(csv), normalizePolicyCSV(cm.Data[settings.PolicyCSVKey]), cmpopts.EquateEmpty()) {
+		return false
+	}
+	if p.PolicyDefault != nil && *p.PolicyDefault != cm.Data[settings.PolicyDefaultKey] {
+		return false
+	}
+	if p.Scopes != nil && !cmp.Equal(p.Scopes, parseScopes(cm.Data[settings.ScopesKey]), cmpopts.EquateEmpty()) {
+		return false
+	}
+	return true
+}
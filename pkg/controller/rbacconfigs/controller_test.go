@@ -0,0 +1,427 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacconfigs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom    = errors.New("boom")
+	testParams = v1alpha1.RBACConfigParameters{
+		PolicyDefault: ptr.To("role:readonly"),
+		Scopes:        []string{"groups", "email"},
+		Roles: []v1alpha1.RBACRole{{
+			Name: "deployer",
+			Policies: []v1alpha1.RBACPolicy{
+				{Resource: "applications", Action: "sync", Object: ptr.To("default/*")},
+				{Resource: "applications", Action: "delete", Effect: ptr.To("deny")},
+			},
+		}},
+		GroupBindings: []v1alpha1.RBACGroupBinding{{Subject: "my-org:deployers", Role: "deployer"}},
+	}
+	testPolicyCSV = `p, role:deployer, applications, sync, default/*, allow
+p, role:deployer, applications, delete, *, deny
+g, my-org:deployers, role:deployer
+`
+	testData = map[string]string{
+		settings.PolicyCSVKey:     testPolicyCSV,
+		settings.PolicyDefaultKey: "role:readonly",
+		settings.ScopesKey:        "[groups, email]",
+	}
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.RBACConfig
+}
+
+func RBACConfig(m ...RBACConfigModifier) *v1alpha1.RBACConfig {
+	cr := &v1alpha1.RBACConfig{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type RBACConfigModifier func(*v1alpha1.RBACConfig)
+
+func withSpec(p v1alpha1.RBACConfigParameters) RBACConfigModifier {
+	return func(r *v1alpha1.RBACConfig) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) RBACConfigModifier {
+	return func(r *v1alpha1.RBACConfig) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withData(kv ...string) map[string]string {
+	data := map[string]string{}
+	for k, v := range testData {
+		data[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		data[kv[i]] = kv[i+1]
+	}
+	return data
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.RBACConfig
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, testData)},
+				cr:   RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: RBACConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"FormattingIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, withData(settings.PolicyCSVKey, `# deployers
+p,role:deployer,applications,sync,default/*,allow
+
+  p, role:deployer, applications, delete, *, deny
+g, my-org:deployers,   role:deployer`))},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: RBACConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ExternalCSVEdit": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, withData(settings.PolicyCSVKey,
+					testPolicyCSV+"p, role:deployer, applications, delete, default/*, allow\n"))},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: RBACConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"PolicyDefaultChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, withData(settings.PolicyDefaultKey, "role:admin"))},
+				cr:   RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: RBACConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ScopesChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, withData(settings.ScopesKey, "[groups]"))},
+				cr:   RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr: RBACConfig(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, nil)},
+				cr:   RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr:     RBACConfig(withSpec(testParams)),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"InvalidPolicy": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, testData)},
+				cr:   RBACConfig(withSpec(v1alpha1.RBACConfigParameters{Policies: []string{"p, role:deployer, applications, sync"}})),
+			},
+			want: want{
+				cr:  RBACConfig(withSpec(v1alpha1.RBACConfigParameters{Policies: []string{"p, role:deployer, applications, sync"}})),
+				err: errors.Wrapf(errors.New(errPolicyFields), errFmtInvalidPolicy, "p, role:deployer, applications, sync"),
+			},
+		},
+		"GetConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr:   RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				cr:  RBACConfig(withSpec(testParams)),
+				err: errors.Wrap(errBoom, "cannot get ArgoCD settings ConfigMap"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"InitializesSettings": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(testData),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"KeepsOtherSettings": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, map[string]string{"policy.matchMode": "glob"}),
+					MockUpdate: settingstest.ExpectConfigMapData(withData("policy.matchMode", "glob")),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	withBinding := testParams
+	withBinding.GroupBindings = append([]v1alpha1.RBACGroupBinding{}, testParams.GroupBindings...)
+	withBinding.GroupBindings = append(withBinding.GroupBindings, v1alpha1.RBACGroupBinding{Subject: "jane@example.com", Role: "role:deployer"})
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"AddsRoleBinding": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, withData()),
+					MockUpdate: settingstest.ExpectConfigMapData(withData(settings.PolicyCSVKey,
+						testPolicyCSV+"g, jane@example.com, role:deployer\n")),
+				},
+				cr: RBACConfig(withSpec(withBinding)),
+			},
+			want: want{},
+		},
+		"RevertsExternalCSVEdit": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, withData(settings.PolicyCSVKey, "p, role:deployer, *, *, */*, allow\n")),
+					MockUpdate: settingstest.ExpectConfigMapData(testData),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesSettings": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, withData("policy.matchMode", "glob")),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{"policy.matchMode": "glob"}),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"KeepsUnmanagedSettings": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.RBACConfigMapName, testData),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{settings.PolicyDefaultKey: "role:readonly", settings.ScopesKey: "[groups, email]"}),
+				},
+				cr: RBACConfig(withSpec(v1alpha1.RBACConfigParameters{Roles: testParams.Roles})),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.RBACConfigMapName, map[string]string{"policy.matchMode": "glob"}),
+				},
+				cr: RBACConfig(withSpec(testParams)),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	cases := map[string]struct {
+		policy string
+		want   error
+	}{
+		"ValidPolicy": {
+			policy: "p, role:deployer, applications, sync, */*, allow",
+		},
+		"ValidGroup": {
+			policy: "g, my-org:deployers, role:deployer",
+		},
+		"UnknownType": {
+			policy: "x, role:deployer, applications",
+			want:   errors.Wrapf(errors.New(errPolicyType), errFmtInvalidPolicy, "x, role:deployer, applications"),
+		},
+		"MissingEffect": {
+			policy: "p, role:deployer, applications, sync, */*",
+			want:   errors.Wrapf(errors.New(errPolicyFields), errFmtInvalidPolicy, "p, role:deployer, applications, sync, */*"),
+		},
+		"InvalidEffect": {
+			policy: "p, role:deployer, applications, sync, */*, grant",
+			want:   errors.Wrapf(errors.New(errPolicyEffect), errFmtInvalidPolicy, "p, role:deployer, applications, sync, */*, grant"),
+		},
+		"GroupWithoutRole": {
+			policy: "g, my-org:deployers",
+			want:   errors.Wrapf(errors.New(errGroupFields), errFmtInvalidPolicy, "g, my-org:deployers"),
+		},
+		"EmptyField": {
+			policy: "g, , role:deployer",
+			want:   errors.Wrapf(errors.New(errEmptyField), errFmtInvalidPolicy, "g, , role:deployer"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := validatePolicy(tc.policy)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("validatePolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacconfigs
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+)
+
+const (
+	rolePrefix    = "role:"
+	defaultObject = "*"
+	effectAllow   = "allow"
+	effectDeny    = "deny"
+
+	errFmtInvalidPolicy = "invalid policy %q"
+	errPolicyType       = "must start with p or g"
+	errPolicyFields     = "p policies must have the fields subject, resource, action, object and effect"
+	errGroupFields      = "g policies must have the fields subject and role"
+	errPolicyEffect     = "effect must be allow or deny"
+	errEmptyField       = "must not have empty fields"
+)
+
+// renderPolicyCSV renders the roles, group bindings and policies of p into
+// the policy.csv format of ArgoCD.
+func renderPolicyCSV(p v1alpha1.RBACConfigParameters) (string, error) {
+	var lines []string
+	for _, r := range p.Roles {
+		for _, rp := range r.Policies {
+			lines = append(lines, joinFields("p", roleName(r.Name), rp.Resource, rp.Action,
+				ptr.Deref(rp.Object, defaultObject), ptr.Deref(rp.Effect, effectAllow)))
+		}
+	}
+	for _, b := range p.GroupBindings {
+		lines = append(lines, joinFields("g", b.Subject, roleName(b.Role)))
+	}
+	for _, l := range p.Policies {
+		if err := validatePolicy(l); err != nil {
+			return "", err
+		}
+		lines = append(lines, joinFields(splitFields(l)...))
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// validatePolicy checks that l is a p or g line of the ArgoCD policy.csv format.
+func validatePolicy(l string) error {
+	fields := splitFields(l)
+	for _, f := range fields {
+		if f == "" {
+			return errors.Wrapf(errors.New(errEmptyField), errFmtInvalidPolicy, l)
+		}
+	}
+	switch fields[0] {
+	case "p":
+		if len(fields) != 6 {
+			return errors.Wrapf(errors.New(errPolicyFields), errFmtInvalidPolicy, l)
+		}
+		if fields[5] != effectAllow && fields[5] != effectDeny {
+			return errors.Wrapf(errors.New(errPolicyEffect), errFmtInvalidPolicy, l)
+		}
+	case "g":
+		if len(fields) != 3 {
+			return errors.Wrapf(errors.New(errGroupFields), errFmtInvalidPolicy, l)
+		}
+	default:
+		return errors.Wrapf(errors.New(errPolicyType), errFmtInvalidPolicy, l)
+	}
+	return nil
+}
+
+// normalizePolicyCSV returns the policies of csv with the fields of each
+// policy separated by ", ". Empty lines and comments are dropped, so that
+// only changes to the policies themselves are reported.
+func normalizePolicyCSV(csv string) []string {
+	var lines []string
+	for _, l := range strings.Split(csv, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		lines = append(lines, joinFields(splitFields(l)...))
+	}
+	return lines
+}
+
+// renderScopes renders scopes into the list format of the scopes setting,
+// e.g. [groups, email].
+func renderScopes(scopes []string) string {
+	return "[" + strings.Join(scopes, ", ") + "]"
+}
+
+// parseScopes parses the scopes setting. A setting which cannot be parsed
+// yields no scopes.
+func parseScopes(s string) []string {
+	var scopes []string
+	if err := yaml.Unmarshal([]byte(s), &scopes); err != nil {
+		return nil
+	}
+	return scopes
+}
+
+func roleName(name string) string {
+	if strings.HasPrefix(name, rolePrefix) {
+		return name
+	}
+	return rolePrefix + name
+}
+
+func splitFields(l string) []string {
+	fields := strings.Split(l, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+func joinFields(fields ...string) string {
+	return strings.Join(fields, ", ")
+}
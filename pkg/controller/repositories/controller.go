@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v2/util/io"
@@ -28,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,10 +37,12 @@ import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/repositories"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
@@ -53,6 +57,12 @@ const (
 	errDeleteFailed     = "cannot delete Argocd repository"
 	errGetSecretFailed  = "cannot get Kubernetes secret"
 	errFmtKeyNotFound   = "key %s is not found in referenced Kubernetes secret"
+	errListRepoCreds    = "cannot list Argocd repository credential templates"
+	errListFailed       = "cannot list Argocd repositories to adopt an existing repository"
+
+	errAzureWorkloadIdentityUnsupported = "useAzureWorkloadIdentity is not supported by the ArgoCD client of the provider"
+	errGithubAppIncomplete              = "githubAppID and githubAppInstallationID must both be set to use GitHub App authentication"
+	errFmtUnsupportedType               = "unsupported repository type %q, must be git or helm"
 )
 
 // SetupRepository adds a controller that reconciles repositories.
@@ -60,8 +70,13 @@ func SetupRepository(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.RepositoryKind)
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: repositories.NewRepositoryServiceClient}),
+		managed.WithExternalConnecter(&connector{
+			kube:                 mgr.GetClient(),
+			newArgocdClientFn:    repositories.NewRepositoryServiceClient,
+			newRepoCredsClientFn: repositories.NewRepoCredsServiceClient,
+		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 	}
 	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
@@ -69,16 +84,17 @@ func SetupRepository(mgr ctrl.Manager, o xpcontroller.Options) error {
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Repository{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.RepositoryGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
-	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, repository.RepositoryServiceClient)
-	conn              io.Closer
+	kube                 client.Client
+	newArgocdClientFn    func(clientOpts *apiclient.ClientOptions) (io.Closer, repositories.RepositoryServiceClient)
+	newRepoCredsClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, repositories.RepoCredsServiceClient)
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -86,22 +102,33 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotRepository)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, _ *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		if ns := repositories.DeclarativeNamespace(cr); ns != "" {
+			// the repository is reconciled through its repository secret, ArgoCD isn't called
+			return &external{kube: c.kube, client: repositories.NewSecretClient(c.kube, ns)}, io.NopCloser
+		}
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		ext := &external{kube: c.kube, client: argocdClient}
+
+		// the credential templates are only needed to observe which one a repository
+		// without credentials of its own inherits them from
+		if c.newRepoCredsClientFn == nil || hasCredentials(&cr.Spec.ForProvider) {
+			return ext, conn
+		}
+		credsConn, credsClient := c.newRepoCredsClientFn(cfg)
+		ext.repoCredsClient = credsClient
+		return ext, clients.Closers{conn, credsConn}
+	})
 	if err != nil {
 		return nil, err
 	}
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
-
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+	return clients.WithPauseHandling(clients.WithLastErrorRecording(clients.WithPermissionHandling(clients.WithAlreadyExistsHandling(fc), v1alpha1.RepositoryKind))), nil
 }
 
 type external struct {
-	kube   client.Client
-	client repositories.RepositoryServiceClient
+	kube            client.Client
+	client          repositories.RepositoryServiceClient
+	repoCredsClient repositories.RepoCredsServiceClient
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -110,29 +137,37 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRepository)
 	}
 
-	if meta.GetExternalName(cr) == "" {
-		return managed.ExternalObservation{
-			ResourceExists: false,
-		}, nil
-	}
-
-	repoQuery := repository.RepoQuery{
-		Repo: meta.GetExternalName(cr),
-	}
-
-	observedRepository, err := e.client.Get(ctx, &repoQuery)
-
-	if err != nil && repositories.IsErrorPermissionDenied(err) || repositories.IsErrorRepositoryNotFound(err) {
-		return managed.ExternalObservation{
-			ResourceExists: false,
-		}, nil
+	var observedRepository *argocdv1alpha1.Repository
+	if name := meta.GetExternalName(cr); name != "" {
+		repoQuery := repository.RepoQuery{
+			Repo: name,
+		}
+		r, err := e.client.Get(ctx, &repoQuery)
+		if err != nil && !repositories.IsErrorPermissionDenied(err) && !repositories.IsErrorRepositoryNotFound(err) {
+			return managed.ExternalObservation{}, err
+		}
+		if err == nil {
+			observedRepository = r
+		}
 	}
 
-	if err != nil {
-		return managed.ExternalObservation{}, err
+	adopted := false
+	if observedRepository == nil {
+		existing, err := e.findExistingRepository(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if existing == nil {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		// repositories are keyed by URL, bind the resource to the existing repository
+		meta.SetExternalName(cr, existing.Repo)
+		observedRepository, adopted = existing, true
 	}
 
-	resourceVersions, err := e.getSecretResource(ctx, cr)
+	secrets, err := e.getSecretResource(ctx, cr)
 
 	if err != nil {
 		return managed.ExternalObservation{}, err
@@ -141,22 +176,48 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	current := cr.Spec.ForProvider.DeepCopy()
 	lateInitializeRepository(&cr.Spec.ForProvider, observedRepository)
 
-	currentStatusAtProvider := cr.Status.AtProvider.DeepCopy()
-	cr.Status.AtProvider = generateRepositoryObservation(observedRepository, resourceVersions)
+	upToDate := isRepositoryUpToDate(cr, secrets, observedRepository)
+	cr.Status.AtProvider = generateRepositoryObservation(observedRepository, secrets, &cr.Status.AtProvider)
+	if err := e.observeCredentialTemplate(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	cr.Status.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isRepositoryUpToDate(cr, currentStatusAtProvider, observedRepository),
-		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: adopted || !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
 
+// findExistingRepository returns the repository of the ArgoCD server whose URL matches the
+// external name or the repo of cr, or nil if there is none. The external name defaults to the
+// name of cr, which only matches if it was set to the URL of the repository to import.
+func (e *external) findExistingRepository(ctx context.Context, cr *v1alpha1.Repository) (*argocdv1alpha1.Repository, error) {
+	list, err := e.client.ListRepositories(ctx, &repository.RepoQuery{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListFailed)
+	}
+	return repositories.MatchRepository(list.Items, meta.GetExternalName(cr), cr.Spec.ForProvider.Repo), nil
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) { // nolint:gocyclo
 	cr, ok := mg.(*v1alpha1.Repository)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRepository)
 	}
+	if ptr.Deref(cr.Spec.ForProvider.UseAzureWorkloadIdentity, false) {
+		return managed.ExternalCreation{}, errors.New(errAzureWorkloadIdentityUnsupported)
+	}
+	if err := validateGithubApp(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if cr.Spec.ForProvider.Type == nil {
+		cr.Spec.ForProvider.Type = ptr.To(repositories.InferType(cr.Spec.ForProvider.Repo, ptr.Deref(cr.Spec.ForProvider.EnableOCI, false)))
+	}
+	if err := validateType(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
 	repoCreateRequest := generateCreateRepositoryOptions(&cr.Spec.ForProvider)
 
@@ -200,6 +261,7 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
+	recordAppliedSecrets(&cr.Status.AtProvider, repoCreateRequest.Repo)
 
 	meta.SetExternalName(cr, cr.Spec.ForProvider.Repo)
 
@@ -211,6 +273,15 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotRepository)
 	}
+	if ptr.Deref(cr.Spec.ForProvider.UseAzureWorkloadIdentity, false) {
+		return managed.ExternalUpdate{}, errors.New(errAzureWorkloadIdentityUnsupported)
+	}
+	if err := validateGithubApp(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := validateType(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
 	repoUpdateRequest := generateUpdateRepositoryOptions(&cr.Spec.ForProvider)
 
@@ -254,6 +325,7 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 	}
+	recordAppliedSecrets(&cr.Status.AtProvider, repoUpdateRequest.Repo)
 
 	return managed.ExternalUpdate{}, nil
 }
@@ -272,6 +344,35 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return errors.Wrap(err, errDeleteFailed)
 }
 
+// hasCredentials returns whether p references credentials of its own. A username alone is
+// no credential, it may be completed by the password of a credential template.
+func hasCredentials(p *v1alpha1.RepositoryParameters) bool {
+	return p.PasswordRef != nil || p.SSHPrivateKeyRef != nil || p.TLSClientCertDataRef != nil || p.GithubAppPrivateKeyRef != nil
+}
+
+// observeCredentialTemplate reports whether the repository of cr inherits its credentials from a
+// credential template, which ArgoCD does for a repository without credentials of its own if the
+// URL of a template is a prefix of the repository URL.
+func (e *external) observeCredentialTemplate(ctx context.Context, cr *v1alpha1.Repository) error {
+	if e.repoCredsClient == nil {
+		return nil
+	}
+	if hasCredentials(&cr.Spec.ForProvider) {
+		cr.Status.AtProvider.InheritedCredentials = ptr.To(false)
+		return nil
+	}
+	templates, err := e.repoCredsClient.ListRepositoryCredentials(ctx, &repocreds.RepoCredsQuery{})
+	if err != nil {
+		return errors.Wrap(err, errListRepoCreds)
+	}
+	t := repositories.MatchCredentialTemplate(cr.Spec.ForProvider.Repo, templates.Items)
+	cr.Status.AtProvider.InheritedCredentials = ptr.To(t != nil)
+	if t != nil {
+		cr.Status.AtProvider.CredentialTemplate = ptr.To(t.URL)
+	}
+	return nil
+}
+
 func lateInitializeRepository(p *v1alpha1.RepositoryParameters, r *argocdv1alpha1.Repository) { // nolint:gocyclo
 	if r == nil {
 		return
@@ -299,19 +400,22 @@ func lateInitializeRepository(p *v1alpha1.RepositoryParameters, r *argocdv1alpha
 	p.GitHubAppEnterpriseBaseURL = clients.LateInitializeStringPtr(p.GitHubAppEnterpriseBaseURL, r.GitHubAppEnterpriseBaseURL)
 }
 
-type secretResourceVersion struct {
-	Password string
+// secretObservations holds the observed state of the secrets referenced by a repository
+type secretObservations struct {
+	Password v1alpha1.SecretObservation
 
-	SSHPrivateKey string
+	SSHPrivateKey v1alpha1.SecretObservation
 
-	TLSClientCertData string
+	TLSClientCertData v1alpha1.SecretObservation
 
-	TLSClientCertKey string
+	TLSClientCertKey v1alpha1.SecretObservation
 
-	GithubAppPrivateKey string
+	GithubAppPrivateKey v1alpha1.SecretObservation
 }
 
-func generateRepositoryObservation(r *argocdv1alpha1.Repository, secretResourceVersion secretResourceVersion) v1alpha1.RepositoryObservation {
+// generateRepositoryObservation observes r and the referenced secrets. The hashes of the
+// secret values last applied to the repository are kept from applied.
+func generateRepositoryObservation(r *argocdv1alpha1.Repository, secrets secretObservations, applied *v1alpha1.RepositoryObservation) v1alpha1.RepositoryObservation {
 	if r == nil {
 		return v1alpha1.RepositoryObservation{}
 	}
@@ -321,37 +425,68 @@ func generateRepositoryObservation(r *argocdv1alpha1.Repository, secretResourceV
 			Message:    r.ConnectionState.Message,
 			ModifiedAt: r.ConnectionState.ModifiedAt,
 		},
+		Password:            observeSecret(secrets.Password, applied.Password),
+		SSHPrivateKey:       observeSecret(secrets.SSHPrivateKey, applied.SSHPrivateKey),
+		TLSClientCertData:   observeSecret(secrets.TLSClientCertData, applied.TLSClientCertData),
+		TLSClientCertKey:    observeSecret(secrets.TLSClientCertKey, applied.TLSClientCertKey),
+		GithubAppPrivateKey: observeSecret(secrets.GithubAppPrivateKey, applied.GithubAppPrivateKey),
 	}
+	return o
+}
 
-	if secretResourceVersion.Password != "" {
-		o.Password = &v1alpha1.PasswordObservation{
-			Secret: v1alpha1.SecretObservation{ResourceVersion: secretResourceVersion.Password},
-		}
+func observeSecret(s v1alpha1.SecretObservation, applied *v1alpha1.PasswordObservation) *v1alpha1.PasswordObservation {
+	if s.ResourceVersion == "" {
+		return nil
+	}
+	return &v1alpha1.PasswordObservation{
+		Secret: v1alpha1.SecretObservation{ResourceVersion: s.ResourceVersion, Hash: appliedHash(applied)},
 	}
+}
 
-	if secretResourceVersion.SSHPrivateKey != "" {
-		o.SSHPrivateKey = &v1alpha1.PasswordObservation{
-			Secret: v1alpha1.SecretObservation{ResourceVersion: secretResourceVersion.SSHPrivateKey},
-		}
+func appliedHash(o *v1alpha1.PasswordObservation) string {
+	if o == nil {
+		return ""
 	}
+	return o.Secret.Hash
+}
 
-	if secretResourceVersion.TLSClientCertData != "" {
-		o.TLSClientCertData = &v1alpha1.PasswordObservation{
-			Secret: v1alpha1.SecretObservation{ResourceVersion: secretResourceVersion.TLSClientCertData},
-		}
+// validateGithubApp checks that p names both the GitHub App and its installation
+// whenever any of the GitHub App credentials are given
+func validateGithubApp(p *v1alpha1.RepositoryParameters) error {
+	if p.GithubAppID == nil && p.GithubAppInstallationID == nil && p.GithubAppPrivateKeyRef == nil {
+		return nil
 	}
+	if p.GithubAppID == nil || p.GithubAppInstallationID == nil {
+		return errors.New(errGithubAppIncomplete)
+	}
+	return nil
+}
 
-	if secretResourceVersion.TLSClientCertKey != "" {
-		o.TLSClientCertKey = &v1alpha1.PasswordObservation{
-			Secret: v1alpha1.SecretObservation{ResourceVersion: secretResourceVersion.TLSClientCertKey},
-		}
+// validateType checks that ArgoCD supports the type of p, if it is set
+func validateType(p *v1alpha1.RepositoryParameters) error {
+	if p.Type != nil && !repositories.IsSupportedType(*p.Type) {
+		return errors.Errorf(errFmtUnsupportedType, *p.Type)
 	}
+	return nil
+}
 
-	if secretResourceVersion.GithubAppPrivateKey != "" {
-		o.GithubAppPrivateKey = &v1alpha1.PasswordObservation{
-			Secret: v1alpha1.SecretObservation{ResourceVersion: secretResourceVersion.GithubAppPrivateKey},
-		}
+// recordAppliedSecrets records the hashes of the secret values applied with repo in o
+func recordAppliedSecrets(o *v1alpha1.RepositoryObservation, repo *argocdv1alpha1.Repository) {
+	o.Password = recordAppliedSecret(o.Password, repo.Password, clients.Hash)
+	o.SSHPrivateKey = recordAppliedSecret(o.SSHPrivateKey, repo.SSHPrivateKey, clients.Hash)
+	o.TLSClientCertData = recordAppliedSecret(o.TLSClientCertData, repo.TLSClientCertData, clients.HashTLSData)
+	o.TLSClientCertKey = recordAppliedSecret(o.TLSClientCertKey, repo.TLSClientCertKey, clients.HashTLSData)
+	o.GithubAppPrivateKey = recordAppliedSecret(o.GithubAppPrivateKey, repo.GithubAppPrivateKey, clients.Hash)
+}
+
+func recordAppliedSecret(o *v1alpha1.PasswordObservation, value string, hash func([]byte) string) *v1alpha1.PasswordObservation {
+	if value == "" {
+		return nil
 	}
+	if o == nil {
+		o = &v1alpha1.PasswordObservation{}
+	}
+	o.Secret.Hash = hash([]byte(value))
 	return o
 }
 
@@ -392,6 +527,9 @@ func generateCreateRepositoryOptions(p *v1alpha1.RepositoryParameters) *reposito
 	if p.GitHubAppEnterpriseBaseURL != nil {
 		repo.GitHubAppEnterpriseBaseURL = *p.GitHubAppEnterpriseBaseURL
 	}
+	if p.ForceHTTPBasicAuth != nil {
+		repo.ForceHttpBasicAuth = *p.ForceHTTPBasicAuth
+	}
 
 	repoCreateRequest := &repository.RepoCreateRequest{
 		Repo:      repo,
@@ -432,6 +570,9 @@ func generateUpdateRepositoryOptions(p *v1alpha1.RepositoryParameters) *reposito
 	if p.GitHubAppEnterpriseBaseURL != nil {
 		repo.GitHubAppEnterpriseBaseURL = *p.GitHubAppEnterpriseBaseURL
 	}
+	if p.ForceHTTPBasicAuth != nil {
+		repo.ForceHttpBasicAuth = *p.ForceHTTPBasicAuth
+	}
 
 	o := &repository.RepoUpdateRequest{
 		Repo: repo,
@@ -439,7 +580,7 @@ func generateUpdateRepositoryOptions(p *v1alpha1.RepositoryParameters) *reposito
 	return o
 }
 
-func isRepositoryUpToDate(rr *v1alpha1.Repository, o *v1alpha1.RepositoryObservation, r *argocdv1alpha1.Repository) bool { // nolint:gocyclo
+func isRepositoryUpToDate(rr *v1alpha1.Repository, secrets secretObservations, r *argocdv1alpha1.Repository) bool { // nolint:gocyclo
 	p := rr.Spec.ForProvider
 	if !cmp.Equal(p.Username, clients.StringToPtr(r.Username)) {
 		return false
@@ -471,29 +612,36 @@ func isRepositoryUpToDate(rr *v1alpha1.Repository, o *v1alpha1.RepositoryObserva
 	if !cmp.Equal(p.GitHubAppEnterpriseBaseURL, clients.StringToPtr(r.GitHubAppEnterpriseBaseURL)) {
 		return false
 	}
-	if !cmp.Equal(rr.Status.AtProvider.Password, o.Password) {
+	if p.ForceHTTPBasicAuth != nil && *p.ForceHTTPBasicAuth != r.ForceHttpBasicAuth {
+		return false
+	}
+	// Never up to date so that Update reports that the setting is not supported.
+	if ptr.Deref(p.UseAzureWorkloadIdentity, false) {
+		return false
+	}
+	if appliedHash(rr.Status.AtProvider.Password) != secrets.Password.Hash {
 		return false
 	}
-	if !cmp.Equal(rr.Status.AtProvider.SSHPrivateKey, o.SSHPrivateKey) {
+	if appliedHash(rr.Status.AtProvider.SSHPrivateKey) != secrets.SSHPrivateKey.Hash {
 		return false
 	}
-	if !cmp.Equal(rr.Status.AtProvider.TLSClientCertData, o.TLSClientCertData) {
+	if appliedHash(rr.Status.AtProvider.TLSClientCertData) != secrets.TLSClientCertData.Hash {
 		return false
 	}
-	if !cmp.Equal(rr.Status.AtProvider.TLSClientCertKey, o.TLSClientCertKey) {
+	if appliedHash(rr.Status.AtProvider.TLSClientCertKey) != secrets.TLSClientCertKey.Hash {
 		return false
 	}
-	if !cmp.Equal(rr.Status.AtProvider.GithubAppPrivateKey, o.GithubAppPrivateKey) {
+	if appliedHash(rr.Status.AtProvider.GithubAppPrivateKey) != secrets.GithubAppPrivateKey.Hash {
 		return false
 	}
 
 	return true
 }
 
-// fetch resource version from a SecretRef so that we can track any updates
-func (e *external) getSecretResourceVersion(ctx context.Context, ref *v1alpha1.SecretReference) (string, error) {
+// fetch resource version and value hash from a SecretRef so that we can track any updates
+func (e *external) getSecretObservation(ctx context.Context, ref *v1alpha1.SecretReference, hash func([]byte) string) (v1alpha1.SecretObservation, error) {
 	if ref == nil {
-		return "", nil
+		return v1alpha1.SecretObservation{}, nil
 	}
 	nn := types.NamespacedName{
 		Name:      ref.Name,
@@ -501,9 +649,13 @@ func (e *external) getSecretResourceVersion(ctx context.Context, ref *v1alpha1.S
 	}
 	sc := &corev1.Secret{}
 	if err := e.kube.Get(ctx, nn, sc); err != nil {
-		return "", errors.Wrap(err, errGetSecretFailed)
+		return v1alpha1.SecretObservation{}, errors.Wrap(err, errGetSecretFailed)
+	}
+	o := v1alpha1.SecretObservation{ResourceVersion: sc.GetResourceVersion()}
+	if ref.Key != "" {
+		o.Hash = hash(sc.Data[ref.Key])
 	}
-	return sc.GetResourceVersion(), nil
+	return o, nil
 }
 
 // fetch kubernetes secret payload
@@ -528,34 +680,33 @@ func (e *external) getPayload(ctx context.Context, ref *v1alpha1.SecretReference
 	return nil, nil
 }
 
-func (e *external) getSecretResource(ctx context.Context, cr *v1alpha1.Repository) (secretResourceVersion, error) {
-	passwordSecretResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.PasswordRef)
+func (e *external) getSecretResource(ctx context.Context, cr *v1alpha1.Repository) (secretObservations, error) {
+	password, err := e.getSecretObservation(ctx, cr.Spec.ForProvider.PasswordRef, clients.Hash)
 	if err != nil {
-		return secretResourceVersion{}, err
+		return secretObservations{}, err
 	}
-	sshPrivateKeyResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.SSHPrivateKeyRef)
+	sshPrivateKey, err := e.getSecretObservation(ctx, cr.Spec.ForProvider.SSHPrivateKeyRef, clients.Hash)
 	if err != nil {
-		return secretResourceVersion{}, err
+		return secretObservations{}, err
 	}
-	tlsClientCertDataResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.TLSClientCertDataRef)
+	tlsClientCertData, err := e.getSecretObservation(ctx, cr.Spec.ForProvider.TLSClientCertDataRef, clients.HashTLSData)
 	if err != nil {
-		return secretResourceVersion{}, err
+		return secretObservations{}, err
 	}
-	tlsClientCertKeyResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.TLSClientCertKeyRef)
+	tlsClientCertKey, err := e.getSecretObservation(ctx, cr.Spec.ForProvider.TLSClientCertKeyRef, clients.HashTLSData)
 	if err != nil {
-		return secretResourceVersion{}, err
+		return secretObservations{}, err
 	}
-	githubAppPrivateKeyResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.GithubAppPrivateKeyRef)
+	githubAppPrivateKey, err := e.getSecretObservation(ctx, cr.Spec.ForProvider.GithubAppPrivateKeyRef, clients.Hash)
 	if err != nil {
-		return secretResourceVersion{}, err
+		return secretObservations{}, err
 	}
 
-	return secretResourceVersion{
-		Password:            passwordSecretResourceVersion,
-		SSHPrivateKey:       sshPrivateKeyResourceVersion,
-		TLSClientCertData:   tlsClientCertDataResourceVersion,
-		TLSClientCertKey:    tlsClientCertKeyResourceVersion,
-		GithubAppPrivateKey: githubAppPrivateKeyResourceVersion,
+	return secretObservations{
+		Password:            password,
+		SSHPrivateKey:       sshPrivateKey,
+		TLSClientCertData:   tlsClientCertData,
+		TLSClientCertKey:    tlsClientCertKey,
+		GithubAppPrivateKey: githubAppPrivateKey,
 	}, nil
-
 }
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefilters
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/features"
+)
+
+const (
+	errNotResourceFilter = "managed resource is not a Argocd resource filter custom resource"
+	errUpdateConfigMap   = "cannot update ArgoCD settings ConfigMap"
+)
+
+// SetupResourceFilter adds a controller that reconciles resource filters.
+func SetupResourceFilter(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.ResourceFilterKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), reader: mgr.GetAPIReader()}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(5 * time.Minute),
+	}
+
+	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ResourceFilter{}).
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ResourceFilterGroupVersionKind),
+			opts...), o.GlobalRateLimiter))
+}
+
+// The resource exclusions and inclusions are stored in the argocd-cm ConfigMap, see package settings.
+type connector struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ResourceFilter); !ok {
+		return nil, errors.New(errNotResourceFilter)
+	}
+	if err := clients.TrackProviderConfigUsage(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	if err := settings.CheckInCluster(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	return clients.WithPauseHandling(&external{kube: c.kube, reader: c.reader}), nil
+}
+
+type external struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ResourceFilter)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotResourceFilter)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired := managedFilters(cr.Spec.ForProvider)
+	if !hasManagedKeys(cm, desired) {
+		return managed.ExternalObservation{}, nil
+	}
+	upToDate, err := isResourceFilterUpToDate(desired, cm)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ResourceFilter)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotResourceFilter)
+	}
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ResourceFilter)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotResourceFilter)
+	}
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ResourceFilter)
+	if !ok {
+		return errors.New(errNotResourceFilter)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	desired := managedFilters(cr.Spec.ForProvider)
+	if len(desired) == 0 || !hasManagedKeys(cm, desired) {
+		return nil
+	}
+	for key := range desired {
+		delete(cm.Data, key)
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func (e *external) apply(ctx context.Context, cr *v1alpha1.ResourceFilter) error {
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for key, frs := range managedFilters(cr.Spec.ForProvider) {
+		v, err := settings.RenderResourceFilter(key, frs)
+		if err != nil {
+			return err
+		}
+		cm.Data[key] = v
+	}
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+// managedFilters returns the desired entries of the resource filter settings managed for p by key.
+// Settings whose parameter is not set are left untouched and are not returned.
+func managedFilters(p v1alpha1.ResourceFilterParameters) map[string][]settings.FilteredResource {
+	filters := map[string][]settings.FilteredResource{}
+	if p.Exclusions != nil {
+		filters[settings.ResourceExclusionsKey] = generateFilteredResources(p.Exclusions)
+	}
+	if p.Inclusions != nil {
+		filters[settings.ResourceInclusionsKey] = generateFilteredResources(p.Inclusions)
+	}
+	return filters
+}
+
+func generateFilteredResources(in []v1alpha1.FilteredResource) []settings.FilteredResource {
+	out := make([]settings.FilteredResource, len(in))
+	for i, fr := range in {
+		out[i] = settings.FilteredResource{
+			APIGroups: fr.APIGroups,
+			Kinds:     fr.Kinds,
+			Clusters:  fr.Clusters,
+		}
+	}
+	return out
+}
+
+// hasManagedKeys reports whether cm holds any of the settings in desired. If no setting is
+// managed, there is nothing to create and the settings are considered to exist.
+func hasManagedKeys(cm *corev1.ConfigMap, desired map[string][]settings.FilteredResource) bool {
+	if len(desired) == 0 {
+		return true
+	}
+	for key := range desired {
+		if _, ok := cm.Data[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isResourceFilterUpToDate compares the parsed settings of cm with desired, so that
+// formatting changes of the YAML made outside the provider are not reported as a difference.
+func isResourceFilterUpToDate(desired map[string][]settings.FilteredResource, cm *corev1.ConfigMap) (bool, error) {
+	for key, frs := range desired {
+		if _, ok := cm.Data[key]; !ok {
+			return false, nil
+		}
+		current, err := settings.GetResourceFilter(cm, key)
+		if err != nil {
+			return false, err
+		}
+		if !cmp.Equal(frs, current, cmpopts.EquateEmpty()) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefilters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom        = errors.New("boom")
+	testExclusion  = v1alpha1.FilteredResource{APIGroups: []string{"cilium.io"}, Kinds: []string{"CiliumIdentity"}, Clusters: []string{"*"}}
+	testExclusions = `- apiGroups:
+  - cilium.io
+  clusters:
+  - '*'
+  kinds:
+  - CiliumIdentity
+`
+	testOtherExclusions = `- apiGroups:
+  - tekton.dev
+  kinds:
+  - PipelineRun
+`
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.ResourceFilter
+}
+
+func ResourceFilter(m ...ResourceFilterModifier) *v1alpha1.ResourceFilter {
+	cr := &v1alpha1.ResourceFilter{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type ResourceFilterModifier func(*v1alpha1.ResourceFilter)
+
+func withSpec(p v1alpha1.ResourceFilterParameters) ResourceFilterModifier {
+	return func(r *v1alpha1.ResourceFilter) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) ResourceFilterModifier {
+	return func(r *v1alpha1.ResourceFilter) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.ResourceFilter
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceExclusionsKey: testExclusions})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(
+					withSpec(v1alpha1.ResourceFilterParameters{
+						Exclusions: []v1alpha1.FilteredResource{testExclusion},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"FormattingIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceExclusionsKey: `
+- kinds: [CiliumIdentity]
+  apiGroups: ["cilium.io"]
+  clusters: ["*"]
+`})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(
+					withSpec(v1alpha1.ResourceFilterParameters{
+						Exclusions: []v1alpha1.FilteredResource{testExclusion},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ExclusionsChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceExclusionsKey: testOtherExclusions})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(
+					withSpec(v1alpha1.ResourceFilterParameters{
+						Exclusions: []v1alpha1.FilteredResource{testExclusion},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"InclusionsMissing": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceExclusionsKey: testExclusions})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+					Inclusions: []v1alpha1.FilteredResource{{APIGroups: []string{"apps"}}},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(
+					withSpec(v1alpha1.ResourceFilterParameters{
+						Exclusions: []v1alpha1.FilteredResource{testExclusion},
+						Inclusions: []v1alpha1.FilteredResource{{APIGroups: []string{"apps"}}},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceInclusionsKey: testOtherExclusions})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ParseFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceExclusionsKey: "kinds: Pod"})},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+				err: errors.Wrapf(errors.New("error unmarshaling JSON: while decoding JSON: json: cannot unmarshal object into Go value of type []settings.FilteredResource"), "cannot parse %s setting", settings.ResourceExclusionsKey),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"AddsExclusion": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{
+						settings.GlobalProjectsKey:     "[]",
+						settings.ResourceInclusionsKey: testOtherExclusions,
+					}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{
+						settings.GlobalProjectsKey:     "[]",
+						settings.ResourceInclusionsKey: testOtherExclusions,
+						settings.ResourceExclusionsKey: testExclusions,
+					}),
+				},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{},
+		},
+		"InitializesData": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{
+						settings.ResourceExclusionsKey: testExclusions,
+						settings.ResourceInclusionsKey: "[]\n",
+					}),
+				},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+					Inclusions: []v1alpha1.FilteredResource{},
+				})),
+			},
+			want: want{},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesManagedSettings": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{
+						settings.ResourceExclusionsKey: testExclusions,
+						settings.ResourceInclusionsKey: testOtherExclusions,
+					}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{
+						settings.ResourceInclusionsKey: testOtherExclusions,
+					}),
+				},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{settings.ResourceInclusionsKey: testOtherExclusions}),
+				},
+				cr: ResourceFilter(withSpec(v1alpha1.ResourceFilterParameters{
+					Exclusions: []v1alpha1.FilteredResource{testExclusion},
+				})),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
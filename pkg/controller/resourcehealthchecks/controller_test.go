@@ -0,0 +1,390 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcehealthchecks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom        = errors.New("boom")
+	testGroup      = "cert-manager.io"
+	testKind       = "Certificate"
+	testKey        = "resource.customizations.health.cert-manager.io_Certificate"
+	testHealthLua  = "hs = {}\nhs.status = \"Healthy\"\nreturn hs\n"
+	testHealthLua2 = "hs = {}\nhs.status = \"Progressing\"\nreturn hs\n"
+	testOtherKey   = "resource.customizations.health.Pod"
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.ResourceHealthCheck
+}
+
+func ResourceHealthCheck(m ...ResourceHealthCheckModifier) *v1alpha1.ResourceHealthCheck {
+	cr := &v1alpha1.ResourceHealthCheck{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type ResourceHealthCheckModifier func(*v1alpha1.ResourceHealthCheck)
+
+func withSpec(p v1alpha1.ResourceHealthCheckParameters) ResourceHealthCheckModifier {
+	return func(r *v1alpha1.ResourceHealthCheck) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) ResourceHealthCheckModifier {
+	return func(r *v1alpha1.ResourceHealthCheck) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func TestHealthCustomizationKey(t *testing.T) {
+	cases := map[string]struct {
+		group string
+		kind  string
+		want  string
+	}{
+		"Group": {group: testGroup, kind: testKind, want: testKey},
+		"Core":  {kind: "Pod", want: testOtherKey},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, settings.HealthCustomizationKey(tc.group, tc.kind)); diff != "" {
+				t.Errorf("HealthCustomizationKey(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.ResourceHealthCheck
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: testHealthLua})},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{
+				cr: ResourceHealthCheck(
+					withSpec(v1alpha1.ResourceHealthCheckParameters{
+						Group:     testGroup,
+						Kind:      testKind,
+						HealthLua: testHealthLua,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"TrailingNewlineIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: testHealthLua + "\n"})},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{
+				cr: ResourceHealthCheck(
+					withSpec(v1alpha1.ResourceHealthCheckParameters{
+						Group:     testGroup,
+						Kind:      testKind,
+						HealthLua: testHealthLua,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"HealthLuaChanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: testHealthLua})},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua2,
+				})),
+			},
+			want: want{
+				cr: ResourceHealthCheck(
+					withSpec(v1alpha1.ResourceHealthCheckParameters{
+						Group:     testGroup,
+						Kind:      testKind,
+						HealthLua: testHealthLua2,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testHealthLua})},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Kind: testKind,
+				})),
+			},
+			want: want{
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Kind: testKind,
+				})),
+				err: errors.Wrap(errBoom, "cannot get ArgoCD settings ConfigMap"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"AddsHealthCheck": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testHealthLua}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testOtherKey: testHealthLua, testKey: testHealthLua}),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{},
+		},
+		"InitializesData": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testKey: testHealthLua}),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{},
+		},
+		"EmptyHealthLua": {
+			args: args{
+				kube: &test.MockClient{},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: " \n",
+				})),
+			},
+			want: want{
+				err: errors.New(errEmptyHealthLua),
+			},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua,
+				})),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"ReplacesHealthLua": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: testHealthLua}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testKey: testHealthLua2}),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group:     testGroup,
+					Kind:      testKind,
+					HealthLua: testHealthLua2,
+				})),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesHealthCheck": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testHealthLua, testKey: testHealthLua}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testOtherKey: testHealthLua}),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group: testGroup,
+					Kind:  testKind,
+				})),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testHealthLua}),
+				},
+				cr: ResourceHealthCheck(withSpec(v1alpha1.ResourceHealthCheckParameters{
+					Group: testGroup,
+					Kind:  testKind,
+				})),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
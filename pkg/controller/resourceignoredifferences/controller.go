@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceignoredifferences
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/features"
+)
+
+const (
+	errNotResourceIgnoreDifference = "managed resource is not a Argocd resource ignore difference custom resource"
+	errUpdateConfigMap             = "cannot update ArgoCD settings ConfigMap"
+	errNoIgnoredFields             = "at least one of jsonPointers, jqPathExpressions or managedFieldsManagers must be set"
+)
+
+// SetupResourceIgnoreDifference adds a controller that reconciles global ignored differences.
+func SetupResourceIgnoreDifference(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.ResourceIgnoreDifferenceKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), reader: mgr.GetAPIReader()}),
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(5 * time.Minute),
+	}
+
+	if o.Features.Enabled(features.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ResourceIgnoreDifference{}).
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ResourceIgnoreDifferenceGroupVersionKind),
+			opts...), o.GlobalRateLimiter))
+}
+
+// The global ignored differences are stored in the argocd-cm ConfigMap, see package settings.
+type connector struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ResourceIgnoreDifference); !ok {
+		return nil, errors.New(errNotResourceIgnoreDifference)
+	}
+	if err := clients.TrackProviderConfigUsage(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	if err := settings.CheckInCluster(ctx, c.kube, mg); err != nil {
+		return nil, err
+	}
+	return clients.WithPauseHandling(&external{kube: c.kube, reader: c.reader}), nil
+}
+
+type external struct {
+	kube   client.Client
+	reader client.Reader
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ResourceIgnoreDifference)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotResourceIgnoreDifference)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	key := ignoreDifferencesKey(cr.Spec.ForProvider)
+	current, ok, err := settings.GetIgnoreDifferences(cm, key)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !ok {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isIgnoreDifferencesUpToDate(generateIgnoreDifferences(cr.Spec.ForProvider), current),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ResourceIgnoreDifference)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotResourceIgnoreDifference)
+	}
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ResourceIgnoreDifference)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotResourceIgnoreDifference)
+	}
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ResourceIgnoreDifference)
+	if !ok {
+		return errors.New(errNotResourceIgnoreDifference)
+	}
+
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	key := ignoreDifferencesKey(cr.Spec.ForProvider)
+	if _, ok := cm.Data[key]; !ok {
+		return nil
+	}
+	delete(cm.Data, key)
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func (e *external) apply(ctx context.Context, cr *v1alpha1.ResourceIgnoreDifference) error {
+	d := generateIgnoreDifferences(cr.Spec.ForProvider)
+	if len(d.JSONPointers) == 0 && len(d.JQPathExpressions) == 0 && len(d.ManagedFieldsManagers) == 0 {
+		return errors.New(errNoIgnoredFields)
+	}
+	cm, err := settings.GetConfigMap(ctx, e.reader, settings.Namespace(cr.Spec.ForProvider.Namespace), settings.ConfigMapName)
+	if err != nil {
+		return err
+	}
+	key := ignoreDifferencesKey(cr.Spec.ForProvider)
+	v, err := settings.RenderIgnoreDifferences(key, d)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = v
+	return errors.Wrap(e.kube.Update(ctx, cm), errUpdateConfigMap)
+}
+
+func ignoreDifferencesKey(p v1alpha1.ResourceIgnoreDifferenceParameters) string {
+	return settings.IgnoreDifferencesCustomizationKey(p.Group, p.Kind)
+}
+
+func generateIgnoreDifferences(p v1alpha1.ResourceIgnoreDifferenceParameters) settings.IgnoreDifferences {
+	return settings.IgnoreDifferences{
+		JSONPointers:          p.JSONPointers,
+		JQPathExpressions:     p.JQPathExpressions,
+		ManagedFieldsManagers: p.ManagedFieldsManagers,
+	}
+}
+
+// isIgnoreDifferencesUpToDate compares the ignored differences structurally, so that the
+// formatting of the setting is irrelevant. ArgoCD ignores every listed field, so their order
+// is irrelevant as well.
+func isIgnoreDifferencesUpToDate(desired, current settings.IgnoreDifferences) bool {
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
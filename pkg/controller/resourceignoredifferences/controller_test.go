@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceignoredifferences
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/settings/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/settings/settingstest"
+)
+
+var (
+	errBoom      = errors.New("boom")
+	testGroup    = "apps"
+	testKind     = "Deployment"
+	testKey      = "resource.customizations.ignoreDifferences.apps_Deployment"
+	testValue    = "jsonPointers:\n- /spec/replicas\nmanagedFieldsManagers:\n- kube-controller-manager\n"
+	testOtherKey = "resource.customizations.ignoreDifferences.all"
+	testParams   = v1alpha1.ResourceIgnoreDifferenceParameters{
+		Group:                 testGroup,
+		Kind:                  testKind,
+		JSONPointers:          []string{"/spec/replicas"},
+		ManagedFieldsManagers: []string{"kube-controller-manager"},
+	}
+)
+
+type args struct {
+	kube client.Client
+	cr   *v1alpha1.ResourceIgnoreDifference
+}
+
+func ResourceIgnoreDifference(m ...ResourceIgnoreDifferenceModifier) *v1alpha1.ResourceIgnoreDifference {
+	cr := &v1alpha1.ResourceIgnoreDifference{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+type ResourceIgnoreDifferenceModifier func(*v1alpha1.ResourceIgnoreDifference)
+
+func withSpec(p v1alpha1.ResourceIgnoreDifferenceParameters) ResourceIgnoreDifferenceModifier {
+	return func(r *v1alpha1.ResourceIgnoreDifference) { r.Spec.ForProvider = p }
+}
+
+func withConditions(c ...xpv1.Condition) ResourceIgnoreDifferenceModifier {
+	return func(r *v1alpha1.ResourceIgnoreDifference) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func TestIgnoreDifferencesCustomizationKey(t *testing.T) {
+	cases := map[string]struct {
+		group string
+		kind  string
+		want  string
+	}{
+		"Group": {group: testGroup, kind: testKind, want: testKey},
+		"All":   {kind: "all", want: testOtherKey},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, settings.IgnoreDifferencesCustomizationKey(tc.group, tc.kind)); diff != "" {
+				t.Errorf("IgnoreDifferencesCustomizationKey(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func withProviderConfigRef(name string) ResourceIgnoreDifferenceModifier {
+	return func(r *v1alpha1.ResourceIgnoreDifference) {
+		r.SetUID("uid")
+		r.SetProviderConfigReference(&xpv1.Reference{Name: name})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	type want struct {
+		usage string
+		err   error
+	}
+
+	cases := map[string]struct {
+		cr         *v1alpha1.ResourceIgnoreDifference
+		serverAddr string
+		want       want
+	}{
+		"TracksUsage": {
+			cr:         ResourceIgnoreDifference(withSpec(testParams), withProviderConfigRef("default")),
+			serverAddr: "argocd-server.argocd.svc:443",
+			want: want{
+				usage: "default",
+			},
+		},
+		"RemoteArgoCD": {
+			cr:         ResourceIgnoreDifference(withSpec(testParams), withProviderConfigRef("default")),
+			serverAddr: "argocd.example.com:443",
+			want: want{
+				usage: "default",
+				err:   errors.New("ArgoCD argocd.example.com:443 of ProviderConfig default is not in the cluster of the provider, its settings cannot be managed"),
+			},
+		},
+		"NoProviderConfigRef": {
+			cr: ResourceIgnoreDifference(withSpec(testParams)),
+			want: want{
+				err: errors.Wrap(errors.New("managed resource does not reference a ProviderConfig"), "cannot track ProviderConfig usage"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var usage string
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					if pc, ok := obj.(*apisv1alpha1.ProviderConfig); ok {
+						pc.Spec.ServerAddr = tc.serverAddr
+						return nil
+					}
+					return kerrors.NewNotFound(schema.GroupResource{}, "uid")
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					if pcu, ok := obj.(*apisv1alpha1.ProviderConfigUsage); ok && pcu.GetName() == "uid" {
+						usage = pcu.GetProviderConfigReference().Name
+					}
+					return nil
+				},
+			}
+			c := &connector{kube: kube, reader: kube}
+			_, err := c.Connect(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.usage, usage); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.ResourceIgnoreDifference
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: testValue})},
+				cr:   ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				cr:     ResourceIgnoreDifference(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"FormattingIgnored": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{
+					testKey: "managedFieldsManagers: [kube-controller-manager]\njsonPointers:\n  - /spec/replicas\njqPathExpressions: []\n",
+				})},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				cr:     ResourceIgnoreDifference(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"JSONPointerAdded": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testKey: "jsonPointers:\n- /spec/replicas\n"})},
+				cr:   ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				cr:     ResourceIgnoreDifference(withSpec(testParams), withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testValue})},
+				cr:   ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				cr:     ResourceIgnoreDifference(withSpec(testParams)),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr:   ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				cr:  ResourceIgnoreDifference(withSpec(testParams)),
+				err: errors.Wrap(errBoom, "cannot get ArgoCD settings ConfigMap"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"AddsCustomization": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testValue}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testOtherKey: testValue, testKey: testValue}),
+				},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"InitializesData": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testKey: testValue}),
+				},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"NoIgnoredFields": {
+			args: args{
+				kube: &test.MockClient{},
+				cr:   ResourceIgnoreDifference(withSpec(v1alpha1.ResourceIgnoreDifferenceParameters{Group: testGroup, Kind: testKind})),
+			},
+			want: want{
+				err: errors.New(errNoIgnoredFields),
+			},
+		},
+		"UpdateConfigMapFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, nil),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateConfigMap),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			got, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RemovesCustomization": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testValue, testKey: testValue}),
+					MockUpdate: settingstest.ExpectConfigMapData(map[string]string{testOtherKey: testValue}),
+				},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{},
+		},
+		"AlreadyRemoved": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: settingstest.WithConfigMapData(settings.ConfigMapName, map[string]string{testOtherKey: testValue}),
+				},
+				cr: ResourceIgnoreDifference(withSpec(testParams)),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, reader: tc.args.kube}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -13,16 +13,21 @@ import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/features"
@@ -35,6 +40,13 @@ const (
 	errCreateTokenFailed = "failed to create ArgoCD Project Token, verify permissions and token configuration"
 	errDeleteFailed      = "failed to delete ArgoCD Project Token, token may require manual cleanup"
 	errKubeUpdateFailed  = "cannot update Argocd Project Token custom resource"
+	errReadOnlyNotFound  = "read-only ArgoCD Project Token not found, set the external name to an existing token ID"
+	errReadOnlyCreate    = "cannot create a read-only ArgoCD Project Token"
+	errTokenNotConfirmed = "ArgoCD Project Token already exists but could not be found in its role"
+	errWriteTokenFailed  = "failed to write ArgoCD Project Token to the connection secret"
+
+	// connectionSecretKeyToken is the key of the token in the connection secret
+	connectionSecretKeyToken = "token"
 )
 
 // SetupToken adds a controller that reconciles tokens.
@@ -42,8 +54,9 @@ func SetupToken(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.ProjectKind)
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnectDisconnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: projects.NewProjectServiceClient}),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: projects.NewProjectServiceClient}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 	}
 
@@ -53,16 +66,16 @@ func SetupToken(mgr ctrl.Manager, o xpcontroller.Options) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Token{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.TokenGroupVersionKind),
-			opts...))
+			opts...), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, project.ProjectServiceClient)
-	conn              io.Closer
+	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) (io.Closer, projects.ProjectServiceClient)
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -70,22 +83,21 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotToken)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	fc, err := clients.ConnectWithFallback(ctx, c.kube, cr, func(cfg *apiclient.ClientOptions, _ *apisv1alpha1.ProviderConfigSpec) (managed.ExternalClient, io.Closer) {
+		conn, argocdClient := c.newArgocdClientFn(cfg)
+		return &external{kube: c.kube, client: argocdClient, tokenBackoff: projects.TokenCreateBackoff(cr, projects.DefaultTokenCreateBackoff)}, conn
+	})
 	if err != nil {
 		return nil, err
 	}
-	conn, argocdClient := c.newArgocdClientFn(cfg)
-	c.conn = conn
-	return &external{kube: c.kube, client: argocdClient}, nil
-}
-
-func (c *connector) Disconnect(ctx context.Context) error {
-	return c.conn.Close()
+	return clients.WithPauseHandling(clients.WithPermissionHandling(fc, v1alpha1.TokenKind)), nil
 }
 
 type external struct {
 	kube   client.Client
 	client projects.ProjectServiceClient
+	// tokenBackoff is the backoff between the attempts to create a token
+	tokenBackoff wait.Backoff
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -94,32 +106,26 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotToken)
 	}
 
+	readOnly := ptr.Deref(cr.Spec.ForProvider.ReadOnly, false)
+
 	if meta.GetExternalName(cr) == "" {
+		if readOnly {
+			return managed.ExternalObservation{}, errors.New(errReadOnlyNotFound)
+		}
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	projectQuery := project.ProjectQuery{
-		Name: *cr.Spec.ForProvider.Project,
-	}
-	project, err := e.client.Get(ctx, &projectQuery)
-	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGetProjectFailed)
-	}
-	roles, _, err := project.GetRoleByName(cr.Spec.ForProvider.Role)
+	token, err := e.getToken(ctx, cr, meta.GetExternalName(cr))
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGetRoleFailed)
-	}
-	var token argocdv1alpha1.JWTToken
-	for _, t := range roles.JWTTokens {
-		if t.ID == meta.GetExternalName(cr) {
-			token = t
-			break
-		}
+		return managed.ExternalObservation{}, err
 	}
 
 	if token.IssuedAt == 0 {
+		if readOnly {
+			return managed.ExternalObservation{}, errors.New(errReadOnlyNotFound)
+		}
 		return managed.ExternalObservation{}, nil
 	}
 
@@ -135,11 +141,45 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isTokenUpToDate(&cr.Spec.ForProvider, token),
+		ResourceUpToDate:        readOnly || isTokenUpToDate(&cr.Spec.ForProvider, token),
 		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
 
+// getToken returns the token with the given ID from the role of the token or an empty token if there is none
+func (e *external) getToken(ctx context.Context, cr *v1alpha1.Token, id string) (argocdv1alpha1.JWTToken, error) {
+	projectQuery := project.ProjectQuery{
+		Name: *cr.Spec.ForProvider.Project,
+	}
+	project, err := e.client.Get(ctx, &projectQuery)
+	if err != nil {
+		return argocdv1alpha1.JWTToken{}, errors.Wrap(err, errGetProjectFailed)
+	}
+	roles, _, err := project.GetRoleByName(cr.Spec.ForProvider.Role)
+	if err != nil {
+		return argocdv1alpha1.JWTToken{}, errors.Wrap(err, errGetRoleFailed)
+	}
+	for _, t := range roles.JWTTokens {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return argocdv1alpha1.JWTToken{}, nil
+}
+
+// confirmExistingToken re-reads the role and sets the external name when the token with the given ID exists
+func (e *external) confirmExistingToken(ctx context.Context, cr *v1alpha1.Token, id string) error {
+	token, err := e.getToken(ctx, cr, id)
+	if err != nil {
+		return err
+	}
+	if token.IssuedAt == 0 {
+		return errors.New(errTokenNotConfirmed)
+	}
+	meta.SetExternalName(cr, token.ID)
+	return nil
+}
+
 func lateInitializeToken(p *v1alpha1.TokenParameters, r *argocdv1alpha1.JWTToken) {
 	if p.ID == "" {
 		p.ID = r.ID
@@ -151,10 +191,17 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotToken)
 	}
+	if ptr.Deref(cr.Spec.ForProvider.ReadOnly, false) {
+		return managed.ExternalCreation{}, errors.New(errReadOnlyCreate)
+	}
 
 	expiresIn, _ := parseDuration(cr.Spec.ForProvider.ExpiresIn)
 	req := createRequest(cr, expiresIn)
-	res, err := e.client.CreateToken(ctx, req)
+	res, err := projects.CreateToken(ctx, e.client, req, e.tokenBackoff)
+	if projects.IsErrorTokenAlreadyExists(err) && req.Id != "" {
+		// another reconcile won the race, adopt its token once it is visible in the role
+		return managed.ExternalCreation{}, e.confirmExistingToken(ctx, cr, req.Id)
+	}
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTokenFailed)
 	}
@@ -171,6 +218,12 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	meta.SetExternalName(cr, claims.ID)
 
+	// ArgoCD returns the token only once. It is written to the connection secret right away,
+	// so that it survives a restart of the provider and never has to be minted again to recover it.
+	if err := e.upsertConnectionSecret(ctx, cr, []byte(token)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errWriteTokenFailed)
+	}
+
 	return managed.ExternalCreation{}, errors.Wrap(nil, errKubeUpdateFailed)
 }
 
@@ -179,6 +232,9 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotToken)
 	}
+	if ptr.Deref(cr.Spec.ForProvider.ReadOnly, false) {
+		return managed.ExternalUpdate{}, nil
+	}
 
 	reqDelete := &project.ProjectTokenDeleteRequest{
 		Project: *cr.Spec.ForProvider.Project,
@@ -192,7 +248,7 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	expiresIn, _ := parseDuration(cr.Spec.ForProvider.ExpiresIn)
 	req := createRequest(cr, expiresIn)
-	res, err := e.client.CreateToken(ctx, req)
+	res, err := projects.CreateToken(ctx, e.client, req, e.tokenBackoff)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateTokenFailed)
 	}
@@ -210,6 +266,9 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotToken)
 	}
+	if ptr.Deref(cr.Spec.ForProvider.ReadOnly, false) {
+		return nil
+	}
 
 	req := &project.ProjectTokenDeleteRequest{
 		Project: *cr.Spec.ForProvider.Project,
@@ -298,12 +357,20 @@ func (e *external) upsertConnectionSecret(ctx context.Context, token *v1alpha1.T
 		return nil
 	}
 	secret := resource.ConnectionSecretFor(token, v1alpha1.TokenGroupVersionKind)
-	secret.Data["token"] = data
+	secret.Data[connectionSecretKeyToken] = data
 	if err := e.kube.Create(ctx, secret); err != nil {
-		if kerrors.IsAlreadyExists(err) {
-			return errors.Wrapf(e.kube.Update(ctx, secret), "failed to update secret: %s", secret.Name)
+		if !kerrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create secret: %s", secret.Name)
+		}
+		// update the existing secret to keep its other connection details and resource version
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, secret); err != nil {
+			return errors.Wrapf(err, "failed to get secret: %s", secret.Name)
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
 		}
-		return errors.Wrapf(err, "failed to create secret: %s", secret.Name)
+		secret.Data[connectionSecretKeyToken] = data
+		return errors.Wrapf(e.kube.Update(ctx, secret), "failed to update secret: %s", secret.Name)
 	}
 	return nil
 }
@@ -16,8 +16,15 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/projects"
@@ -33,11 +40,15 @@ var (
 	testIssuedAt           int64 = 1
 	errBoom                      = errors.New("boom")
 	errProjectNotFound           = errors.New("code = NotFound desc = appprojects")
+	errTokenAlreadyExists        = errors.New("rpc error: code = InvalidArgument desc = Token id 'test-token' has been used. ")
 	testJWTHeaderJSON            = `{"alg":"HS256","typ":"JWT"}`
 	testJWTPayloadJSON           = `{"jti":"test-token","iss":"test-issuer"}`
+	testSecretName               = "test-token-secret"
+	testSecretNamespace          = "crossplane-system"
 )
 
 type args struct {
+	kube   client.Client
 	client projects.ProjectServiceClient
 	cr     *v1alpha1.Token
 }
@@ -79,6 +90,22 @@ func withConditions(c ...xpv1.Condition) TokenModifier {
 	return func(r *v1alpha1.Token) { r.Status.ConditionedStatus.Conditions = c }
 }
 
+func withConnectionSecret(name string) TokenModifier {
+	return func(r *v1alpha1.Token) {
+		r.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: name, Namespace: testSecretNamespace})
+	}
+}
+
+// expectSecretData returns a function which fails unless the written secret holds data.
+func expectSecretData(data map[string][]byte) func(context.Context, client.Object) error {
+	return func(_ context.Context, obj client.Object) error {
+		if diff := cmp.Diff(data, obj.(*corev1.Secret).Data); diff != "" {
+			return errors.Errorf("unexpected secret data: -want, +got:\n%s", diff)
+		}
+		return nil
+	}
+}
+
 func createTestJWTToken() string {
 	header := base64.RawURLEncoding.EncodeToString([]byte(testJWTHeaderJSON))
 	payload := base64.RawURLEncoding.EncodeToString([]byte(testJWTPayloadJSON))
@@ -159,6 +186,71 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"NoRemintAfterRestart": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					// the token is still valid in ArgoCD, so it is observed without minting a new one
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{
+									{
+										Name: testRoleName,
+										JWTTokens: []argocdv1alpha1.JWTToken{
+											{
+												IssuedAt:  testIssuedAt,
+												ExpiresAt: testExpiresInZero,
+												ID:        testTokenExternalName,
+											},
+										},
+									},
+								},
+							},
+						}, nil)
+					mcs.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Times(0)
+				}),
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.TokenObservation{
+						IssuedAt:  testIssuedAt,
+						ExpiresAt: &testExpiresInZero,
+						ID:        &testTokenExternalName,
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
 		"SuccessfulLateInitialize": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
@@ -725,6 +817,115 @@ func TestObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"ReadOnlyExpired": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{
+									{
+										Name: testRoleName,
+										JWTTokens: []argocdv1alpha1.JWTToken{
+											{
+												IssuedAt:  testIssuedAt,
+												ExpiresAt: testIssuedAt + testExpiresInOneMinute,
+												ID:        testTokenExternalName,
+											},
+										},
+									},
+								},
+							},
+						}, nil)
+				}),
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withSpec(v1alpha1.TokenParameters{
+						ID:        testTokenExternalName,
+						Project:   &testProjectName,
+						Role:      testRoleName,
+						ExpiresIn: ptr.To("1m"),
+						ReadOnly:  ptr.To(true),
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withSpec(v1alpha1.TokenParameters{
+						ID:        testTokenExternalName,
+						Project:   &testProjectName,
+						Role:      testRoleName,
+						ExpiresIn: ptr.To("1m"),
+						ReadOnly:  ptr.To(true),
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.TokenObservation{
+						IssuedAt:  testIssuedAt,
+						ExpiresAt: ptr.To(testIssuedAt + testExpiresInOneMinute),
+						ID:        &testTokenExternalName,
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"ReadOnlyNotFound": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{
+									{
+										Name:      testRoleName,
+										JWTTokens: []argocdv1alpha1.JWTToken{},
+									},
+								},
+							},
+						}, nil)
+				}),
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withSpec(v1alpha1.TokenParameters{
+						Project:  &testProjectName,
+						Role:     testRoleName,
+						ReadOnly: ptr.To(true),
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withSpec(v1alpha1.TokenParameters{
+						Project:  &testProjectName,
+						Role:     testRoleName,
+						ReadOnly: ptr.To(true),
+					}),
+				),
+				result: managed.ExternalObservation{},
+				err:    errors.New(errReadOnlyNotFound),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -828,6 +1029,132 @@ func TestCreate(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessfulWritesConnectionSecret": {
+			args: args{
+				kube: &test.MockClient{
+					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
+						return expectSecretData(map[string][]byte{"token": []byte(createTestJWTToken())})(ctx, obj)
+					},
+				},
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:   testProjectName,
+							Role:      testRoleName,
+							ExpiresIn: testExpiresInZero,
+						},
+					).Return(
+						&project.ProjectTokenResponse{
+							Token: createTestJWTToken(),
+						}, nil)
+				}),
+				cr: Token(
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulUpdatesExistingConnectionSecret": {
+			args: args{
+				kube: &test.MockClient{
+					MockCreate: test.NewMockCreateFn(kerrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, testSecretName)),
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("old-token"), "other": []byte("value")}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						return expectSecretData(map[string][]byte{"token": []byte(createTestJWTToken()), "other": []byte("value")})(ctx, obj)
+					},
+				},
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:   testProjectName,
+							Role:      testRoleName,
+							ExpiresIn: testExpiresInZero,
+						},
+					).Return(
+						&project.ProjectTokenResponse{
+							Token: createTestJWTToken(),
+						}, nil)
+				}),
+				cr: Token(
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"WriteConnectionSecretFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockCreate: test.NewMockCreateFn(errBoom),
+				},
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:   testProjectName,
+							Role:      testRoleName,
+							ExpiresIn: testExpiresInZero,
+						},
+					).Return(
+						&project.ProjectTokenResponse{
+							Token: createTestJWTToken(),
+						}, nil)
+				}),
+				cr: Token(
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withConnectionSecret(testSecretName),
+					withSpec(v1alpha1.TokenParameters{
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errors.Wrapf(errBoom, "failed to create secret: %s", testSecretName), errWriteTokenFailed),
+			},
+		},
 		"CreateError": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
@@ -856,11 +1183,144 @@ func TestCreate(t *testing.T) {
 				err:    errors.Wrap(errBoom, errCreateTokenFailed),
 			},
 		},
+		"ReadOnly": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						Project:  &testProjectName,
+						Role:     testRoleName,
+						ReadOnly: ptr.To(true),
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						Project:  &testProjectName,
+						Role:     testRoleName,
+						ReadOnly: ptr.To(true),
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.New(errReadOnlyCreate),
+			},
+		},
+		"AlreadyExists": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:   testProjectName,
+							Role:      testRoleName,
+							Id:        testTokenExternalName,
+							ExpiresIn: testExpiresInZero,
+						},
+					).Return(nil, errTokenAlreadyExists)
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{
+									{
+										Name: testRoleName,
+										JWTTokens: []argocdv1alpha1.JWTToken{
+											{
+												IssuedAt: testIssuedAt,
+												ID:       testTokenExternalName,
+											},
+										},
+									},
+								},
+							},
+						}, nil)
+				}),
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withExternalName(testTokenExternalName),
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"AlreadyExistsNotConfirmed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:   testProjectName,
+							Role:      testRoleName,
+							Id:        testTokenExternalName,
+							ExpiresIn: testExpiresInZero,
+						},
+					).Return(nil, errTokenAlreadyExists)
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{
+									{
+										Name:      testRoleName,
+										JWTTokens: []argocdv1alpha1.JWTToken{},
+									},
+								},
+							},
+						}, nil)
+				}),
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						ID:      testTokenExternalName,
+						Project: &testProjectName,
+						Role:    testRoleName,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.New(errTokenNotConfirmed),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{kube: tc.kube, client: tc.client}
 			o, err := e.Create(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -876,6 +1336,60 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateRetry(t *testing.T) {
+	errUnavailable := status.Error(codes.Unavailable, "connection refused")
+	errInvalid := status.Error(codes.InvalidArgument, "invalid expiration")
+	req := &project.ProjectTokenCreateRequest{Project: testProjectName, Role: testRoleName}
+	spec := withSpec(v1alpha1.TokenParameters{Project: &testProjectName, Role: testRoleName})
+
+	cases := map[string]struct {
+		annotations map[string]string
+		errs        []error
+		want        error
+	}{
+		"RetryOnUnavailable": {
+			errs: []error{errUnavailable, status.Error(codes.Internal, "etcd timeout"), nil},
+		},
+		"RetriesExhausted": {
+			errs: []error{errUnavailable, errUnavailable, errUnavailable},
+			want: errors.Wrap(errUnavailable, errCreateTokenFailed),
+		},
+		"NoRetryOnInvalidArgument": {
+			errs: []error{errInvalid},
+			want: errors.Wrap(errInvalid, errCreateTokenFailed),
+		},
+		"RetriesDisabled": {
+			annotations: map[string]string{projects.AnnotationKeyTokenCreateRetries: "0"},
+			errs:        []error{errUnavailable},
+			want:        errors.Wrap(errUnavailable, errCreateTokenFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mc := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+				var calls []*gomock.Call
+				for _, err := range tc.errs {
+					resp := &project.ProjectTokenResponse{Token: createTestJWTToken()}
+					if err != nil {
+						resp = nil
+					}
+					calls = append(calls, mcs.EXPECT().CreateToken(context.Background(), req).Return(resp, err).Times(1))
+				}
+				gomock.InOrder(calls...)
+			})
+			cr := Token(spec)
+			cr.SetAnnotations(tc.annotations)
+			e := &external{client: mc, tokenBackoff: projects.TokenCreateBackoff(cr, wait.Backoff{Steps: 3})}
+
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.Token
@@ -1020,6 +1534,37 @@ func TestUpdate(t *testing.T) {
 				err:    errors.Wrap(errBoom, errCreateTokenFailed),
 			},
 		},
+		"ReadOnly": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						Project:   &testProjectName,
+						Role:      testRoleName,
+						ExpiresIn: ptr.To("1m"),
+						ReadOnly:  ptr.To(true),
+					}),
+					withObservation(v1alpha1.TokenObservation{
+						ID: &testTokenExternalName,
+					}),
+				),
+			},
+			want: want{
+				cr: Token(
+					withSpec(v1alpha1.TokenParameters{
+						Project:   &testProjectName,
+						Role:      testRoleName,
+						ExpiresIn: ptr.To("1m"),
+						ReadOnly:  ptr.To(true),
+					}),
+					withObservation(v1alpha1.TokenObservation{
+						ID: &testTokenExternalName,
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {